@@ -2,11 +2,23 @@ package main
 
 import (
 	"log"
+	"net"
 	"os"
+	"pokemon-api/internal/adapters/auth"
+	"pokemon-api/internal/adapters/blobstore"
 	"pokemon-api/internal/adapters/external"
+	"pokemon-api/internal/adapters/graph"
+	"pokemon-api/internal/adapters/grpcserver"
 	"pokemon-api/internal/adapters/handlers"
+	"pokemon-api/internal/adapters/publishers"
+	"pokemon-api/internal/adapters/ratelimit"
 	"pokemon-api/internal/adapters/repositories"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
 	"pokemon-api/internal/core/services"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -29,6 +41,27 @@ func main() {
 	dbName := getEnv("DB_NAME", "pokemon_db")
 	dbPort := getEnv("DB_PORT", "5432")
 	pokeAPIBaseURL := getEnv("POKEAPI_BASE_URL", "https://pokeapi.co/api/v2")
+	pokeAPISnapshot := getEnv("POKEAPI_SNAPSHOT", "")
+	adminAPIKey := getEnv("ADMIN_API_KEY", "")
+	ipRateLimit := domain.PerMinute(getEnvInt("RATE_LIMIT_PER_IP_PER_MINUTE", 600))
+	apiRateLimit := domain.PerMinute(getEnvInt("RATE_LIMIT_PER_MINUTE", 300))
+	pokeAPIRouteRateLimit := domain.PerMinute(getEnvInt("RATE_LIMIT_POKEAPI_ROUTES_PER_MINUTE", 20))
+	outboundRateLimit := domain.PerMinute(getEnvInt("POKEAPI_RATE_LIMIT_PER_MINUTE", 100))
+	idempotencyTTL := time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour
+	eventLogSize := getEnvInt("EVENT_LOG_SIZE", 1000)
+	webhookPollInterval := time.Duration(getEnvInt("WEBHOOK_POLL_SECONDS", 5)) * time.Second
+	outboxPollInterval := time.Duration(getEnvInt("OUTBOX_POLL_MILLISECONDS", 500)) * time.Millisecond
+	logEvents := getEnv("OUTBOX_LOG_EVENTS", "false") == "true"
+	trashRetention := time.Duration(getEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+	spriteCacheDir := getEnv("SPRITE_CACHE_DIR", "sprite-cache")
+	speciesNameSyncInterval := time.Duration(getEnvInt("SPECIES_NAME_SYNC_SECONDS", 30)) * time.Second
+	trustedProxies := getEnvList("TRUSTED_PROXIES")
+	jwtConfig := auth.JWTConfig{
+		HMACSecret: getEnv("JWT_HMAC_SECRET", ""),
+		JWKSFile:   getEnv("JWT_JWKS_FILE", ""),
+		Issuer:     getEnv("JWT_ISSUER", ""),
+		Audience:   getEnv("JWT_AUDIENCE", ""),
+	}
 
 	dsn := "host=" + dbHost + " user=" + dbUser + " password=" + dbPassword + " dbname=" + dbName + " port=" + dbPort + " sslmode=disable TimeZone=UTC"
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
@@ -38,39 +71,329 @@ func main() {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	tenantRepo := repositories.NewTenantRepository(db)
+	if err := tenantRepo.(*repositories.TenantRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
 	repo := repositories.NewPokemonRepository(db)
 	pokemonRepo := repo.(*repositories.PokemonRepository)
 	if err := pokemonRepo.Migrate(); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
-	apiClient := external.NewPokeAPIClient(pokeAPIBaseURL)
-	service := services.NewPokemonService(repo, apiClient)
-	handler := handlers.NewPokemonHandler(service)
+	speciesNameRepo := repositories.NewSpeciesNameRepository(db)
+	if err := speciesNameRepo.(*repositories.SpeciesNameRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	teamRepo := repositories.NewTeamRepository(db)
+	if err := teamRepo.(*repositories.TeamRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	trainerRepo := repositories.NewTrainerRepository(db)
+	if err := trainerRepo.(*repositories.TrainerRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	battleRepo := repositories.NewBattleRepository(db)
+	if err := battleRepo.(*repositories.BattleRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	pokedexRepo := repositories.NewPokedexRepository(db)
+	if err := pokedexRepo.(*repositories.PokedexRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	annotationRepo := repositories.NewAnnotationRepository(db)
+	if err := annotationRepo.(*repositories.AnnotationRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	if err := apiKeyRepo.(*repositories.APIKeyRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	if err := idempotencyRepo.(*repositories.IdempotencyRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	outboxRepo := repositories.NewOutboxRepository(db)
+	if err := outboxRepo.(*repositories.OutboxRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	eventRepo := repositories.NewEventRepository(db)
+	if err := eventRepo.(*repositories.EventRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	webhookRepo := repositories.NewWebhookRepository(db)
+	if err := webhookRepo.(*repositories.WebhookRepository).Migrate(); err != nil {
+		log.Fatal("Failed to migrate database:", err)
+	}
+
+	var tokenVerifier ports.TokenVerifier
+	if jwtConfig.HMACSecret != "" || jwtConfig.JWKSFile != "" {
+		tokenVerifier, err = auth.NewJWTVerifier(jwtConfig)
+		if err != nil {
+			log.Fatal("Failed to configure JWT verification:", err)
+		}
+	}
+
+	// Rows stored before tenants existed, and callers that name no tenant, belong to the default tenant
+	tenantService := services.NewTenantService(tenantRepo)
+	if err := tenantService.EnsureTenant(domain.DefaultTenantID, "Default"); err != nil {
+		log.Fatal("Failed to create the default tenant:", err)
+	}
+	tenantMiddleware := handlers.NewTenantMiddleware(tenantService)
+	tenantHandler := handlers.NewTenantHandler(tenantService)
+
+	authService := services.NewAuthService(apiKeyRepo, tokenVerifier)
+	if adminAPIKey != "" {
+		if err := authService.EnsureAPIKey("bootstrap-admin", adminAPIKey, []string{domain.RoleAdmin}); err != nil {
+			log.Fatal("Failed to store admin API key:", err)
+		}
+	}
+	authMiddleware := handlers.NewAuthMiddleware(authService)
+	authHandler := handlers.NewAuthHandler(authService)
+	idempotency := handlers.NewIdempotencyMiddleware(services.NewIdempotencyService(idempotencyRepo, idempotencyTTL))
+
+	// Client requests and outbound PokeAPI calls are limited separately, so clients cannot use up the outbound budget alone
+	rateLimitStore := ratelimit.NewMemoryStore()
+	rateLimiter := handlers.NewRateLimiter(rateLimitStore)
+	apiClient := external.NewPokeAPIClient(pokeAPIBaseURL, external.WithRateLimit(ratelimit.NewMemoryStore(), outboundRateLimit))
+	// A snapshot replaces PokeAPI entirely, for environments that cannot reach it
+	if pokeAPISnapshot != "" {
+		apiClient, err = external.NewSnapshotClient(pokeAPISnapshot)
+		if err != nil {
+			log.Fatal("Failed to load the PokeAPI snapshot:", err)
+		}
+		log.Printf("Serving PokeAPI data from the snapshot at %s", pokeAPISnapshot)
+	}
+	eventService := services.NewEventService(eventRepo, eventLogSize)
+	eventHandler := handlers.NewEventHandler(eventService)
+
+	webhookService := services.NewWebhookService(webhookRepo, external.NewWebhookSender())
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	go runEvery(webhookPollInterval, "webhook delivery", webhookService.DeliverDue)
+
+	// Changes are written to the outbox with the change itself; the relay then hands them to every publisher
+	eventPublishers := []ports.EventPublisher{eventService, webhookService}
+	if logEvents {
+		eventPublishers = append(eventPublishers, publishers.NewLogPublisher(log.Default()))
+	}
+	outboxRelay := services.NewOutboxRelay(outboxRepo, eventPublishers...)
+	go runEvery(outboxPollInterval, "outbox relay", outboxRelay.RelayPending)
+
+	// Localized names are fetched a batch of species at a time until the catalog covers the national dex
+	speciesNameSync := services.NewSpeciesNameSync(speciesNameRepo, apiClient)
+	go runEvery(speciesNameSyncInterval, "species name sync", speciesNameSync.SyncNext)
+
+	service := services.NewPokemonService(repo, apiClient, speciesNameRepo)
+	annotationService := services.NewAnnotationService(annotationRepo, repo)
+	handler := handlers.NewPokemonHandler(service, annotationService)
+	annotationHandler := handlers.NewAnnotationHandler(annotationService)
+
+	spriteStore, err := blobstore.NewDiskStore(spriteCacheDir)
+	if err != nil {
+		log.Fatal("Failed to create the sprite cache:", err)
+	}
+	spriteHandler := handlers.NewSpriteHandler(services.NewSpriteService(repo, apiClient, external.NewImageFetcher(), spriteStore))
+	go runEvery(time.Hour, "trash purge", func() (int, error) {
+		return service.PurgeDeletedPokemon(trashRetention)
+	})
+
+	graphqlExecutor, err := graph.NewExecutor(service, rateLimitStore, pokeAPIRouteRateLimit)
+	if err != nil {
+		log.Fatal("Failed to build GraphQL schema:", err)
+	}
+	graphqlHandler := handlers.NewGraphQLHandler(graphqlExecutor)
+
+	grpcServer := grpcserver.NewServer(service, authService, tenantService, grpcserver.RateLimits{
+		Store:         rateLimitStore,
+		API:           apiRateLimit,
+		CreatePokemon: pokeAPIRouteRateLimit,
+	})
+
+	teamService := services.NewTeamService(teamRepo, repo, apiClient)
+	teamHandler := handlers.NewTeamHandler(teamService)
+
+	trainerService := services.NewTrainerService(trainerRepo, repo)
+	trainerHandler := handlers.NewTrainerHandler(trainerService)
+
+	calcService := services.NewCalcService(repo, apiClient)
+	calcHandler := handlers.NewCalcHandler(calcService)
+
+	battleService := services.NewBattleService(battleRepo, teamRepo, apiClient)
+	battleHandler := handlers.NewBattleHandler(battleService)
+
+	pokedexService := services.NewPokedexService(pokedexRepo, repo, trainerRepo, apiClient)
+	pokedexHandler := handlers.NewPokedexHandler(pokedexService)
 
 	router := gin.Default()
+	// Client IPs key the rate limits, so X-Forwarded-For is only believed from the configured proxies; with none,
+	// the connection's address is used and a client cannot pick its own bucket
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(handlers.RequestID())
 
 	router.GET("/health", handler.HealthCheck)
 
-	api := router.Group("/api/v1")
+	reader := handlers.RequireRole(domain.RoleReader)
+	editor := handlers.RequireRole(domain.RoleEditor)
+	admin := handlers.RequireRole(domain.RoleAdmin)
+	operator := handlers.RequireOperator()
+
+	// Routes that may call PokeAPI get their own, stricter limits
+	createPokemonLimit := rateLimiter.Limit("create-pokemon", pokeAPIRouteRateLimit)
+	calcLimit := rateLimiter.Limit("calc-damage", pokeAPIRouteRateLimit)
+	battleLimit := rateLimiter.Limit("create-battle", pokeAPIRouteRateLimit)
+
+	// Each address is limited before authentication, so bad credentials cannot be tried without limit; each
+	// client is limited again once it is known
+	ipLimit := rateLimiter.LimitByIP("client-ip", ipRateLimit)
+
+	api := router.Group("/api/v1", ipLimit, authMiddleware.Authenticate, tenantMiddleware.Resolve, rateLimiter.Limit("api", apiRateLimit), idempotency.Handle)
 	{
+		// API keys and tenants belong to the whole deployment, so only operator admins manage them
+		authRoutes := api.Group("/auth")
+		{
+			authRoutes.GET("/me", reader, authHandler.Me)
+			authRoutes.POST("/keys", admin, operator, authHandler.CreateAPIKey)
+			authRoutes.GET("/keys", admin, operator, authHandler.ListAPIKeys)
+			authRoutes.DELETE("/keys/:id", admin, operator, authHandler.RevokeAPIKey)
+		}
+
+		tenants := api.Group("/tenants")
+		{
+			tenants.POST("", admin, operator, tenantHandler.CreateTenant)
+			tenants.GET("", admin, operator, tenantHandler.ListTenants)
+			tenants.GET("/:id", admin, operator, tenantHandler.GetTenant)
+		}
+
 		pokemon := api.Group("/pokemon")
 		{
-			pokemon.POST("", handler.CreatePokemonFlexible)
-			pokemon.GET("/:id", handler.GetPokemon)
-			pokemon.GET("", handler.ListPokemon)
+			pokemon.POST("", editor, createPokemonLimit, handler.CreatePokemonFlexible)
+			pokemon.POST("/import", editor, handler.ImportPokemon)
+			pokemon.GET("/:id", reader, handler.GetPokemon)
+			pokemon.GET("/by-name/:name", reader, handler.GetPokemonByName)
+			pokemon.GET("/by-dex/:number", reader, handler.GetPokemonByDexNumber)
+			pokemon.GET("", reader, handler.ListPokemon)
+			pokemon.PUT("/:id", editor, handler.UpdatePokemon)
+			pokemon.DELETE("/:id", editor, handler.DeletePokemon)
+			pokemon.GET("/:id/history", reader, handler.GetPokemonHistory)
+			pokemon.GET("/:id/sprite", reader, spriteHandler.GetSprite)
+			pokemon.GET("/trash", editor, handler.ListTrash)
+			pokemon.POST("/:id/restore", editor, handler.RestorePokemon)
+			pokemon.GET("/:id/annotations", reader, annotationHandler.GetAnnotations)
+			pokemon.PUT("/:id/tags/:tag", editor, annotationHandler.AddTag)
+			pokemon.DELETE("/:id/tags/:tag", editor, annotationHandler.RemoveTag)
+			pokemon.PUT("/:id/note", editor, annotationHandler.SetNote)
+			// Favorites are personal, so readers may keep their own
+			pokemon.PUT("/:id/favorite", reader, annotationHandler.AddFavorite)
+			pokemon.DELETE("/:id/favorite", reader, annotationHandler.RemoveFavorite)
+		}
+
+		events := api.Group("/events")
+		{
+			events.GET("", reader, eventHandler.Stream)
+			events.GET("/ws", reader, eventHandler.WebSocket)
+		}
+
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.POST("", admin, webhookHandler.CreateWebhook)
+			webhooks.GET("", admin, webhookHandler.ListWebhooks)
+			webhooks.GET("/:id", admin, webhookHandler.GetWebhook)
+			webhooks.DELETE("/:id", admin, webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", admin, webhookHandler.ListDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/retry", admin, webhookHandler.RetryDelivery)
+		}
+
+		api.GET("/search", reader, handler.SearchPokemon)
+		api.GET("/tags", reader, annotationHandler.TagCloud)
+		api.GET("/pokedex", reader, pokedexHandler.GetCompletion)
+
+		teams := api.Group("/teams")
+		{
+			teams.POST("", editor, teamHandler.CreateTeam)
+			teams.GET("", reader, teamHandler.ListTeams)
+			teams.GET("/:id", reader, teamHandler.GetTeam)
+			teams.PUT("/:id", editor, teamHandler.UpdateTeam)
+			teams.DELETE("/:id", editor, teamHandler.DeleteTeam)
+			teams.GET("/:id/analysis", reader, teamHandler.AnalyzeTeam)
+		}
+
+		trainers := api.Group("/trainers")
+		{
+			trainers.POST("", editor, trainerHandler.CreateTrainer)
+			trainers.GET("", reader, trainerHandler.ListTrainers)
+			trainers.GET("/:id", reader, trainerHandler.GetTrainer)
+			trainers.POST("/:id/pokemon", editor, trainerHandler.AddPokemon)
+			trainers.GET("/:id/pokemon", reader, trainerHandler.ListPokemon)
+			trainers.GET("/:id/pokemon/:pokemonId", reader, trainerHandler.GetPokemon)
+			trainers.PUT("/:id/pokemon/:pokemonId", editor, trainerHandler.UpdatePokemon)
+			trainers.DELETE("/:id/pokemon/:pokemonId", editor, trainerHandler.ReleasePokemon)
+			trainers.GET("/:id/pokedex", reader, pokedexHandler.GetTrainerCompletion)
+			trainers.PUT("/:id/pokedex/:number", editor, pokedexHandler.SetFlag)
+		}
+
+		// Damage calculations do not store anything, so readers may run them
+		calc := api.Group("/calc")
+		{
+			calc.POST("/damage", reader, calcLimit, calcHandler.CalculateDamage)
+		}
+
+		battles := api.Group("/battles")
+		{
+			battles.POST("", editor, battleLimit, battleHandler.CreateBattle)
+			battles.GET("/:id", reader, battleHandler.GetBattle)
 		}
 	}
 
+	// GraphQL sits outside /api/v1 by convention but is authenticated and limited like it; mutations check roles themselves
+	router.POST("/graphql", ipLimit, authMiddleware.Authenticate, tenantMiddleware.Resolve, rateLimiter.Limit("api", apiRateLimit), reader, graphqlHandler.Query)
+	if gin.Mode() != gin.ReleaseMode {
+		router.GET("/graphql", graphqlHandler.GraphiQL)
+	}
+
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	grpcPort := getEnv("GRPC_PORT", "9090")
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC:", err)
+	}
+	go func() {
+		log.Printf("Starting gRPC server on port %s", grpcPort)
+		log.Fatal(grpcServer.Serve(grpcListener))
+	}()
+
 	port := getEnv("PORT", "8080")
 	log.Printf("Starting server on port %s", port)
 	log.Fatal(router.Run(":" + port))
 }
 
+// runEvery calls work every interval for the life of the process, logging its errors
+func runEvery(interval time.Duration, name string, work func() (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := work(); err != nil {
+			log.Printf("%s: %v", name, err)
+		}
+	}
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -78,3 +401,23 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList gets a comma-separated environment variable, or nil if it is unset
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
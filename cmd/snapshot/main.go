@@ -0,0 +1,54 @@
+// Command snapshot copies Pokemon, their species and their moves from PokeAPI into a directory in the api-data
+// layout, which the API serves instead of PokeAPI when POKEAPI_SNAPSHOT points at it.
+//
+//	go run ./cmd/snapshot -from 1 -to 151 -out pokeapi-snapshot
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"pokemon-api/internal/adapters/external"
+	"pokemon-api/internal/adapters/ratelimit"
+	"pokemon-api/internal/core/domain"
+	"strconv"
+)
+
+func main() {
+	baseURL := flag.String("base-url", getEnv("POKEAPI_BASE_URL", "https://pokeapi.co/api/v2"), "PokeAPI base URL")
+	out := flag.String("out", "pokeapi-snapshot", "directory to write the snapshot to; earlier builds into it are kept")
+	from := flag.Int("from", 1, "first Pokemon ID to copy")
+	to := flag.Int("to", domain.NationalDexSize, "last Pokemon ID to copy")
+	moves := flag.Bool("moves", true, "also copy every move the Pokemon learn, for damage calculations and battles")
+	ratePerMinute := flag.Int("rate", getEnvInt("POKEAPI_RATE_LIMIT_PER_MINUTE", 100), "PokeAPI requests per minute")
+	flag.Parse()
+
+	builder := external.NewSnapshotBuilder(*baseURL, *out, external.WithRateLimit(ratelimit.NewMemoryStore(), domain.PerMinute(*ratePerMinute)))
+	report, err := builder.Build(*from, *to, *moves)
+	if report != nil {
+		log.Printf("Copied %d Pokemon, %d species and %d moves into %s", report.Pokemon, report.Species, report.Moves, *out)
+		if len(report.Missing) > 0 {
+			log.Printf("PokeAPI has no Pokemon with IDs %v", report.Missing)
+		}
+	}
+	if err != nil {
+		log.Fatal("Failed to build the snapshot:", err)
+	}
+}
+
+// getEnv gets an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
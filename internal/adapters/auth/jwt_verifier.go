@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTConfig configures bearer token verification; at least one of HMACSecret and JWKSFile must be set
+type JWTConfig struct {
+	// HMACSecret verifies HS256 tokens
+	HMACSecret string
+	// JWKSFile is a JSON Web Key Set file whose RSA keys verify RS256 tokens
+	JWKSFile string
+	Issuer   string
+	Audience string
+}
+
+type jwtVerifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	parser     *jwt.Parser
+}
+
+// claims are the token claims used to build a principal; roles come from "roles" or the space-separated "scope",
+// "tenant" binds the token to one tenant, and "operator" lets an unbound token act in any tenant
+type claims struct {
+	Roles    []string `json:"roles"`
+	Scope    string   `json:"scope"`
+	Tenant   string   `json:"tenant"`
+	Operator bool     `json:"operator"`
+	jwt.RegisteredClaims
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func NewJWTVerifier(config JWTConfig) (ports.TokenVerifier, error) {
+	verifier := &jwtVerifier{}
+	var methods []string
+
+	if config.HMACSecret != "" {
+		verifier.hmacSecret = []byte(config.HMACSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if config.JWKSFile != "" {
+		keys, err := loadJWKS(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		verifier.rsaKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("JWT verification requires an HMAC secret or a JWKS file")
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	verifier.parser = jwt.NewParser(options...)
+
+	return verifier, nil
+}
+
+func (v *jwtVerifier) Verify(token string) (*domain.Principal, error) {
+	var tokenClaims claims
+	if _, err := v.parser.ParseWithClaims(token, &tokenClaims, v.key); err != nil {
+		return nil, err
+	}
+	if tokenClaims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	roles := append([]string{}, tokenClaims.Roles...)
+	for _, scope := range strings.Fields(tokenClaims.Scope) {
+		if domain.IsValidRole(scope) {
+			roles = append(roles, scope)
+		}
+	}
+
+	// A token bound to a tenant stays there, whatever else it claims
+	return &domain.Principal{
+		Subject:  tokenClaims.Subject,
+		Method:   domain.AuthMethodJWT,
+		Roles:    roles,
+		TenantID: tokenClaims.Tenant,
+		Operator: tokenClaims.Operator && tokenClaims.Tenant == "",
+	}, nil
+}
+
+// key selects the verification key for the token's algorithm; RS256 keys are chosen by "kid"
+func (v *jwtVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id '%s'", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key '%s': %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key '%s': %w", key.Kid, err)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS file contains no RSA keys")
+	}
+
+	return keys, nil
+}
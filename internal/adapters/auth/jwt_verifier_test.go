@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "test-hmac-secret"
+
+func writeJWKS(t *testing.T, keys map[string]*rsa.PublicKey) string {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	set.Keys = append(set.Keys, jsonWebKey{Kty: "EC", Kid: "ignored"})
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	data, err := json.Marshal(set)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "ash",
+		"iss":   "https://auth.example.com",
+		"aud":   "pokemon-api",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{domain.RoleEditor},
+	}
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	jwksFile := writeJWKS(t, map[string]*rsa.PublicKey{"key-1": &rsaKey.PublicKey})
+
+	verifier, err := NewJWTVerifier(JWTConfig{
+		HMACSecret: testSecret,
+		JWKSFile:   jwksFile,
+		Issuer:     "https://auth.example.com",
+		Audience:   "pokemon-api",
+	})
+	assert.NoError(t, err)
+
+	withClaims := func(changes jwt.MapClaims) jwt.MapClaims {
+		claims := validClaims()
+		for name, value := range changes {
+			if value == nil {
+				delete(claims, name)
+			} else {
+				claims[name] = value
+			}
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedRoles  []string
+		expectedTenant string
+		expectOperator bool
+		expectError    bool
+	}{
+		{
+			name:          "HS256",
+			token:         sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()),
+			expectedRoles: []string{domain.RoleEditor},
+		},
+		{
+			name:          "RS256 from JWKS",
+			token:         sign(t, jwt.SigningMethodRS256, rsaKey, "key-1", validClaims()),
+			expectedRoles: []string{domain.RoleEditor},
+		},
+		{
+			name:          "roles from scope claim",
+			token:         sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"roles": nil, "scope": "openid admin"})),
+			expectedRoles: []string{domain.RoleAdmin},
+		},
+		{
+			name:           "tenant claim",
+			token:          sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"tenant": "kanto"})),
+			expectedRoles:  []string{domain.RoleEditor},
+			expectedTenant: "kanto",
+		},
+		{
+			name:           "operator claim",
+			token:          sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"operator": true})),
+			expectedRoles:  []string{domain.RoleEditor},
+			expectOperator: true,
+		},
+		{
+			name:           "operator claim on a tenant-bound token",
+			token:          sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"operator": true, "tenant": "kanto"})),
+			expectedRoles:  []string{domain.RoleEditor},
+			expectedTenant: "kanto",
+		},
+		{
+			name:        "wrong HMAC secret",
+			token:       sign(t, jwt.SigningMethodHS256, []byte("other-secret"), "", validClaims()),
+			expectError: true,
+		},
+		{
+			name:        "RS256 signed by an unknown key",
+			token:       sign(t, jwt.SigningMethodRS256, otherKey, "key-1", validClaims()),
+			expectError: true,
+		},
+		{
+			name:        "unknown key id",
+			token:       sign(t, jwt.SigningMethodRS256, rsaKey, "key-2", validClaims()),
+			expectError: true,
+		},
+		{
+			name:        "expired",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})),
+			expectError: true,
+		},
+		{
+			name:        "missing expiry",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"exp": nil})),
+			expectError: true,
+		},
+		{
+			name:        "wrong issuer",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+			expectError: true,
+		},
+		{
+			name:        "wrong audience",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"aud": "another-api"})),
+			expectError: true,
+		},
+		{
+			name:        "missing subject",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", withClaims(jwt.MapClaims{"sub": nil})),
+			expectError: true,
+		},
+		{
+			name:        "not a token",
+			token:       "not-a-token",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, principal)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "ash", principal.Subject)
+			assert.Equal(t, domain.AuthMethodJWT, principal.Method)
+			assert.Equal(t, tt.expectedRoles, principal.Roles)
+			assert.Equal(t, tt.expectedTenant, principal.TenantID)
+			assert.Equal(t, tt.expectOperator, principal.IsOperator())
+		})
+	}
+}
+
+func TestJWTVerifier_RejectsUnconfiguredAlgorithm(t *testing.T) {
+	verifier, err := NewJWTVerifier(JWTConfig{HMACSecret: testSecret})
+	assert.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	principal, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims()))
+	assert.Error(t, err)
+	assert.Nil(t, principal)
+}
+
+func TestNewJWTVerifier_InvalidConfig(t *testing.T) {
+	_, err := NewJWTVerifier(JWTConfig{})
+	assert.EqualError(t, err, "JWT verification requires an HMAC secret or a JWKS file")
+
+	_, err = NewJWTVerifier(JWTConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+
+	_, err = NewJWTVerifier(JWTConfig{JWKSFile: writeJWKS(t, nil)})
+	assert.EqualError(t, err, "JWKS file contains no RSA keys")
+}
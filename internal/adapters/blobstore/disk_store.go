@@ -0,0 +1,84 @@
+package blobstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+)
+
+// DiskStore keeps each blob in a data file next to a JSON file with its content type and ETag.
+// Files are named by the hash of the key, so any key is safe to use.
+type DiskStore struct {
+	dir string
+}
+
+func NewDiskStore(dir string) (ports.BlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+func (s *DiskStore) Get(key string) (*domain.Blob, error) {
+	path := s.path(key)
+	meta, err := os.ReadFile(path + ".json")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("blob not found")
+		}
+		return nil, err
+	}
+
+	var blob domain.Blob
+	if err := json.Unmarshal(meta, &blob); err != nil {
+		return nil, err
+	}
+	if blob.Data, err = os.ReadFile(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("blob not found")
+		}
+		return nil, err
+	}
+	return &blob, nil
+}
+
+// Put writes the data before the metadata, each through a temporary file, so readers never see a partial blob
+func (s *DiskStore) Put(key string, blob *domain.Blob) error {
+	meta, err := json.Marshal(blob)
+	if err != nil {
+		return err
+	}
+
+	path := s.path(key)
+	if err := writeFile(path, blob.Data); err != nil {
+		return err
+	}
+	return writeFile(path+".json", meta)
+}
+
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:]))
+}
+
+func writeFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,49 @@
+package blobstore
+
+import (
+	"os"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskStore_PutAndGet(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir)
+	assert.NoError(t, err)
+
+	_, err = store.Get("sprites/pikachu/default")
+	assert.EqualError(t, err, "blob not found")
+
+	blob := &domain.Blob{ContentType: "image/png", ETag: `"abc"`, Data: []byte("\x89PNG")}
+	assert.NoError(t, store.Put("sprites/pikachu/default", blob))
+
+	got, err := store.Get("sprites/pikachu/default")
+	assert.NoError(t, err)
+	assert.Equal(t, blob, got)
+
+	// Keys never become paths, and no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// A new store on the same directory sees the cached blob
+	reopened, err := NewDiskStore(dir)
+	assert.NoError(t, err)
+	got, err = reopened.Get("sprites/pikachu/default")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("\x89PNG"), got.Data)
+}
+
+func TestDiskStore_Overwrite(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Put("key", &domain.Blob{ContentType: "image/png", ETag: `"1"`, Data: []byte("old")}))
+	assert.NoError(t, store.Put("key", &domain.Blob{ContentType: "image/gif", ETag: `"2"`, Data: []byte("new")}))
+
+	got, err := store.Get("key")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Blob{ContentType: "image/gif", ETag: `"2"`, Data: []byte("new")}, got)
+}
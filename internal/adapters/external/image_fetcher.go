@@ -0,0 +1,59 @@
+package external
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+	"time"
+)
+
+// maxImageSize bounds downloaded images; official artwork is well under 1 MB
+const maxImageSize = 5 << 20
+
+type imageFetcher struct {
+	httpClient *http.Client
+}
+
+func NewImageFetcher() ports.ImageFetcher {
+	return &imageFetcher{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (f *imageFetcher) FetchImage(imageURL string) (*domain.Blob, error) {
+	parsed, err := url.Parse(imageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("invalid image URL %q", imageURL)
+	}
+
+	resp, err := f.httpClient.Get(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image host returned status %d", resp.StatusCode)
+	}
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("image host returned %q instead of an image", resp.Header.Get("Content-Type"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image is larger than %d MB", maxImageSize>>20)
+	}
+
+	return &domain.Blob{ContentType: contentType, Data: data}, nil
+}
@@ -0,0 +1,50 @@
+package external
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageFetcher_FetchImage(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n")
+	host := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/25.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(png)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html></html>"))
+		case "/huge.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(bytes.Repeat([]byte{0}, maxImageSize+1))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer host.Close()
+
+	fetcher := NewImageFetcher()
+
+	blob, err := fetcher.FetchImage(host.URL + "/25.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", blob.ContentType)
+	assert.Equal(t, png, blob.Data)
+	assert.Empty(t, blob.ETag)
+
+	_, err = fetcher.FetchImage(host.URL + "/missing.png")
+	assert.EqualError(t, err, "image host returned status 404")
+
+	_, err = fetcher.FetchImage(host.URL + "/page")
+	assert.EqualError(t, err, `image host returned "text/html; charset=utf-8" instead of an image`)
+
+	_, err = fetcher.FetchImage(host.URL + "/huge.png")
+	assert.EqualError(t, err, "image is larger than 5 MB")
+
+	_, err = fetcher.FetchImage("file:///etc/passwd")
+	assert.EqualError(t, err, `invalid image URL "file:///etc/passwd"`)
+}
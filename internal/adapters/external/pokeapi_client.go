@@ -2,49 +2,161 @@ package external
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"pokemon-api/internal/core/domain"
 	"pokemon-api/internal/core/ports"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// outboundLimiterKey is the single bucket shared by every outbound PokeAPI request
+const outboundLimiterKey = "pokeapi:outbound"
+
 type pokeAPIClient struct {
 	baseURL    string
 	httpClient *http.Client
+
+	limiter ports.RateLimitStore
+	limit   domain.RateLimit
+	sleep   func(time.Duration)
+}
+
+// ClientOption configures optional behaviour of the PokeAPI client
+type ClientOption func(*pokeAPIClient)
+
+// WithRateLimit caps outbound requests across all callers; requests wait for a token for up to the HTTP timeout
+func WithRateLimit(store ports.RateLimitStore, limit domain.RateLimit) ClientOption {
+	return func(c *pokeAPIClient) {
+		c.limiter = store
+		c.limit = limit
+	}
 }
 
-func NewPokeAPIClient(baseURL string) ports.PokemonAPIClient {
-	return &pokeAPIClient{
+func NewPokeAPIClient(baseURL string, opts ...ClientOption) ports.PokemonAPIClient {
+	client := &pokeAPIClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		sleep: time.Sleep,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
 }
 
 func (c *pokeAPIClient) GetPokemonData(identifier string) (*domain.ExternalPokemonResponse, error) {
 	identifier = strings.ToLower(strings.TrimSpace(identifier))
-	url := fmt.Sprintf("%s/pokemon/%s", c.baseURL, identifier)
 
-	resp, err := c.httpClient.Get(url)
+	var pokemonData domain.ExternalPokemonResponse
+	if err := c.getJSON("pokemon", identifier, nil, &pokemonData); err != nil {
+		return nil, err
+	}
+
+	return &pokemonData, nil
+}
+
+func (c *pokeAPIClient) GetMoveData(identifier string) (*domain.ExternalMoveResponse, error) {
+	identifier = strings.ToLower(strings.Join(strings.Fields(identifier), "-"))
+
+	var moveData domain.ExternalMoveResponse
+	if err := c.getJSON("move", identifier, nil, &moveData); err != nil {
+		return nil, err
+	}
+
+	return &moveData, nil
+}
+
+func (c *pokeAPIClient) GetSpeciesData(identifier string) (*domain.ExternalSpeciesResponse, error) {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+
+	var speciesData domain.ExternalSpeciesResponse
+	if err := c.getJSON("pokemon-species", identifier, nil, &speciesData); err != nil {
+		return nil, err
+	}
+
+	return &speciesData, nil
+}
+
+func (c *pokeAPIClient) GetPokemonList(limit int) (*domain.ExternalPokemonListResponse, error) {
+	var list domain.ExternalPokemonListResponse
+	if err := c.getJSON("pokemon", "", url.Values{"limit": {strconv.Itoa(limit)}}, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// getJSON fetches /{resource}/{identifier}?{query} and decodes the response into target
+func (c *pokeAPIClient) getJSON(resource, identifier string, query url.Values, target interface{}) error {
+	body, err := c.get(resource, identifier, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request to PokeAPI: %w", err)
+		return err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return &domain.ExternalServiceError{Err: fmt.Errorf("failed to decode PokeAPI response: %w", err)}
+	}
+
+	return nil
+}
+
+// get fetches /{resource}/{identifier}?{query} and returns the response body as PokeAPI sent it. An empty
+// identifier fetches the resource's list, and a nil query sends none.
+func (c *pokeAPIClient) get(resource, identifier string, query url.Values) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/%s/%s", c.baseURL, resource, url.PathEscape(identifier))
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	if err := c.waitForToken(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Get(endpoint)
+	if err != nil {
+		return nil, &domain.ExternalServiceError{Err: fmt.Errorf("failed to make request to PokeAPI: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("pokemon '%s' not found", identifier)
+		return nil, &domain.ExternalNotFoundError{Resource: resource, Identifier: identifier}
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("PokeAPI returned status %d", resp.StatusCode)
+		return nil, &domain.ExternalServiceError{Err: fmt.Errorf("PokeAPI returned status %d", resp.StatusCode)}
 	}
 
-	var pokemonData domain.ExternalPokemonResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pokemonData); err != nil {
-		return nil, fmt.Errorf("failed to decode PokeAPI response: %w", err)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &domain.ExternalServiceError{Err: fmt.Errorf("failed to read PokeAPI response: %w", err)}
 	}
 
-	return &pokemonData, nil
+	return body, nil
+}
+
+// waitForToken blocks until the outbound limiter allows a request, giving up if that would exceed the HTTP timeout
+func (c *pokeAPIClient) waitForToken() error {
+	if c.limiter == nil || c.limit.Burst <= 0 {
+		return nil
+	}
+
+	var waited time.Duration
+	for {
+		decision, err := c.limiter.Take(outboundLimiterKey, c.limit)
+		if err != nil || decision.Allowed {
+			// A failing shared store should not take PokeAPI access down with it
+			return nil
+		}
+		if waited+decision.RetryAfter > c.httpClient.Timeout {
+			return &domain.ExternalServiceError{Err: errors.New("PokeAPI rate limit exceeded")}
+		}
+		c.sleep(decision.RetryAfter)
+		waited += decision.RetryAfter
+	}
 }
@@ -215,6 +215,312 @@ func TestPokeAPIClient_GetPokemonData_NameNormalization(t *testing.T) {
 	}
 }
 
+func TestPokeAPIClient_GetPokemonData_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"id": 25,
+			"name": "pikachu",
+			"types": [],
+			"stats": [
+				{"base_stat": 35, "stat": {"name": "hp"}},
+				{"base_stat": 55, "stat": {"name": "attack"}},
+				{"base_stat": 50, "stat": {"name": "special-attack"}},
+				{"base_stat": 90, "stat": {"name": "speed"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewPokeAPIClient(server.URL)
+	result, err := client.GetPokemonData("pikachu")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 35, result.BaseStat("hp"))
+	assert.Equal(t, 50, result.BaseStat("special-attack"))
+	assert.Equal(t, 90, result.BaseStat("speed"))
+	assert.Equal(t, 0, result.BaseStat("defense"))
+}
+
+func TestPokeAPIClient_GetPokemonData_AbilitiesAndSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"id": 386,
+			"name": "deoxys-normal",
+			"abilities": [{"ability": {"name": "pressure"}, "is_hidden": false, "slot": 1}],
+			"species": {"name": "deoxys", "url": "https://pokeapi.co/api/v2/pokemon-species/386/"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewPokeAPIClient(server.URL)
+	result, err := client.GetPokemonData("deoxys-normal")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pressure"}, result.AbilityNames())
+	assert.Equal(t, "deoxys", result.SpeciesName())
+}
+
+func TestPokeAPIClient_GetSpeciesData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pokemon-species/pikachu", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"id": 25,
+			"name": "pikachu",
+			"flavor_text_entries": [
+				{"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}},
+				{"flavor_text": "It keeps its tail\nraised to monitor\fits surroundings.", "language": {"name": "en"}},
+				{"flavor_text": "Il lui arrive de remettre en marche un Pikachu évanoui.", "language": {"name": "fr"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewPokeAPIClient(server.URL)
+	result, err := client.GetSpeciesData("Pikachu")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 25, result.ID)
+	assert.Equal(t, "It keeps its tail raised to monitor its surroundings.", result.EnglishFlavorText())
+}
+
+func TestPokeAPIClient_GetMoveData(t *testing.T) {
+	tests := []struct {
+		name           string
+		identifier     string
+		expectedPath   string
+		mockResponse   string
+		mockStatusCode int
+		expectedError  string
+	}{
+		{
+			name:         "damaging move",
+			identifier:   "Thunder Punch",
+			expectedPath: "/move/thunder-punch",
+			mockResponse: `{
+				"id": 9,
+				"name": "thunder-punch",
+				"power": 75,
+				"accuracy": 100,
+				"type": {"name": "electric"},
+				"damage_class": {"name": "physical"}
+			}`,
+			mockStatusCode: http.StatusOK,
+		},
+		{
+			name:           "status move has no power",
+			identifier:     "swords-dance",
+			expectedPath:   "/move/swords-dance",
+			mockResponse:   `{"id": 14, "name": "swords-dance", "power": null, "type": {"name": "normal"}, "damage_class": {"name": "status"}}`,
+			mockStatusCode: http.StatusOK,
+		},
+		{
+			name:           "move not found",
+			identifier:     "hyper-punch",
+			expectedPath:   "/move/hyper-punch",
+			mockStatusCode: http.StatusNotFound,
+			expectedError:  "move 'hyper-punch' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expectedPath, r.URL.Path)
+				w.WriteHeader(tt.mockStatusCode)
+				if tt.mockResponse != "" {
+					w.Write([]byte(tt.mockResponse))
+				}
+			}))
+			defer server.Close()
+
+			client := NewPokeAPIClient(server.URL)
+			result, err := client.GetMoveData(tt.identifier)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			switch result.Name {
+			case "thunder-punch":
+				assert.Equal(t, 75, *result.Power)
+				assert.Equal(t, "electric", result.Type.Name)
+				assert.Equal(t, "physical", result.DamageClass.Name)
+			case "swords-dance":
+				assert.Nil(t, result.Power)
+				assert.Equal(t, "status", result.DamageClass.Name)
+			default:
+				t.Fatalf("unexpected move %q", result.Name)
+			}
+		})
+	}
+}
+
+func TestPokeAPIClient_ErrorTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/move/hyper-punch" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	client := NewPokeAPIClient(server.URL)
+
+	_, err := client.GetMoveData("hyper-punch")
+	var notFound *domain.ExternalNotFoundError
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, "move", notFound.Resource)
+		assert.Equal(t, "hyper-punch", notFound.Identifier)
+	}
+
+	_, err = client.GetMoveData("thunder-punch")
+	var unavailable *domain.ExternalServiceError
+	assert.ErrorAs(t, err, &unavailable)
+	assert.EqualError(t, err, "PokeAPI returned status 503")
+
+	server.Close()
+	_, err = client.GetMoveData("thunder-punch")
+	assert.ErrorAs(t, err, &unavailable)
+}
+
+func TestPokeAPIClient_GetPokemonList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pokemon/", r.URL.Path)
+		assert.Equal(t, "limit=3", r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"count": 1302,
+			"results": [
+				{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"},
+				{"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon/2/"},
+				{"name": "venusaur", "url": "https://pokeapi.co/api/v2/pokemon/3/"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewPokeAPIClient(server.URL)
+	result, err := client.GetPokemonList(3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1302, result.Count)
+	assert.Equal(t, []domain.DexEntry{
+		{Number: 1, Name: "bulbasaur"},
+		{Number: 2, Name: "ivysaur"},
+		{Number: 3, Name: "venusaur"},
+	}, result.DexEntries())
+}
+
+func TestPokeAPIClient_GetPokemonList_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewPokeAPIClient(server.URL).GetPokemonList(1025)
+
+	var notFound *domain.ExternalNotFoundError
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, "pokemon", notFound.Resource)
+		assert.Empty(t, notFound.Identifier)
+	}
+	assert.EqualError(t, err, "pokemon list not found")
+}
+
+func TestPokeAPIClient_EscapesIdentifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The identifier stays in the path rather than starting a query
+		assert.Equal(t, "/pokemon/?limit=1", r.URL.Path)
+		assert.Empty(t, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewPokeAPIClient(server.URL).GetPokemonData("?limit=1")
+
+	assert.EqualError(t, err, "pokemon '?limit=1' not found")
+}
+
+type scriptedLimiter struct {
+	decisions []domain.RateLimitDecision
+	keys      []string
+}
+
+func (l *scriptedLimiter) Take(key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	l.keys = append(l.keys, key)
+	decision := l.decisions[0]
+	l.decisions = l.decisions[1:]
+	return decision, nil
+}
+
+func TestPokeAPIClient_RateLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		decisions     []domain.RateLimitDecision
+		expectedSleep []time.Duration
+		expectedCalls int
+		expectedError string
+	}{
+		{
+			name:          "token available",
+			decisions:     []domain.RateLimitDecision{{Allowed: true}},
+			expectedCalls: 1,
+		},
+		{
+			name:          "waits for the next token",
+			decisions:     []domain.RateLimitDecision{{RetryAfter: 2 * time.Second}, {RetryAfter: time.Second}, {Allowed: true}},
+			expectedSleep: []time.Duration{2 * time.Second, time.Second},
+			expectedCalls: 1,
+		},
+		{
+			name:          "gives up when the wait exceeds the timeout",
+			decisions:     []domain.RateLimitDecision{{RetryAfter: 6 * time.Second}, {RetryAfter: 6 * time.Second}},
+			expectedSleep: []time.Duration{6 * time.Second},
+			expectedError: "PokeAPI rate limit exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+			}))
+			defer server.Close()
+
+			limiter := &scriptedLimiter{decisions: tt.decisions}
+			client := NewPokeAPIClient(server.URL, WithRateLimit(limiter, domain.PerMinute(100))).(*pokeAPIClient)
+			var slept []time.Duration
+			client.sleep = func(d time.Duration) {
+				slept = append(slept, d)
+			}
+
+			result, err := client.GetPokemonData("pikachu")
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "pikachu", result.Name)
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedSleep, slept)
+			assert.Empty(t, limiter.decisions)
+			for _, key := range limiter.keys {
+				assert.Equal(t, "pokeapi:outbound", key)
+			}
+		})
+	}
+}
+
 func TestPokeAPIClient_Timeout(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(15 * time.Second)
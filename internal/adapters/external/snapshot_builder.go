@@ -0,0 +1,196 @@
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"pokemon-api/internal/core/domain"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SnapshotBuilder copies resources from PokeAPI into a directory in the api-data layout, for NewSnapshotClient
+type SnapshotBuilder struct {
+	client *pokeAPIClient
+	dir    string
+}
+
+// SnapshotReport counts the resources a build wrote
+type SnapshotReport struct {
+	Pokemon int
+	Species int
+	Moves   int
+	// Missing lists the Pokemon IDs in the range that PokeAPI does not have
+	Missing []int
+}
+
+// snapshotPokemon is the part of a PokeAPI Pokemon that says which other resources to copy
+type snapshotPokemon struct {
+	Name    string        `json:"name"`
+	Species snapshotEntry `json:"species"`
+	Moves   []struct {
+		Move snapshotEntry `json:"move"`
+	} `json:"moves"`
+}
+
+// NewSnapshotBuilder creates a builder writing to dir; opts configure its PokeAPI client as for NewPokeAPIClient
+func NewSnapshotBuilder(baseURL, dir string, opts ...ClientOption) *SnapshotBuilder {
+	return &SnapshotBuilder{
+		client: NewPokeAPIClient(baseURL, opts...).(*pokeAPIClient),
+		dir:    dir,
+	}
+}
+
+// Build copies the Pokemon with IDs from first to last, their species and, with moves, every move they learn,
+// storing each as PokeAPI sent it. The indexes keep what earlier builds into the same directory added, so a
+// snapshot can be built a range at a time.
+func (b *SnapshotBuilder) Build(first, last int, moves bool) (*SnapshotReport, error) {
+	if first < 1 || last < first {
+		return nil, errors.New("snapshot range must start at 1 or more and not end before it starts")
+	}
+
+	report := &SnapshotReport{}
+	species := make(map[string]bool)
+	moveIDs := make(map[string]bool)
+	pokemon := make(map[string]string)
+	for id := first; id <= last; id++ {
+		identifier := strconv.Itoa(id)
+		data, err := b.client.get("pokemon", identifier, nil)
+		if err != nil {
+			var notFound *domain.ExternalNotFoundError
+			if errors.As(err, &notFound) {
+				report.Missing = append(report.Missing, id)
+				continue
+			}
+			return report, err
+		}
+
+		var parsed snapshotPokemon
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return report, fmt.Errorf("failed to decode PokeAPI response: %w", err)
+		}
+		if err := b.write("pokemon", identifier, data); err != nil {
+			return report, err
+		}
+		pokemon[identifier] = parsed.Name
+		report.Pokemon++
+
+		if parsed.Species.URL != "" {
+			species[idFromURL(parsed.Species.URL)] = true
+		}
+		if moves {
+			for _, move := range parsed.Moves {
+				if move.Move.URL != "" {
+					moveIDs[idFromURL(move.Move.URL)] = true
+				}
+			}
+		}
+	}
+	if err := b.updateIndex("pokemon", pokemon); err != nil {
+		return report, err
+	}
+
+	var err error
+	if report.Species, err = b.copyAll("pokemon-species", species); err != nil {
+		return report, err
+	}
+	if report.Moves, err = b.copyAll("move", moveIDs); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// copyAll copies the resources with the IDs and adds them to the resource's index
+func (b *SnapshotBuilder) copyAll(resource string, ids map[string]bool) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	names := make(map[string]string, len(ids))
+	for _, id := range sortedIDs(ids) {
+		data, err := b.client.get(resource, id, nil)
+		if err != nil {
+			return len(names), err
+		}
+
+		var parsed snapshotEntry
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return len(names), fmt.Errorf("failed to decode PokeAPI response: %w", err)
+		}
+		if err := b.write(resource, id, data); err != nil {
+			return len(names), err
+		}
+		names[id] = parsed.Name
+	}
+	return len(names), b.updateIndex(resource, names)
+}
+
+// updateIndex merges the resources, by ID, into the resource's index.json, listing them in ID order
+func (b *SnapshotBuilder) updateIndex(resource string, names map[string]string) error {
+	indexPath := filepath.Join(b.dir, snapshotRoot, resource, "index.json")
+	merged := make(map[string]string)
+	if data, err := os.ReadFile(indexPath); err == nil {
+		var existing snapshotIndex
+		if err := json.Unmarshal(data, &existing); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", indexPath, err)
+		}
+		for _, entry := range existing.Results {
+			merged[idFromURL(entry.URL)] = entry.Name
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for id, name := range names {
+		merged[id] = name
+	}
+
+	ids := make(map[string]bool, len(merged))
+	for id := range merged {
+		ids[id] = true
+	}
+	index := snapshotIndex{Count: len(merged), Results: make([]snapshotEntry, 0, len(merged))}
+	for _, id := range sortedIDs(ids) {
+		index.Results = append(index.Results, snapshotEntry{
+			Name: merged[id],
+			URL:  "/" + strings.Join([]string{snapshotRoot, resource, id}, "/") + "/",
+		})
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.writeFile(indexPath, data)
+}
+
+// write stores a resource at api/v2/{resource}/{id}/index.json
+func (b *SnapshotBuilder) write(resource, id string, data []byte) error {
+	return b.writeFile(filepath.Join(b.dir, snapshotRoot, resource, id, "index.json"), data)
+}
+
+func (b *SnapshotBuilder) writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// sortedIDs orders numeric IDs numerically, so indexes list resources as PokeAPI does
+func sortedIDs(ids map[string]bool) []string {
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, errA := strconv.Atoi(sorted[i])
+		b, errB := strconv.Atoi(sorted[j])
+		if errA != nil || errB != nil {
+			return sorted[i] < sorted[j]
+		}
+		return a < b
+	})
+	return sorted
+}
@@ -0,0 +1,103 @@
+package external
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePokeAPI serves two Pokemon sharing a move, their species and the move; every other path is a 404
+func fakePokeAPI(t *testing.T) *httptest.Server {
+	resources := map[string]string{
+		"/pokemon/1": `{"id": 1, "name": "bulbasaur", "species": {"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon-species/1/"},
+			"moves": [{"move": {"name": "tackle", "url": "https://pokeapi.co/api/v2/move/33/"}}]}`,
+		"/pokemon/2": `{"id": 2, "name": "ivysaur", "species": {"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon-species/2/"},
+			"moves": [{"move": {"name": "tackle", "url": "https://pokeapi.co/api/v2/move/33/"}}]}`,
+		"/pokemon-species/1": `{"id": 1, "name": "bulbasaur", "names": [{"name": "フシギダネ", "language": {"name": "ja-Hrkt"}}]}`,
+		"/pokemon-species/2": `{"id": 2, "name": "ivysaur"}`,
+		"/move/33":           `{"id": 33, "name": "tackle", "power": 40, "type": {"name": "normal"}, "damage_class": {"name": "physical"}}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestSnapshotBuilder_Build(t *testing.T) {
+	server := fakePokeAPI(t)
+	defer server.Close()
+	dir := t.TempDir()
+
+	report, err := NewSnapshotBuilder(server.URL, dir).Build(1, 3, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &SnapshotReport{Pokemon: 2, Species: 2, Moves: 1, Missing: []int{3}}, report)
+
+	client, err := NewSnapshotClient(dir)
+	assert.NoError(t, err)
+
+	pokemon, err := client.GetPokemonData("Ivysaur")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pokemon.ID)
+	assert.Equal(t, "ivysaur", pokemon.SpeciesName())
+
+	species, err := client.GetSpeciesData("bulbasaur")
+	assert.NoError(t, err)
+	assert.Equal(t, "フシギダネ", species.Translations()["ja-hrkt"].Name)
+
+	move, err := client.GetMoveData("Tackle")
+	assert.NoError(t, err)
+	assert.Equal(t, 40, *move.Power)
+
+	list, err := client.GetPokemonList(10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+	assert.Equal(t, "bulbasaur", list.Results[0].Name)
+	assert.Equal(t, "/api/v2/pokemon/1/", list.Results[0].URL)
+}
+
+func TestSnapshotBuilder_BuildKeepsEarlierRanges(t *testing.T) {
+	server := fakePokeAPI(t)
+	defer server.Close()
+	dir := t.TempDir()
+	builder := NewSnapshotBuilder(server.URL, dir)
+
+	_, err := builder.Build(2, 2, false)
+	assert.NoError(t, err)
+	report, err := builder.Build(1, 1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &SnapshotReport{Pokemon: 1, Species: 1}, report)
+
+	client, err := NewSnapshotClient(dir)
+	assert.NoError(t, err)
+	list, err := client.GetPokemonList(10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+	assert.Equal(t, "bulbasaur", list.Results[0].Name)
+	assert.Equal(t, "ivysaur", list.Results[1].Name)
+
+	// Without moves, no move is copied
+	_, err = os.Stat(filepath.Join(dir, "api", "v2", "move"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSnapshotBuilder_Errors(t *testing.T) {
+	_, err := NewSnapshotBuilder("http://localhost", t.TempDir()).Build(5, 4, false)
+	assert.EqualError(t, err, "snapshot range must start at 1 or more and not end before it starts")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err = NewSnapshotBuilder(server.URL, t.TempDir()).Build(1, 1, false)
+	assert.EqualError(t, err, "PokeAPI returned status 503")
+}
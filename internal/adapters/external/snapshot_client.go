@@ -0,0 +1,243 @@
+package external
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// snapshotRoot is where resources live in the api-data layout: /api/v2/pokemon/25/ is api/v2/pokemon/25/index.json
+const snapshotRoot = "api/v2"
+
+// snapshotClient serves PokeAPI data from a local copy in the api-data layout, for environments that cannot
+// reach PokeAPI. Resources are stored by ID only, so names are looked up in each resource's index.
+type snapshotClient struct {
+	read func(name string) ([]byte, error)
+
+	mu sync.Mutex
+	// ids maps each resource's names to IDs, loaded from its index on first use
+	ids map[string]map[string]string
+}
+
+// snapshotIndex is a resource's index.json, which lists every stored resource with its URL
+type snapshotIndex struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []snapshotEntry `json:"results"`
+}
+
+type snapshotEntry struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// NewSnapshotClient opens a snapshot directory, or a .tar, .tar.gz or .tgz file of one. The snapshot's api/v2
+// folder may sit at the top, as NewSnapshotBuilder writes it, or under data/, as in a checkout of PokeAPI's
+// api-data repository.
+func NewSnapshotClient(snapshotPath string) (ports.PokemonAPIClient, error) {
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PokeAPI snapshot: %w", err)
+	}
+
+	client := &snapshotClient{ids: make(map[string]map[string]string)}
+	switch {
+	case info.IsDir():
+		fsys := os.DirFS(snapshotPath)
+		if dataDir, err := fs.Stat(fsys, path.Join("data", snapshotRoot)); err == nil && dataDir.IsDir() {
+			fsys, _ = fs.Sub(fsys, "data")
+		}
+		client.read = func(name string) ([]byte, error) { return fs.ReadFile(fsys, name) }
+	case strings.HasSuffix(snapshotPath, ".tar"), strings.HasSuffix(snapshotPath, ".tar.gz"), strings.HasSuffix(snapshotPath, ".tgz"):
+		files, err := readSnapshotArchive(snapshotPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PokeAPI snapshot: %w", err)
+		}
+		client.read = func(name string) ([]byte, error) {
+			data, ok := files[name]
+			if !ok {
+				return nil, fs.ErrNotExist
+			}
+			return data, nil
+		}
+	default:
+		return nil, errors.New("PokeAPI snapshot must be a directory or a .tar, .tar.gz or .tgz file")
+	}
+
+	if _, err := client.index("pokemon"); err != nil {
+		return nil, fmt.Errorf("PokeAPI snapshot has no Pokemon index: %w", err)
+	}
+	return client, nil
+}
+
+// readSnapshotArchive loads every file under api/v2 in the archive, keyed by its path from api/v2 on
+func readSnapshotArchive(archivePath string) (map[string][]byte, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if !strings.HasSuffix(archivePath, ".tar") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	files := make(map[string][]byte)
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Archives may wrap the snapshot in folders such as "./" or "api-data/data/"
+		name := path.Clean(header.Name)
+		start := strings.Index("/"+name, "/"+snapshotRoot+"/")
+		if start < 0 {
+			continue
+		}
+		if files[name[start:]], err = io.ReadAll(archive); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *snapshotClient) GetPokemonData(identifier string) (*domain.ExternalPokemonResponse, error) {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+
+	var pokemonData domain.ExternalPokemonResponse
+	if err := c.getJSON("pokemon", identifier, &pokemonData); err != nil {
+		return nil, err
+	}
+
+	return &pokemonData, nil
+}
+
+func (c *snapshotClient) GetMoveData(identifier string) (*domain.ExternalMoveResponse, error) {
+	identifier = strings.ToLower(strings.Join(strings.Fields(identifier), "-"))
+
+	var moveData domain.ExternalMoveResponse
+	if err := c.getJSON("move", identifier, &moveData); err != nil {
+		return nil, err
+	}
+
+	return &moveData, nil
+}
+
+func (c *snapshotClient) GetSpeciesData(identifier string) (*domain.ExternalSpeciesResponse, error) {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+
+	var speciesData domain.ExternalSpeciesResponse
+	if err := c.getJSON("pokemon-species", identifier, &speciesData); err != nil {
+		return nil, err
+	}
+
+	return &speciesData, nil
+}
+
+// GetPokemonList lists the snapshot's Pokemon, which may be fewer than PokeAPI has
+func (c *snapshotClient) GetPokemonList(limit int) (*domain.ExternalPokemonListResponse, error) {
+	data, err := c.read(path.Join(snapshotRoot, "pokemon", "index.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PokeAPI snapshot: %w", err)
+	}
+
+	var list domain.ExternalPokemonListResponse
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode PokeAPI snapshot: %w", err)
+	}
+	if limit >= 0 && len(list.Results) > limit {
+		list.Results = list.Results[:limit]
+	}
+
+	return &list, nil
+}
+
+// getJSON reads the resource with the ID or name and decodes it into target. Missing resources fail the way
+// PokeAPI's 404s do in the live client.
+func (c *snapshotClient) getJSON(resource, identifier string, target interface{}) error {
+	notFound := &domain.ExternalNotFoundError{Resource: resource, Identifier: identifier}
+
+	id := identifier
+	if _, err := strconv.Atoi(identifier); err != nil {
+		ids, err := c.index(resource)
+		if errors.Is(err, fs.ErrNotExist) {
+			return notFound
+		}
+		if err != nil {
+			return err
+		}
+		if id = ids[identifier]; id == "" {
+			return notFound
+		}
+	}
+
+	data, err := c.read(path.Join(snapshotRoot, resource, id, "index.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return notFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read PokeAPI snapshot: %w", err)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to decode PokeAPI snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// index returns the resource's names mapped to IDs; a failed read is not cached
+func (c *snapshotClient) index(resource string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ids, ok := c.ids[resource]; ok {
+		return ids, nil
+	}
+
+	data, err := c.read(path.Join(snapshotRoot, resource, "index.json"))
+	if err != nil {
+		return nil, err
+	}
+	var index snapshotIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode PokeAPI snapshot: %w", err)
+	}
+
+	ids := make(map[string]string, len(index.Results))
+	for _, entry := range index.Results {
+		ids[entry.Name] = idFromURL(entry.URL)
+	}
+	c.ids[resource] = ids
+	return ids, nil
+}
+
+// idFromURL returns the ID at the end of a resource URL such as "https://pokeapi.co/api/v2/pokemon/25/"
+func idFromURL(resourceURL string) string {
+	return path.Base(strings.TrimSuffix(resourceURL, "/"))
+}
@@ -0,0 +1,111 @@
+package external
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// snapshotFiles is a one-Pokemon snapshot in the api-data layout
+var snapshotFiles = map[string]string{
+	"api/v2/pokemon/index.json":    `{"count": 1, "results": [{"name": "pikachu", "url": "/api/v2/pokemon/25/"}]}`,
+	"api/v2/pokemon/25/index.json": `{"id": 25, "name": "pikachu", "species": {"name": "pikachu"}}`,
+}
+
+func writeSnapshotDir(t *testing.T, dir string) {
+	for name, content := range snapshotFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// writeSnapshotArchive writes the snapshot to a .tar.gz under prefix, as tarballs of api-data are laid out
+func writeSnapshotArchive(t *testing.T, archivePath, prefix string) {
+	file, err := os.Create(archivePath)
+	assert.NoError(t, err)
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	archive := tar.NewWriter(gz)
+	for name, content := range snapshotFiles {
+		assert.NoError(t, archive.WriteHeader(&tar.Header{Name: prefix + name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := archive.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, archive.Close())
+	assert.NoError(t, gz.Close())
+}
+
+func TestSnapshotClient_Layouts(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string) string
+	}{
+		{name: "directory", setup: func(t *testing.T, dir string) string {
+			writeSnapshotDir(t, dir)
+			return dir
+		}},
+		{name: "api-data checkout", setup: func(t *testing.T, dir string) string {
+			writeSnapshotDir(t, filepath.Join(dir, "data"))
+			return dir
+		}},
+		{name: "tarball", setup: func(t *testing.T, dir string) string {
+			archivePath := filepath.Join(dir, "snapshot.tar.gz")
+			writeSnapshotArchive(t, archivePath, "./api-data/data/")
+			return archivePath
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewSnapshotClient(tt.setup(t, t.TempDir()))
+			assert.NoError(t, err)
+
+			byName, err := client.GetPokemonData(" Pikachu ")
+			assert.NoError(t, err)
+			assert.Equal(t, 25, byName.ID)
+
+			byID, err := client.GetPokemonData("25")
+			assert.NoError(t, err)
+			assert.Equal(t, "pikachu", byID.Name)
+		})
+	}
+}
+
+func TestSnapshotClient_NotFound(t *testing.T) {
+	dir := t.TempDir()
+	writeSnapshotDir(t, dir)
+	client, err := NewSnapshotClient(dir)
+	assert.NoError(t, err)
+
+	// Messages match the live client's 404s, which callers check for
+	_, err = client.GetPokemonData("raichu")
+	assert.EqualError(t, err, "pokemon 'raichu' not found")
+	_, err = client.GetPokemonData("26")
+	assert.EqualError(t, err, "pokemon '26' not found")
+	_, err = client.GetSpeciesData("25")
+	assert.EqualError(t, err, "pokemon-species '25' not found")
+	_, err = client.GetMoveData("thunderbolt")
+	assert.EqualError(t, err, "move 'thunderbolt' not found")
+
+	list, err := client.GetPokemonList(0)
+	assert.NoError(t, err)
+	assert.Empty(t, list.Results)
+}
+
+func TestSnapshotClient_InvalidSnapshot(t *testing.T) {
+	_, err := NewSnapshotClient(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to open PokeAPI snapshot")
+
+	_, err = NewSnapshotClient(t.TempDir())
+	assert.ErrorContains(t, err, "PokeAPI snapshot has no Pokemon index")
+
+	zip := filepath.Join(t.TempDir(), "snapshot.zip")
+	assert.NoError(t, os.WriteFile(zip, nil, 0o644))
+	_, err = NewSnapshotClient(zip)
+	assert.EqualError(t, err, "PokeAPI snapshot must be a directory or a .tar, .tar.gz or .tgz file")
+}
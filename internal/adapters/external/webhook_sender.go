@@ -0,0 +1,112 @@
+package external
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Headers sent with every webhook request
+const (
+	WebhookEventHeader     = "X-Pokemon-Event"
+	WebhookDeliveryHeader  = "X-Pokemon-Delivery"
+	WebhookTimestampHeader = "X-Pokemon-Timestamp"
+	WebhookSignatureHeader = "X-Pokemon-Signature"
+)
+
+type webhookSender struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewWebhookSender returns a sender that only connects to public addresses, since any tenant admin chooses the URLs
+func NewWebhookSender() ports.WebhookSender {
+	return newWebhookSender(publicAddressOnly)
+}
+
+// newWebhookSender checks every address the sender dials with control; a nil control allows any address
+func newWebhookSender(control func(network, address string, conn syscall.RawConn) error) *webhookSender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the webhook, leaving its address unchecked
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   control,
+	}).DialContext
+
+	return &webhookSender{
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   10 * time.Second,
+			// Redirects are reported as failures rather than followed, so a webhook cannot bounce requests elsewhere
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// publicAddressOnly refuses to connect to loopback, link-local, private and unspecified addresses. It runs after DNS
+// resolution, on the address actually dialed, so a hostname that later resolves to an internal address is caught too.
+func publicAddressOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("webhook address %s is not an IP address", host)
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook address %s is not allowed: loopback, link-local and private addresses are blocked", host)
+	}
+	return nil
+}
+
+func (s *webhookSender) Send(webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pokemon-api-webhooks")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "timestamp.payload" under secret. Receivers recompute it
+// to verify the sender, and reject old timestamps to stop replays.
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,132 @@
+package external
+
+import (
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	payload := []byte(`{"type":"pokemon.created","pokemon":{"name":"pikachu"}}`)
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	// The receiver listens on loopback, which the real sender refuses
+	sender := newWebhookSender(nil)
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+	webhook := &domain.Webhook{ID: 1, URL: receiver.URL, Secret: "s3cret"}
+	delivery := &domain.WebhookDelivery{ID: 42, WebhookID: 1, EventType: domain.EventPokemonCreated, Payload: payload}
+
+	status, err := sender.Send(webhook, delivery)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "pokemon.created", received.Header.Get(WebhookEventHeader))
+	assert.Equal(t, "42", received.Header.Get(WebhookDeliveryHeader))
+	assert.Equal(t, "1700000000", received.Header.Get(WebhookTimestampHeader))
+
+	// The receiver verifies the signature with the shared secret
+	expected := "sha256=" + SignWebhook("s3cret", "1700000000", payload)
+	assert.True(t, hmac.Equal([]byte(expected), []byte(received.Header.Get(WebhookSignatureHeader))))
+	assert.NotEqual(t, expected, "sha256="+SignWebhook("wrong", "1700000000", payload))
+}
+
+func TestWebhookSender_Failures(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	sender := newWebhookSender(nil)
+	delivery := &domain.WebhookDelivery{ID: 1, Payload: []byte(`{}`)}
+
+	status, err := sender.Send(&domain.Webhook{URL: receiver.URL, Secret: "s"}, delivery)
+	assert.EqualError(t, err, "webhook responded with status 503")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	receiver.Close()
+	status, err = sender.Send(&domain.Webhook{URL: receiver.URL, Secret: "s"}, delivery)
+	assert.Error(t, err)
+	assert.Zero(t, status)
+}
+
+func TestWebhookSender_BlocksInternalAddresses(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	status, err := NewWebhookSender().Send(&domain.Webhook{URL: receiver.URL, Secret: "s"}, &domain.WebhookDelivery{ID: 1, Payload: []byte(`{}`)})
+
+	assert.ErrorContains(t, err, "webhook address 127.0.0.1 is not allowed")
+	assert.Zero(t, status)
+	assert.False(t, called)
+}
+
+func TestWebhookSender_DoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer internal.Close()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer receiver.Close()
+
+	status, err := newWebhookSender(nil).Send(&domain.Webhook{URL: receiver.URL, Secret: "s"}, &domain.WebhookDelivery{ID: 1, Payload: []byte(`{}`)})
+
+	assert.EqualError(t, err, "webhook responded with status 302")
+	assert.Equal(t, http.StatusFound, status)
+	assert.False(t, redirected)
+}
+
+func TestPublicAddressOnly(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"[::1]:80", false},
+		{"169.254.169.254:80", false},
+		{"[fe80::1]:80", false},
+		{"10.0.0.5:80", false},
+		{"172.16.0.1:80", false},
+		{"192.168.1.1:80", false},
+		{"[fd00::1]:80", false},
+		{"0.0.0.0:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := publicAddressOnly("tcp", tt.address, nil)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// HMAC-SHA256 of "1.{}" under "key"
+	assert.Equal(t, "1ba6b8171186efc613e8bcc0cbdab2748f24984d7c5a84faa2637afa0e40d224", SignWebhook("key", "1", []byte("{}")))
+}
@@ -0,0 +1,165 @@
+package formats
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"pokemon-api/internal/core/domain"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxLineSize bounds a single NDJSON line
+const maxLineSize = 1 << 20
+
+// Decode reads every record of an import file in format. Rows are numbered from 1, not counting
+// the CSV header; a record that cannot be parsed is returned with Error set instead of failing
+// the whole file. An error is returned only when the file as a whole is unreadable.
+func Decode(r io.Reader, format string) ([]domain.PokemonImportRow, error) {
+	switch format {
+	case CSV:
+		return decodeCSV(r)
+	case NDJSON:
+		return decodeNDJSON(r)
+	case YAML:
+		return decodeYAML(r)
+	default:
+		return nil, errors.New("unsupported import format")
+	}
+}
+
+func decodeCSV(r io.Reader) ([]domain.PokemonImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		index[name] = i
+	}
+	if _, ok := index["name"]; !ok {
+		return nil, errors.New("CSV import must start with a header row that has a name column")
+	}
+
+	var rows []domain.PokemonImportRow
+	for n := 1; ; n++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		row := domain.PokemonImportRow{Row: n}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			row.Error = parseErr.Err.Error()
+		} else if len(values) != len(header) {
+			row.Error = fmt.Sprintf("expected %d columns, got %d", len(header), len(values))
+		} else {
+			row.Pokemon, err = csvPokemon(index, values)
+			if err != nil {
+				row.Error = err.Error()
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func csvPokemon(index map[string]int, values []string) (*domain.Pokemon, error) {
+	text := func(column string) string {
+		if i, ok := index[column]; ok {
+			return values[i]
+		}
+		return ""
+	}
+
+	var f fields
+	f.Name, f.Type1, f.Type2 = text("name"), text("type1"), text("type2")
+	numbers := []struct {
+		column string
+		target *int
+	}{
+		{"height", &f.Height}, {"weight", &f.Weight}, {"base_experience", &f.BaseExp},
+		{"hp", &f.HP}, {"attack", &f.Attack}, {"defense", &f.Defense},
+		{"special_attack", &f.SpAttack}, {"special_defense", &f.SpDefense}, {"speed", &f.Speed},
+	}
+	for _, number := range numbers {
+		value := strings.TrimSpace(text(number.column))
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q", number.column, value)
+		}
+		*number.target = parsed
+	}
+	return f.pokemon(), nil
+}
+
+func decodeNDJSON(r io.Reader) ([]domain.PokemonImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []domain.PokemonImportRow
+	for n := 1; scanner.Scan(); {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		row := domain.PokemonImportRow{Row: n}
+		var f fields
+		if err := json.Unmarshal([]byte(line), &f); err != nil {
+			row.Error = "invalid JSON: " + err.Error()
+		} else {
+			row.Pokemon = f.pokemon()
+		}
+		rows = append(rows, row)
+		n++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func decodeYAML(r io.Reader) ([]domain.PokemonImportRow, error) {
+	var document yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.SequenceNode {
+		return nil, errors.New("YAML import must be a list of Pokemon")
+	}
+
+	items := document.Content[0].Content
+	rows := make([]domain.PokemonImportRow, 0, len(items))
+	for i, item := range items {
+		row := domain.PokemonImportRow{Row: i + 1}
+		var f fields
+		if err := item.Decode(&f); err != nil {
+			row.Error = "invalid YAML: " + err.Error()
+		} else {
+			row.Pokemon = f.pokemon()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,87 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode_CSV(t *testing.T) {
+	input := "\ufeffName,Type1,HP,notes\n" +
+		"pikachu,electric,35,starter\n" +
+		"eevee,normal,lots,\n" +
+		"ditto,normal\n"
+
+	rows, err := Decode(strings.NewReader(input), CSV)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "pikachu", rows[0].Pokemon.Name)
+	assert.Equal(t, 35, rows[0].Pokemon.HP)
+	assert.Equal(t, `invalid hp: "lots"`, rows[1].Error)
+	assert.Nil(t, rows[1].Pokemon)
+	assert.Equal(t, 3, rows[2].Row)
+	assert.Equal(t, "expected 4 columns, got 2", rows[2].Error)
+}
+
+func TestDecode_NDJSON(t *testing.T) {
+	input := `{"name":"pikachu","type1":"electric","speed":90}` + "\n\n" +
+		`{"name":"eevee",` + "\n" +
+		`{"name":"ditto","type1":"normal","hp":"48"}` + "\n"
+
+	rows, err := Decode(strings.NewReader(input), NDJSON)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, 90, rows[0].Pokemon.Speed)
+	assert.Equal(t, 2, rows[1].Row)
+	assert.Contains(t, rows[1].Error, "invalid JSON")
+	assert.Equal(t, 3, rows[2].Row)
+	assert.Contains(t, rows[2].Error, "invalid JSON")
+}
+
+func TestDecode_YAML(t *testing.T) {
+	input := "- name: pikachu\n  type1: electric\n  hp: 35\n" +
+		"- name: eevee\n  hp: [1]\n"
+
+	rows, err := Decode(strings.NewReader(input), YAML)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 35, rows[0].Pokemon.HP)
+	assert.Equal(t, 2, rows[1].Row)
+	assert.Contains(t, rows[1].Error, "invalid YAML")
+}
+
+func TestDecode_InvalidFiles(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		format        string
+		expectedError string
+	}{
+		{name: "CSV without name column", input: "species,type1\npikachu,electric\n", format: CSV, expectedError: "CSV import must start with a header row that has a name column"},
+		{name: "YAML mapping", input: "name: pikachu\n", format: YAML, expectedError: "YAML import must be a list of Pokemon"},
+		{name: "malformed YAML", input: "- name: [pikachu\n", format: YAML, expectedError: "invalid YAML"},
+		{name: "unsupported format", input: "{}", format: "application/json", expectedError: "unsupported import format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Decode(strings.NewReader(tt.input), tt.format)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.Nil(t, rows)
+		})
+	}
+}
+
+func TestDecode_Empty(t *testing.T) {
+	for _, format := range []string{CSV, NDJSON, YAML} {
+		rows, err := Decode(strings.NewReader(""), format)
+		assert.NoError(t, err, format)
+		assert.Empty(t, rows, format)
+	}
+}
@@ -0,0 +1,110 @@
+package formats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"pokemon-api/internal/core/domain"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// columns is the CSV header; imports read the same names
+var columns = []string{
+	"id", "name", "type1", "type2", "height", "weight", "base_experience",
+	"hp", "attack", "defense", "special_attack", "special_defense", "speed",
+	"created_by", "created_at", "updated_at",
+}
+
+// Encoder writes Pokemon one at a time, so an export never holds the whole collection
+type Encoder interface {
+	Encode(pokemon *domain.Pokemon) error
+	// Close writes anything still buffered; an empty export is still a valid document
+	Close() error
+}
+
+// NewEncoder returns an encoder for format, which must be CSV, NDJSON or YAML
+func NewEncoder(w io.Writer, format string) Encoder {
+	switch format {
+	case CSV:
+		return &csvEncoder{writer: csv.NewWriter(w)}
+	case NDJSON:
+		return &ndjsonEncoder{encoder: json.NewEncoder(w)}
+	default:
+		return &yamlEncoder{writer: w}
+	}
+}
+
+type csvEncoder struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (e *csvEncoder) Encode(pokemon *domain.Pokemon) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	r := newRecord(pokemon)
+	return e.writer.Write([]string{
+		strconv.FormatUint(uint64(r.ID), 10), r.Name, r.Type1, r.Type2,
+		strconv.Itoa(r.Height), strconv.Itoa(r.Weight), strconv.Itoa(r.BaseExp),
+		strconv.Itoa(r.HP), strconv.Itoa(r.Attack), strconv.Itoa(r.Defense),
+		strconv.Itoa(r.SpAttack), strconv.Itoa(r.SpDefense), strconv.Itoa(r.Speed),
+		r.CreatedBy, r.CreatedAt.UTC().Format(time.RFC3339), r.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.writer.Write(columns)
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(pokemon *domain.Pokemon) error {
+	return e.encoder.Encode(newRecord(pokemon))
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+// yamlEncoder writes a single top-level sequence; each Pokemon is marshalled as a one-item
+// sequence, and consecutive items concatenate into one valid list
+type yamlEncoder struct {
+	writer io.Writer
+	count  int
+}
+
+func (e *yamlEncoder) Encode(pokemon *domain.Pokemon) error {
+	data, err := yaml.Marshal([]record{newRecord(pokemon)})
+	if err != nil {
+		return err
+	}
+	e.count++
+	_, err = e.writer.Write(data)
+	return err
+}
+
+func (e *yamlEncoder) Close() error {
+	if e.count > 0 {
+		return nil
+	}
+	_, err := io.WriteString(e.writer, "[]\n")
+	return err
+}
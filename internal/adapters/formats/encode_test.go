@@ -0,0 +1,81 @@
+package formats
+
+import (
+	"bytes"
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var exportTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func exported() []*domain.Pokemon {
+	return []*domain.Pokemon{
+		{ID: 1, Name: "pikachu", Type1: "electric", Height: 4, Weight: 60, BaseExp: 112, HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90, CreatedBy: "ci", CreatedAt: exportTime, UpdatedAt: exportTime},
+		{ID: 2, Name: "mr-mime", Type1: "psychic", Type2: "fairy", HP: 40, CreatedAt: exportTime, UpdatedAt: exportTime},
+	}
+}
+
+func encodeAll(t *testing.T, format string, pokemon []*domain.Pokemon) string {
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf, format)
+	for _, p := range pokemon {
+		assert.NoError(t, encoder.Encode(p))
+	}
+	assert.NoError(t, encoder.Close())
+	return buf.String()
+}
+
+func TestEncoder_CSV(t *testing.T) {
+	expected := "id,name,type1,type2,height,weight,base_experience,hp,attack,defense,special_attack,special_defense,speed,created_by,created_at,updated_at\n" +
+		"1,pikachu,electric,,4,60,112,35,55,40,50,50,90,ci,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z\n" +
+		"2,mr-mime,psychic,fairy,0,0,0,40,0,0,0,0,0,,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z\n"
+
+	assert.Equal(t, expected, encodeAll(t, CSV, exported()))
+}
+
+func TestEncoder_NDJSON(t *testing.T) {
+	expected := `{"id":1,"name":"pikachu","type1":"electric","height":4,"weight":60,"base_experience":112,"hp":35,"attack":55,"defense":40,"special_attack":50,"special_defense":50,"speed":90,"created_by":"ci","created_at":"2024-05-01T12:00:00Z","updated_at":"2024-05-01T12:00:00Z"}` + "\n" +
+		`{"id":2,"name":"mr-mime","type1":"psychic","type2":"fairy","height":0,"weight":0,"base_experience":0,"hp":40,"attack":0,"defense":0,"special_attack":0,"special_defense":0,"speed":0,"created_at":"2024-05-01T12:00:00Z","updated_at":"2024-05-01T12:00:00Z"}` + "\n"
+
+	assert.Equal(t, expected, encodeAll(t, NDJSON, exported()))
+}
+
+func TestEncoder_YAML(t *testing.T) {
+	var decoded []map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(encodeAll(t, YAML, exported())), &decoded))
+
+	assert.Len(t, decoded, 2)
+	assert.Equal(t, "pikachu", decoded[0]["name"])
+	assert.Equal(t, 90, decoded[0]["speed"])
+	assert.NotContains(t, decoded[0], "type2")
+	assert.Equal(t, "fairy", decoded[1]["type2"])
+	assert.Equal(t, exportTime, decoded[1]["created_at"])
+}
+
+func TestEncoder_Empty(t *testing.T) {
+	assert.Equal(t, "id,name,type1,type2,height,weight,base_experience,hp,attack,defense,special_attack,special_defense,speed,created_by,created_at,updated_at\n", encodeAll(t, CSV, nil))
+	assert.Equal(t, "", encodeAll(t, NDJSON, nil))
+	assert.Equal(t, "[]\n", encodeAll(t, YAML, nil))
+}
+
+func TestEncoder_RoundTrip(t *testing.T) {
+	for _, format := range []string{CSV, NDJSON, YAML} {
+		t.Run(format, func(t *testing.T) {
+			rows, err := Decode(bytes.NewBufferString(encodeAll(t, format, exported())), format)
+			assert.NoError(t, err)
+			assert.Len(t, rows, 2)
+			for i, row := range rows {
+				original := exported()[i]
+				assert.Empty(t, row.Error)
+				assert.Equal(t, original.Name, row.Pokemon.Name)
+				assert.Equal(t, original.Type2, row.Pokemon.Type2)
+				assert.Equal(t, original.BaseStats(), row.Pokemon.BaseStats())
+				assert.Equal(t, original.BaseExp, row.Pokemon.BaseExp)
+			}
+		})
+	}
+}
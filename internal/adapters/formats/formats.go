@@ -0,0 +1,145 @@
+// Package formats encodes the Pokemon collection as CSV, NDJSON or YAML for export,
+// and decodes the same formats for import.
+package formats
+
+import (
+	"mime"
+	"pokemon-api/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported media types; JSON is handled by the regular handlers
+const (
+	CSV    = "text/csv"
+	NDJSON = "application/x-ndjson"
+	YAML   = "application/yaml"
+)
+
+// aliases maps the media types clients commonly send to the supported ones
+var aliases = map[string]string{
+	CSV:                  CSV,
+	NDJSON:               NDJSON,
+	"application/jsonl":  NDJSON,
+	YAML:                 YAML,
+	"application/x-yaml": YAML,
+	"text/yaml":          YAML,
+	"text/x-yaml":        YAML,
+	"application/json":   "",
+	"application/*":      "",
+	"*/*":                "",
+}
+
+// Extension returns the file extension used for downloads in format
+func Extension(format string) string {
+	switch format {
+	case CSV:
+		return "csv"
+	case NDJSON:
+		return "ndjson"
+	default:
+		return "yaml"
+	}
+}
+
+// Negotiate returns the supported format the Accept header prefers, or "" for JSON.
+// Media ranges are ranked by their q value, and earlier ranges win ties.
+func Negotiate(accept string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		format, ok := aliases[mediaType]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best
+}
+
+// FromContentType returns the supported format of a request's Content-Type
+func FromContentType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	format, ok := aliases[mediaType]
+	return format, ok && format != ""
+}
+
+// fields are the columns an import reads; they mirror the JSON representation of domain.Pokemon
+type fields struct {
+	Name      string `json:"name" yaml:"name"`
+	Type1     string `json:"type1" yaml:"type1"`
+	Type2     string `json:"type2,omitempty" yaml:"type2,omitempty"`
+	Height    int    `json:"height" yaml:"height"`
+	Weight    int    `json:"weight" yaml:"weight"`
+	BaseExp   int    `json:"base_experience" yaml:"base_experience"`
+	HP        int    `json:"hp" yaml:"hp"`
+	Attack    int    `json:"attack" yaml:"attack"`
+	Defense   int    `json:"defense" yaml:"defense"`
+	SpAttack  int    `json:"special_attack" yaml:"special_attack"`
+	SpDefense int    `json:"special_defense" yaml:"special_defense"`
+	Speed     int    `json:"speed" yaml:"speed"`
+}
+
+// record is one exported Pokemon
+type record struct {
+	ID        uint `json:"id" yaml:"id"`
+	fields    `yaml:",inline"`
+	CreatedBy string    `json:"created_by,omitempty" yaml:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+func newRecord(p *domain.Pokemon) record {
+	return record{
+		ID: p.ID,
+		fields: fields{
+			Name:      p.Name,
+			Type1:     p.Type1,
+			Type2:     p.Type2,
+			Height:    p.Height,
+			Weight:    p.Weight,
+			BaseExp:   p.BaseExp,
+			HP:        p.HP,
+			Attack:    p.Attack,
+			Defense:   p.Defense,
+			SpAttack:  p.SpAttack,
+			SpDefense: p.SpDefense,
+			Speed:     p.Speed,
+		},
+		CreatedBy: p.CreatedBy,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func (f fields) pokemon() *domain.Pokemon {
+	return &domain.Pokemon{
+		Name:      f.Name,
+		Type1:     f.Type1,
+		Type2:     f.Type2,
+		Height:    f.Height,
+		Weight:    f.Weight,
+		BaseExp:   f.BaseExp,
+		HP:        f.HP,
+		Attack:    f.Attack,
+		Defense:   f.Defense,
+		SpAttack:  f.SpAttack,
+		SpDefense: f.SpDefense,
+		Speed:     f.Speed,
+	}
+}
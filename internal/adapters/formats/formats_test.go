@@ -0,0 +1,57 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{name: "no header", accept: "", expected: ""},
+		{name: "json", accept: "application/json", expected: ""},
+		{name: "csv", accept: "text/csv", expected: CSV},
+		{name: "ndjson", accept: "application/x-ndjson", expected: NDJSON},
+		{name: "yaml", accept: "application/yaml", expected: YAML},
+		{name: "yaml alias", accept: "text/yaml; charset=utf-8", expected: YAML},
+		{name: "first of equal preference", accept: "text/csv, application/yaml", expected: CSV},
+		{name: "highest q wins", accept: "text/csv;q=0.5, application/x-ndjson", expected: NDJSON},
+		{name: "wildcard prefers json", accept: "*/*", expected: ""},
+		{name: "browser default", accept: "text/html,application/xhtml+xml,*/*;q=0.8", expected: ""},
+		{name: "unsupported only", accept: "text/html", expected: ""},
+		{name: "excluded with q=0", accept: "text/csv;q=0", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.accept))
+		})
+	}
+}
+
+func TestFromContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    string
+		ok          bool
+	}{
+		{contentType: "text/csv", expected: CSV, ok: true},
+		{contentType: "text/csv; charset=utf-8", expected: CSV, ok: true},
+		{contentType: "application/x-ndjson", expected: NDJSON, ok: true},
+		{contentType: "application/x-yaml", expected: YAML, ok: true},
+		{contentType: "application/json", ok: false},
+		{contentType: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			format, ok := FromContentType(tt.contentType)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
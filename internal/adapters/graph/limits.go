@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Type.pokemon and Pokemon.types form a cycle, so without limits one small query could nest them arbitrarily deep
+const (
+	// maxQueryDepth is how deeply fields may nest; pokemons { nodes { types { pokemon { name } } } } is five deep
+	maxQueryDepth = 8
+	// maxQueryComplexity is how many fields a query may select, counting each fragment every time it is spread
+	maxQueryComplexity = 200
+)
+
+// checkLimits rejects a query nested deeper than maxQueryDepth or selecting more than maxQueryComplexity fields.
+// A query that does not parse passes, so graphql.Do reports the syntax error.
+func checkLimits(query string) error {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	fragments := map[string]*ast.SelectionSet{}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment.SelectionSet
+		}
+	}
+
+	m := &measure{fragments: fragments, spreading: map[string]bool{}}
+	for _, definition := range document.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			if depth := m.selections(operation.SelectionSet); depth > m.depth {
+				m.depth = depth
+			}
+		}
+	}
+
+	if m.depth > maxQueryDepth {
+		return fmt.Errorf("query is nested %d levels deep, more than the limit of %d", m.depth, maxQueryDepth)
+	}
+	if m.fields > maxQueryComplexity {
+		return fmt.Errorf("query selects %d fields, more than the limit of %d", m.fields, maxQueryComplexity)
+	}
+	return nil
+}
+
+// measure walks a query's selections, expanding fragment spreads, and counts its fields and deepest nesting
+type measure struct {
+	fragments map[string]*ast.SelectionSet
+	// spreading holds the fragments being expanded, so a fragment that spreads itself is not followed forever;
+	// validation rejects such queries later
+	spreading map[string]bool
+	fields    int
+	depth     int
+}
+
+// selections counts the fields in set and returns how deeply they nest
+func (m *measure) selections(set *ast.SelectionSet) int {
+	if set == nil || m.fields > maxQueryComplexity {
+		return 0
+	}
+
+	deepest := 0
+	for _, selection := range set.Selections {
+		depth := 0
+		switch s := selection.(type) {
+		case *ast.Field:
+			m.fields++
+			depth = 1 + m.selections(s.SelectionSet)
+		case *ast.InlineFragment:
+			depth = m.selections(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Name == nil || m.spreading[s.Name.Value] {
+				continue
+			}
+			m.spreading[s.Name.Value] = true
+			depth = m.selections(m.fragments[s.Name.Value])
+			delete(m.spreading, s.Name.Value)
+		}
+		if depth > deepest {
+			deepest = depth
+		}
+	}
+	return deepest
+}
@@ -0,0 +1,66 @@
+package graph
+
+import "sync"
+
+// loader batches the lookups made while one level of a query resolves, like a dataloader. Load queues its key
+// and returns a thunk; the executor calls thunks only after every field at that level has resolved, so the first
+// thunk fetches all queued keys with one call to batch. Results are cached for the rest of the request.
+type loader struct {
+	batch func(keys []string) (map[string]interface{}, error)
+
+	mu      sync.Mutex
+	pending []string
+	results map[string]loadResult
+}
+
+type loadResult struct {
+	value interface{}
+	err   error
+}
+
+func newLoader(batch func(keys []string) (map[string]interface{}, error)) *loader {
+	return &loader{
+		batch:   batch,
+		results: make(map[string]loadResult),
+	}
+}
+
+// Load returns a thunk resolving to the value batch returned for key, or nil if it returned none
+func (l *loader) Load(key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.results[key]; !ok {
+			l.flush()
+		}
+		result := l.results[key]
+		return result.value, result.err
+	}
+}
+
+// flush fetches every pending key; a failed batch fails every key in it
+func (l *loader) flush() {
+	keys := make([]string, 0, len(l.pending))
+	for _, key := range l.pending {
+		if _, ok := l.results[key]; !ok {
+			l.results[key] = loadResult{}
+			keys = append(keys, key)
+		}
+	}
+	l.pending = nil
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.batch(keys)
+	for _, key := range keys {
+		l.results[key] = loadResult{value: values[key], err: err}
+	}
+}
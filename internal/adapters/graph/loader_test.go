@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader_BatchesPendingKeys(t *testing.T) {
+	var batches [][]string
+	l := newLoader(func(keys []string) (map[string]interface{}, error) {
+		batches = append(batches, keys)
+		values := make(map[string]interface{})
+		for _, key := range keys {
+			if key != "missing" {
+				values[key] = key + "!"
+			}
+		}
+		return values, nil
+	})
+
+	fire := l.Load("fire")
+	water := l.Load("water")
+	fireAgain := l.Load("fire")
+	missing := l.Load("missing")
+
+	for thunk, expected := range map[*func() (interface{}, error)]interface{}{&fire: "fire!", &water: "water!", &fireAgain: "fire!", &missing: nil} {
+		value, err := (*thunk)()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+	assert.Equal(t, [][]string{{"fire", "water", "missing"}}, batches)
+
+	// Cached keys are not fetched again
+	value, err := l.Load("water")()
+	assert.NoError(t, err)
+	assert.Equal(t, "water!", value)
+	assert.Len(t, batches, 1)
+}
+
+func TestLoader_BatchErrorFailsEveryKey(t *testing.T) {
+	l := newLoader(func(keys []string) (map[string]interface{}, error) {
+		return nil, errors.New("database error")
+	})
+
+	fire := l.Load("fire")
+	water := l.Load("water")
+
+	_, err := fire()
+	assert.EqualError(t, err, "database error")
+	_, err = water()
+	assert.EqualError(t, err, "database error")
+}
@@ -0,0 +1,516 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	cursorPrefix    = "pokemon:"
+	// createPokemonLimitName is the REST create route's limit, so both APIs draw on one bucket per client
+	createPokemonLimitName = "create-pokemon"
+)
+
+// Request is a GraphQL request as clients send it
+type Request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	// RequestID and TenantID are set from the HTTP request, never from the request body
+	RequestID string `json:"-" form:"-"`
+	TenantID  string `json:"-" form:"-"`
+}
+
+type contextKey int
+
+const requestStateKey contextKey = iota
+
+// requestState is what resolvers need for one request: the caller, the service scoped to its tenant and the
+// request's loaders
+type requestState struct {
+	principal     *domain.Principal
+	requestID     string
+	service       ports.PokemonService
+	pokemonByType *loader
+}
+
+// pokemonType is the source value of the Type object
+type pokemonType struct {
+	name string
+}
+
+// Executor runs GraphQL requests against the Pokemon service
+type Executor struct {
+	service ports.PokemonService
+	// limits and createLimit hold each createPokemon mutation to the REST create route's limit, since every one
+	// may call PokeAPI; a limit with no burst disables it
+	limits      ports.RateLimitStore
+	createLimit domain.RateLimit
+	schema      graphql.Schema
+}
+
+func NewExecutor(service ports.PokemonService, limits ports.RateLimitStore, createLimit domain.RateLimit) (*Executor, error) {
+	e := &Executor{service: service, limits: limits, createLimit: createLimit}
+	schema, err := e.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	e.schema = schema
+	return e, nil
+}
+
+// Execute runs req on behalf of principal, which may be nil for anonymous callers, in req's tenant or the default one.
+// Queries over the depth or complexity limits are refused before any field resolves.
+func (e *Executor) Execute(ctx context.Context, principal *domain.Principal, req Request) *graphql.Result {
+	if err := checkLimits(req.Query); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	tenantID := req.TenantID
+	if tenantID == "" {
+		tenantID = domain.DefaultTenantID
+	}
+	service := e.service.ForTenant(tenantID)
+	state := &requestState{
+		principal:     principal,
+		requestID:     req.RequestID,
+		service:       service,
+		pokemonByType: newLoader(pokemonByTypeLoader(service)),
+	}
+	return graphql.Do(graphql.Params{
+		Schema:         e.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        context.WithValue(ctx, requestStateKey, state),
+	})
+}
+
+func stateFrom(ctx context.Context) *requestState {
+	state, _ := ctx.Value(requestStateKey).(*requestState)
+	return state
+}
+
+// pokemonByTypeLoader answers every type requested at one level of a query with a single query for Pokemon of
+// any of those types
+func pokemonByTypeLoader(service ports.PokemonService) func([]string) (map[string]interface{}, error) {
+	return func(types []string) (map[string]interface{}, error) {
+		page, err := service.ListPokemonPage(domain.PokemonPageQuery{Types: types})
+		if err != nil {
+			return nil, err
+		}
+
+		byType := make(map[string]interface{}, len(types))
+		for _, name := range types {
+			matches := []*domain.Pokemon{}
+			for _, pokemon := range page.Pokemon {
+				if pokemon.Type1 == name || pokemon.Type2 == name {
+					matches = append(matches, pokemon)
+				}
+			}
+			byType[name] = matches
+		}
+		return byType, nil
+	}
+}
+
+func (e *Executor) buildSchema() (graphql.Schema, error) {
+	statsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Stats",
+		Description: "Base stats",
+		Fields: graphql.Fields{
+			"hp":             intField(func(s domain.StatSpread) int { return s.HP }),
+			"attack":         intField(func(s domain.StatSpread) int { return s.Attack }),
+			"defense":        intField(func(s domain.StatSpread) int { return s.Defense }),
+			"specialAttack":  intField(func(s domain.StatSpread) int { return s.SpAttack }),
+			"specialDefense": intField(func(s domain.StatSpread) int { return s.SpDefense }),
+			"speed":          intField(func(s domain.StatSpread) int { return s.Speed }),
+			"total":          intField(func(s domain.StatSpread) int { return s.Total() }),
+		},
+	})
+
+	typeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Type",
+		Description: "One of the eighteen Pokemon types",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*pokemonType).name, nil
+				},
+			},
+			"weakTo": &graphql.Field{
+				Type:        stringList,
+				Description: "Attacking types that deal double damage to this type",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return attackTypes(func(m float64) bool { return m > 1 }, p.Source.(*pokemonType).name), nil
+				},
+			},
+			"resists": &graphql.Field{
+				Type:        stringList,
+				Description: "Attacking types that deal half damage to this type",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return attackTypes(func(m float64) bool { return m > 0 && m < 1 }, p.Source.(*pokemonType).name), nil
+				},
+			},
+			"immuneTo": &graphql.Field{
+				Type:        stringList,
+				Description: "Attacking types that deal no damage to this type",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return attackTypes(func(m float64) bool { return m == 0 }, p.Source.(*pokemonType).name), nil
+				},
+			},
+		},
+	})
+
+	pokemonObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Pokemon",
+		Description: "A species stored in the catalog",
+		Fields: graphql.Fields{
+			"id":   pokemonField(graphql.NewNonNull(graphql.ID), func(p *domain.Pokemon) interface{} { return strconv.FormatUint(uint64(p.ID), 10) }),
+			"name": pokemonField(graphql.NewNonNull(graphql.String), func(p *domain.Pokemon) interface{} { return p.Name }),
+			"dexNumber": pokemonField(graphql.Int, func(p *domain.Pokemon) interface{} {
+				if p.DexNumber == 0 {
+					return nil
+				}
+				return p.DexNumber
+			}),
+			"height":         pokemonField(graphql.NewNonNull(graphql.Int), func(p *domain.Pokemon) interface{} { return p.Height }),
+			"weight":         pokemonField(graphql.NewNonNull(graphql.Int), func(p *domain.Pokemon) interface{} { return p.Weight }),
+			"baseExperience": pokemonField(graphql.NewNonNull(graphql.Int), func(p *domain.Pokemon) interface{} { return p.BaseExp }),
+			"stats":          pokemonField(graphql.NewNonNull(statsType), func(p *domain.Pokemon) interface{} { return p.BaseStats() }),
+			"abilities": pokemonField(stringList, func(p *domain.Pokemon) interface{} {
+				if p.Abilities == nil {
+					return []string{}
+				}
+				return p.Abilities
+			}),
+			"flavorText": pokemonField(graphql.String, func(p *domain.Pokemon) interface{} {
+				if p.FlavorText == "" {
+					return nil
+				}
+				return p.FlavorText
+			}),
+			"generation": pokemonField(graphql.Int, func(p *domain.Pokemon) interface{} {
+				if p.Generation == 0 {
+					return nil
+				}
+				return p.Generation
+			}),
+			"createdBy": pokemonField(graphql.String, func(p *domain.Pokemon) interface{} { return p.CreatedBy }),
+			"createdAt": pokemonField(graphql.NewNonNull(graphql.DateTime), func(p *domain.Pokemon) interface{} { return p.CreatedAt }),
+			"updatedAt": pokemonField(graphql.NewNonNull(graphql.DateTime), func(p *domain.Pokemon) interface{} { return p.UpdatedAt }),
+			"types": pokemonField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typeType))), func(p *domain.Pokemon) interface{} {
+				var types []*pokemonType
+				for _, name := range p.Types() {
+					types = append(types, &pokemonType{name: name})
+				}
+				return types
+			}),
+			"weaknesses": &graphql.Field{
+				Type:        stringList,
+				Description: "Attacking types that deal more than normal damage against both of the Pokemon's types together",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return attackTypes(func(m float64) bool { return m > 1 }, p.Source.(*domain.Pokemon).Types()...), nil
+				},
+			},
+		},
+	})
+
+	// The Type to Pokemon relation closes the cycle, so it is added once both objects exist
+	typeType.AddFieldConfig("pokemon", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pokemonObject))),
+		Description: "Stored Pokemon of this type, loaded in one batch per query level",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return stateFrom(p.Context).pokemonByType.Load(p.Source.(*pokemonType).name), nil
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"endCursor":   &graphql.Field{Type: graphql.String},
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PokemonConnection",
+		Fields: graphql.Fields{
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"nodes":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pokemonObject)))},
+			"pageInfo":   &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PokemonFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"type":             &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Either of the Pokemon's types"},
+			"nameContains":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"createdBy":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"minBaseStatTotal": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"pokemon": &graphql.Field{
+				Type:        pokemonObject,
+				Description: "A stored Pokemon by ID, name or national dex number",
+				Args: graphql.FieldConfigArgument{
+					"id":        &graphql.ArgumentConfig{Type: graphql.ID},
+					"name":      &graphql.ArgumentConfig{Type: graphql.String},
+					"dexNumber": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: e.resolvePokemon,
+			},
+			"pokemons": &graphql.Field{
+				Type:        graphql.NewNonNull(connectionType),
+				Description: "Stored Pokemon in ID order, filtered and paginated by cursor",
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: e.resolvePokemons,
+			},
+			"types": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(typeType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					types := make([]*pokemonType, 0, len(domain.PokemonTypes))
+					for _, name := range domain.PokemonTypes {
+						types = append(types, &pokemonType{name: name})
+					}
+					return types, nil
+				},
+			},
+			"type": &graphql.Field{
+				Type: typeType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := strings.ToLower(p.Args["name"].(string))
+					if !domain.IsValidType(name) {
+						return nil, nil
+					}
+					return &pokemonType{name: name}, nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPokemon": &graphql.Field{
+				Type:        graphql.NewNonNull(pokemonObject),
+				Description: "Fetch a Pokemon from PokeAPI and store it; requires the editor role",
+				Args: graphql.FieldConfigArgument{
+					"name":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"type1": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"type2": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: e.resolveCreatePokemon,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+func (e *Executor) resolvePokemon(p graphql.ResolveParams) (interface{}, error) {
+	id, hasID := p.Args["id"].(string)
+	name, hasName := p.Args["name"].(string)
+	dexNumber, hasDexNumber := p.Args["dexNumber"].(int)
+
+	given := 0
+	for _, has := range []bool{hasID, hasName, hasDexNumber} {
+		if has {
+			given++
+		}
+	}
+	if given != 1 {
+		return nil, errors.New("pass exactly one of id, name or dexNumber")
+	}
+
+	var pokemon *domain.Pokemon
+	var err error
+	switch {
+	case hasID:
+		parsed, parseErr := strconv.ParseUint(id, 10, 32)
+		if parseErr != nil {
+			return nil, errors.New("invalid pokemon ID")
+		}
+		pokemon, err = stateFrom(p.Context).service.GetPokemon(uint(parsed))
+	case hasName:
+		pokemon, err = stateFrom(p.Context).service.GetPokemonByName(name)
+	default:
+		pokemon, err = stateFrom(p.Context).service.GetPokemonByDexNumber(dexNumber)
+	}
+
+	if err != nil {
+		if err.Error() == "pokemon not found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pokemon, nil
+}
+
+func (e *Executor) resolvePokemons(p graphql.ResolveParams) (interface{}, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 0 || first > maxPageSize {
+		return nil, errors.New("first must be between 0 and 100")
+	}
+	var afterID uint64
+	if after, ok := p.Args["after"].(string); ok {
+		var err error
+		afterID, err = decodeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filter, _ := p.Args["filter"].(map[string]interface{})
+
+	// A zero limit would return every match, so first: 0 fetches one Pokemon only to tell whether there are more
+	page, err := stateFrom(p.Context).service.ListPokemonPage(pageQuery(filter, uint(afterID), max(first, 1)))
+	if err != nil {
+		return nil, err
+	}
+	if first == 0 {
+		page.HasMore = len(page.Pokemon) > 0
+		page.Pokemon = []*domain.Pokemon{}
+	}
+
+	var endCursor interface{}
+	if len(page.Pokemon) > 0 {
+		endCursor = encodeCursor(page.Pokemon[len(page.Pokemon)-1].ID)
+	}
+	return map[string]interface{}{
+		"totalCount": page.Total,
+		"nodes":      page.Pokemon,
+		"pageInfo": map[string]interface{}{
+			"endCursor":   endCursor,
+			"hasNextPage": page.HasMore,
+		},
+	}, nil
+}
+
+func (e *Executor) resolveCreatePokemon(p graphql.ResolveParams) (interface{}, error) {
+	state := stateFrom(p.Context)
+	principal := state.principal
+	if principal == nil || !principal.HasRole(domain.RoleEditor) {
+		return nil, errors.New("insufficient permissions")
+	}
+	// Each mutation takes its own token, so aliasing createPokemon many times in one request is limited too
+	if err := e.takeCreateToken(principal); err != nil {
+		return nil, err
+	}
+
+	req := &domain.CreatePokemonRequest{
+		Name:      p.Args["name"].(string),
+		Type1:     p.Args["type1"].(string),
+		CreatedBy: principal.Subject,
+		RequestID: state.requestID,
+	}
+	if type2, ok := p.Args["type2"].(string); ok {
+		req.Type2 = type2
+	}
+	return state.service.CreatePokemon(req)
+}
+
+// takeCreateToken takes a token from the principal's create-pokemon bucket. Mutations are let through if the store
+// fails, as REST requests are.
+func (e *Executor) takeCreateToken(principal *domain.Principal) error {
+	if e.limits == nil || e.createLimit.Burst <= 0 {
+		return nil
+	}
+	decision, err := e.limits.Take(createPokemonLimitName+":"+principal.ClientKey(), e.createLimit)
+	if err != nil {
+		log.Printf("rate limiter %s: %v", createPokemonLimitName, err)
+		return nil
+	}
+	if !decision.Allowed {
+		return fmt.Errorf("rate limit exceeded, retry after %d seconds", int(math.Ceil(decision.RetryAfter.Seconds())))
+	}
+	return nil
+}
+
+// pageQuery turns a PokemonFilter argument and the page arguments into the service's query
+func pageQuery(filter map[string]interface{}, after uint, first int) domain.PokemonPageQuery {
+	query := domain.PokemonPageQuery{After: after, Limit: first}
+	if t, ok := filter["type"].(string); ok {
+		query.Types = []string{t}
+	}
+	query.NameContains, _ = filter["nameContains"].(string)
+	query.CreatedBy, _ = filter["createdBy"].(string)
+	query.MinBaseStatTotal, _ = filter["minBaseStatTotal"].(int)
+	return query
+}
+
+// Cursors are opaque to clients; they wrap the ID of the last Pokemon on the page
+func encodeCursor(id uint) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCursor(cursor string) (uint64, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, errors.New("invalid cursor")
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(raw), cursorPrefix), 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	return id, nil
+}
+
+// attackTypes lists the attacking types whose multiplier against defenders satisfies keep
+func attackTypes(keep func(float64) bool, defenders ...string) []string {
+	types := []string{}
+	for _, attacker := range domain.PokemonTypes {
+		if keep(domain.TypeEffectiveness(attacker, defenders...)) {
+			types = append(types, attacker)
+		}
+	}
+	return types
+}
+
+var stringList = graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))
+
+func pokemonField(fieldType graphql.Output, value func(*domain.Pokemon) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: fieldType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(*domain.Pokemon)), nil
+		},
+	}
+}
+
+func intField(value func(domain.StatSpread) int) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(domain.StatSpread)), nil
+		},
+	}
+}
@@ -0,0 +1,488 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"pokemon-api/internal/adapters/ratelimit"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPokemonService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockPokemonService) ForTenant(tenantID string) ports.PokemonService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockPokemonService) CreatePokemon(req *domain.CreatePokemonRequest) (*domain.Pokemon, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) CreatePokemonFlexible(req *domain.FlexiblePokemonRequest) (*domain.Pokemon, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemon(id uint) (*domain.Pokemon, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonByName(name string) (*domain.Pokemon, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonByDexNumber(number int) (*domain.Pokemon, error) {
+	args := m.Called(number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) ListPokemon() ([]*domain.Pokemon, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) ListPokemonPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonPage), args.Error(1)
+}
+
+func (m *MockPokemonService) UpdatePokemon(id uint, req *domain.UpdatePokemonRequest, expected *time.Time, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, req, expected, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) DeletePokemon(id uint, expected *time.Time, by domain.Attribution) error {
+	args := m.Called(id, expected, by)
+	return args.Error(0)
+}
+
+func (m *MockPokemonService) ListDeletedPokemon() ([]*domain.Pokemon, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) RestorePokemon(id uint, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) PurgeDeletedPokemon(retention time.Duration) (int, error) {
+	args := m.Called(retention)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPokemonService) ExportPokemon(fn func(*domain.Pokemon) error) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockPokemonService) ImportPokemon(rows []domain.PokemonImportRow, by domain.Attribution, dryRun bool) (*domain.PokemonImportReport, error) {
+	args := m.Called(rows, by, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonImportReport), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonHistory(id uint) ([]*domain.PokemonAudit, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PokemonAudit), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonAsOf(id uint, at time.Time) (*domain.Pokemon, error) {
+	args := m.Called(id, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) SearchPokemon(query *domain.PokemonSearchQuery) (*domain.PokemonSearchResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonSearchResult), args.Error(1)
+}
+
+func testCatalog() []*domain.Pokemon {
+	return []*domain.Pokemon{
+		{ID: 3, Name: "charmander", Type1: "fire", HP: 39, Attack: 52, Defense: 43, SpAttack: 60, SpDefense: 50, Speed: 65},
+		{ID: 1, Name: "pikachu", Type1: "electric", HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90, CreatedBy: "ash"},
+		{ID: 2, Name: "charizard", Type1: "fire", Type2: "flying", HP: 78, Attack: 84, Defense: 78, SpAttack: 109, SpDefense: 85, Speed: 100},
+	}
+}
+
+func execute(t *testing.T, service *MockPokemonService, principal *domain.Principal, query string, variables map[string]interface{}) (map[string]interface{}, []string) {
+	executor, err := NewExecutor(service, nil, domain.RateLimit{})
+	assert.NoError(t, err)
+
+	result := executor.Execute(context.Background(), principal, Request{Query: query, Variables: variables})
+	var messages []string
+	for _, e := range result.Errors {
+		messages = append(messages, e.Message)
+	}
+	data, _ := result.Data.(map[string]interface{})
+	return data, messages
+}
+
+func TestExecutor_Pokemon(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockPokemonService)
+		expectedData   map[string]interface{}
+		expectedErrors []string
+	}{
+		{
+			name:  "by ID with only the requested fields",
+			query: `{ pokemon(id: "2") { name stats { speed total } types { name } weaknesses } }`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemon", uint(2)).Return(testCatalog()[2], nil)
+			},
+			expectedData: map[string]interface{}{
+				"pokemon": map[string]interface{}{
+					"name":       "charizard",
+					"stats":      map[string]interface{}{"speed": 100, "total": 534},
+					"types":      []interface{}{map[string]interface{}{"name": "fire"}, map[string]interface{}{"name": "flying"}},
+					"weaknesses": []interface{}{"water", "electric", "rock"},
+				},
+			},
+		},
+		{
+			name:  "by name",
+			query: `{ pokemon(name: "Pikachu") { id createdBy } }`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonByName", "Pikachu").Return(testCatalog()[1], nil)
+			},
+			expectedData: map[string]interface{}{
+				"pokemon": map[string]interface{}{"id": "1", "createdBy": "ash"},
+			},
+		},
+		{
+			name:  "by dex number",
+			query: `{ pokemon(dexNumber: 25) { id dexNumber } }`,
+			setupMock: func(service *MockPokemonService) {
+				pikachu := *testCatalog()[1]
+				pikachu.DexNumber = 25
+				service.On("GetPokemonByDexNumber", 25).Return(&pikachu, nil)
+			},
+			expectedData: map[string]interface{}{
+				"pokemon": map[string]interface{}{"id": "1", "dexNumber": 25},
+			},
+		},
+		{
+			name:  "not found is null",
+			query: `{ pokemon(id: "9") { name } }`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemon", uint(9)).Return(nil, errors.New("pokemon not found"))
+			},
+			expectedData: map[string]interface{}{"pokemon": nil},
+		},
+		{
+			name:           "needs an ID or a name",
+			query:          `{ pokemon { name } }`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedData:   map[string]interface{}{"pokemon": nil},
+			expectedErrors: []string{"pass exactly one of id, name or dexNumber"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+
+			data, errs := execute(t, mockService, nil, tt.query, nil)
+
+			assert.Equal(t, tt.expectedErrors, errs)
+			assert.Equal(t, tt.expectedData, data)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExecutor_ScopesToRequestTenant(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemon", uint(1)).Return(testCatalog()[1], nil)
+	executor, err := NewExecutor(mockService, nil, domain.RateLimit{})
+	assert.NoError(t, err)
+
+	result := executor.Execute(context.Background(), nil, Request{Query: `{ pokemon(id: "1") { name } }`, TenantID: "kanto"})
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
+
+func TestExecutor_PokemonsPagination(t *testing.T) {
+	catalog := testCatalog()
+	mockService := new(MockPokemonService)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{Limit: 2}).
+		Return(&domain.PokemonPage{Pokemon: []*domain.Pokemon{catalog[1], catalog[2]}, Total: 3, HasMore: true}, nil)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{After: 2, Limit: 2}).
+		Return(&domain.PokemonPage{Pokemon: []*domain.Pokemon{catalog[0]}, Total: 3}, nil)
+	query := `query($after: String) {
+		pokemons(first: 2, after: $after) { totalCount nodes { id } pageInfo { endCursor hasNextPage } }
+	}`
+
+	data, errs := execute(t, mockService, nil, query, nil)
+	assert.Empty(t, errs)
+	page := data["pokemons"].(map[string]interface{})
+	assert.Equal(t, 3, page["totalCount"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}}, page["nodes"])
+	pageInfo := page["pageInfo"].(map[string]interface{})
+	assert.Equal(t, true, pageInfo["hasNextPage"])
+
+	data, errs = execute(t, mockService, nil, query, map[string]interface{}{"after": pageInfo["endCursor"]})
+	assert.Empty(t, errs)
+	page = data["pokemons"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "3"}}, page["nodes"])
+	assert.Equal(t, false, page["pageInfo"].(map[string]interface{})["hasNextPage"])
+
+	_, errs = execute(t, mockService, nil, query, map[string]interface{}{"after": "bogus"})
+	assert.Equal(t, []string{"invalid cursor"}, errs)
+	mockService.AssertExpectations(t)
+}
+
+func TestExecutor_PokemonsFirstZero(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{Limit: 1}).
+		Return(&domain.PokemonPage{Pokemon: []*domain.Pokemon{testCatalog()[1]}, Total: 3, HasMore: true}, nil)
+
+	data, errs := execute(t, mockService, nil, `{ pokemons(first: 0) { totalCount nodes { id } pageInfo { hasNextPage } } }`, nil)
+
+	assert.Empty(t, errs)
+	page := data["pokemons"].(map[string]interface{})
+	assert.Equal(t, 3, page["totalCount"])
+	assert.Equal(t, []interface{}{}, page["nodes"])
+	assert.Equal(t, true, page["pageInfo"].(map[string]interface{})["hasNextPage"])
+}
+
+func TestExecutor_PokemonsFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        string
+		expectedQuery domain.PokemonPageQuery
+	}{
+		{name: "by type", filter: `{type: "FIRE"}`, expectedQuery: domain.PokemonPageQuery{Types: []string{"FIRE"}}},
+		{name: "by name", filter: `{nameContains: "char"}`, expectedQuery: domain.PokemonPageQuery{NameContains: "char"}},
+		{name: "by creator", filter: `{createdBy: "ash"}`, expectedQuery: domain.PokemonPageQuery{CreatedBy: "ash"}},
+		{name: "by stat total", filter: `{type: "fire", minBaseStatTotal: 500}`, expectedQuery: domain.PokemonPageQuery{Types: []string{"fire"}, MinBaseStatTotal: 500}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expectedQuery.Limit = defaultPageSize
+			mockService := new(MockPokemonService)
+			mockService.On("ListPokemonPage", tt.expectedQuery).Return(&domain.PokemonPage{Pokemon: []*domain.Pokemon{testCatalog()[2]}, Total: 1}, nil)
+
+			data, errs := execute(t, mockService, nil, `{ pokemons(filter: `+tt.filter+`) { nodes { id } } }`, nil)
+
+			assert.Empty(t, errs)
+			assert.Equal(t, []interface{}{map[string]interface{}{"id": "2"}}, data["pokemons"].(map[string]interface{})["nodes"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExecutor_TypeRelationIsBatched(t *testing.T) {
+	catalog := testCatalog()
+	mockService := new(MockPokemonService)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{Limit: defaultPageSize}).
+		Return(&domain.PokemonPage{Pokemon: []*domain.Pokemon{catalog[1], catalog[2]}, Total: 2}, nil)
+	mockService.On("ListPokemonPage", mock.MatchedBy(func(query domain.PokemonPageQuery) bool {
+		types := append([]string(nil), query.Types...)
+		sort.Strings(types)
+		return reflect.DeepEqual(types, []string{"electric", "fire", "flying"}) && query.Limit == 0
+	})).Return(&domain.PokemonPage{Pokemon: []*domain.Pokemon{catalog[0], catalog[1], catalog[2]}, Total: 3}, nil)
+
+	data, errs := execute(t, mockService, nil, `{ pokemons { nodes { name types { name pokemon { name } } } } }`, nil)
+
+	assert.Empty(t, errs)
+	nodes := data["pokemons"].(map[string]interface{})["nodes"].([]interface{})
+	charizardTypes := nodes[1].(map[string]interface{})["types"].([]interface{})
+	flying := charizardTypes[1].(map[string]interface{})
+	assert.Equal(t, "flying", flying["name"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "charizard"}}, flying["pokemon"])
+	// One query for the page and one for every type on it, not one per type
+	mockService.AssertNumberOfCalls(t, "ListPokemonPage", 2)
+}
+
+func TestExecutor_QueryLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{
+			name:          "too deep",
+			query:         `{ types { pokemon { types { pokemon { types { pokemon { types { pokemon { name } } } } } } } } }`,
+			expectedError: "query is nested 9 levels deep, more than the limit of 8",
+		},
+		{
+			name: "too deep through fragments",
+			query: `{ types { ...deep } }
+				fragment deep on Type { pokemon { types { pokemon { types { pokemon { types { pokemon { name } } } } } } } }`,
+			expectedError: "query is nested 9 levels deep, more than the limit of 8",
+		},
+		{
+			name:          "too many fields",
+			query:         `{ types { ...t } } fragment t on Type { ` + strings.Repeat("name ", 200) + `}`,
+			expectedError: "query selects 201 fields, more than the limit of 200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+
+			data, errs := execute(t, mockService, nil, tt.query, nil)
+
+			assert.Nil(t, data)
+			assert.Equal(t, []string{tt.expectedError}, errs)
+			// Nothing resolves, so no Pokemon are loaded
+			mockService.AssertNotCalled(t, "ListPokemonPage", mock.Anything)
+		})
+	}
+}
+
+func TestExecutor_Types(t *testing.T) {
+	data, errs := execute(t, new(MockPokemonService), nil, `{ type(name: "Ghost") { name weakTo resists immuneTo } missing: type(name: "sound") { name } }`, nil)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{
+		"name":     "ghost",
+		"weakTo":   []interface{}{"ghost", "dark"},
+		"resists":  []interface{}{"poison", "bug"},
+		"immuneTo": []interface{}{"normal", "fighting"},
+	}, data["type"])
+	assert.Nil(t, data["missing"])
+}
+
+func TestExecutor_CreatePokemon(t *testing.T) {
+	mutation := `mutation { createPokemon(name: "eevee", type1: "normal") { id name } }`
+	editor := &domain.Principal{Subject: "misty", Roles: []string{domain.RoleEditor}}
+	reader := &domain.Principal{Subject: "brock", Roles: []string{domain.RoleReader}}
+
+	tests := []struct {
+		name           string
+		principal      *domain.Principal
+		setupMock      func(*MockPokemonService)
+		expectedData   interface{}
+		expectedErrors []string
+	}{
+		{
+			name:      "editor creates",
+			principal: editor,
+			setupMock: func(service *MockPokemonService) {
+				service.On("CreatePokemon", &domain.CreatePokemonRequest{Name: "eevee", Type1: "normal", CreatedBy: "misty"}).
+					Return(&domain.Pokemon{ID: 4, Name: "eevee", Type1: "normal"}, nil)
+			},
+			expectedData: map[string]interface{}{"createPokemon": map[string]interface{}{"id": "4", "name": "eevee"}},
+		},
+		{
+			name:           "reader is refused",
+			principal:      reader,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedErrors: []string{"insufficient permissions"},
+		},
+		{
+			name:      "service error",
+			principal: editor,
+			setupMock: func(service *MockPokemonService) {
+				service.On("CreatePokemon", mock.AnythingOfType("*domain.CreatePokemonRequest")).Return(nil, errors.New("pokemon with this name already exists"))
+			},
+			expectedErrors: []string{"pokemon with this name already exists"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+
+			data, errs := execute(t, mockService, tt.principal, mutation, nil)
+
+			assert.Equal(t, tt.expectedErrors, errs)
+			if tt.expectedData != nil {
+				assert.Equal(t, tt.expectedData, data)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExecutor_CreatePokemonRateLimit(t *testing.T) {
+	editor := &domain.Principal{Subject: "misty", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}}
+	mockService := new(MockPokemonService)
+	mockService.On("CreatePokemon", mock.AnythingOfType("*domain.CreatePokemonRequest")).Return(&domain.Pokemon{ID: 4, Name: "eevee", Type1: "normal"}, nil).Twice()
+
+	store := ratelimit.NewMemoryStore()
+	executor, err := NewExecutor(mockService, store, domain.PerMinute(3))
+	assert.NoError(t, err)
+	// The client already created a Pokemon over REST, which shares the bucket
+	_, err = store.Take("create-pokemon:api_key:misty", domain.PerMinute(3))
+	assert.NoError(t, err)
+
+	// Aliases run one mutation each, and each takes a token
+	result := executor.Execute(context.Background(), editor, Request{Query: `mutation {
+		a: createPokemon(name: "eevee", type1: "normal") { id }
+		b: createPokemon(name: "eevee", type1: "normal") { id }
+		c: createPokemon(name: "eevee", type1: "normal") { id }
+	}`})
+
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "rate limit exceeded")
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,188 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"pokemon-api/internal/adapters/grpcserver/pokemonv1"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+	pageTokenPrefix = "pokemon:"
+)
+
+type pokemonServer struct {
+	pokemonv1.UnimplementedPokemonServiceServer
+	service ports.PokemonService
+}
+
+func NewPokemonServer(service ports.PokemonService) pokemonv1.PokemonServiceServer {
+	return &pokemonServer{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the tenant authorize resolved for the call
+func (s *pokemonServer) serviceFor(ctx context.Context) ports.PokemonService {
+	return s.service.ForTenant(tenantFrom(ctx))
+}
+
+func (s *pokemonServer) CreatePokemon(ctx context.Context, req *pokemonv1.CreatePokemonRequest) (*pokemonv1.CreatePokemonResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pokemon name is required")
+	}
+	if req.GetType1() == "" {
+		return nil, status.Error(codes.InvalidArgument, "type1 is required")
+	}
+
+	createReq := &domain.CreatePokemonRequest{
+		Name:      req.GetName(),
+		Type1:     req.GetType1(),
+		Type2:     req.GetType2(),
+		RequestID: requestIDFrom(ctx),
+	}
+	if principal := principalFrom(ctx); principal != nil {
+		createReq.CreatedBy = principal.Subject
+	}
+
+	pokemon, err := s.serviceFor(ctx).CreatePokemon(createReq)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return &pokemonv1.CreatePokemonResponse{Pokemon: toProto(pokemon)}, nil
+}
+
+func (s *pokemonServer) GetPokemon(ctx context.Context, req *pokemonv1.GetPokemonRequest) (*pokemonv1.GetPokemonResponse, error) {
+	pokemon, err := s.serviceFor(ctx).GetPokemon(uint(req.GetId()))
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return &pokemonv1.GetPokemonResponse{Pokemon: toProto(pokemon)}, nil
+}
+
+func (s *pokemonServer) ListPokemon(ctx context.Context, req *pokemonv1.ListPokemonRequest) (*pokemonv1.ListPokemonResponse, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "page_size cannot be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	var after uint64
+	if req.GetPageToken() != "" {
+		id, err := decodePageToken(req.GetPageToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		after = id
+	}
+
+	page, err := s.serviceFor(ctx).ListPokemonPage(domain.PokemonPageQuery{After: uint(after), Limit: pageSize})
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	resp := &pokemonv1.ListPokemonResponse{
+		Pokemon:   make([]*pokemonv1.Pokemon, 0, len(page.Pokemon)),
+		TotalSize: int32(page.Total),
+	}
+	for _, pokemon := range page.Pokemon {
+		resp.Pokemon = append(resp.Pokemon, toProto(pokemon))
+	}
+	if page.HasMore {
+		resp.NextPageToken = encodePageToken(page.Pokemon[len(page.Pokemon)-1].ID)
+	}
+	return resp, nil
+}
+
+func (s *pokemonServer) StreamPokemon(req *pokemonv1.StreamPokemonRequest, stream pokemonv1.PokemonService_StreamPokemonServer) error {
+	err := s.serviceFor(stream.Context()).ExportPokemon(func(pokemon *domain.Pokemon) error {
+		return stream.Send(&pokemonv1.StreamPokemonResponse{Pokemon: toProto(pokemon)})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			// Send failed, usually because the client went away
+			return err
+		}
+		return statusFromError(err)
+	}
+	return nil
+}
+
+// statusFromError maps the service's error messages onto gRPC status codes
+func statusFromError(err error) error {
+	var unknown *domain.UnknownPokemonError
+	if errors.As(err, &unknown) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch message := err.Error(); {
+	case message == "pokemon not found":
+		return status.Error(codes.NotFound, message)
+	case message == "pokemon with this name already exists":
+		return status.Error(codes.AlreadyExists, message)
+	case message == "pokemon name is required",
+		message == "type1 must be a known type",
+		message == "type2 must be a known type different from type1":
+		return status.Error(codes.InvalidArgument, message)
+	case message == "failed to fetch Pokemon data: PokeAPI rate limit exceeded":
+		return status.Error(codes.ResourceExhausted, message)
+	case strings.HasPrefix(message, "failed to fetch Pokemon data: "):
+		return status.Error(codes.Unavailable, message)
+	default:
+		return status.Error(codes.Internal, message)
+	}
+}
+
+func toProto(pokemon *domain.Pokemon) *pokemonv1.Pokemon {
+	return &pokemonv1.Pokemon{
+		Id:             uint32(pokemon.ID),
+		DexNumber:      int32(pokemon.DexNumber),
+		Name:           pokemon.Name,
+		Type1:          pokemon.Type1,
+		Type2:          pokemon.Type2,
+		Height:         int32(pokemon.Height),
+		Weight:         int32(pokemon.Weight),
+		BaseExperience: int32(pokemon.BaseExp),
+		Stats: &pokemonv1.Stats{
+			Hp:             int32(pokemon.HP),
+			Attack:         int32(pokemon.Attack),
+			Defense:        int32(pokemon.Defense),
+			SpecialAttack:  int32(pokemon.SpAttack),
+			SpecialDefense: int32(pokemon.SpDefense),
+			Speed:          int32(pokemon.Speed),
+		},
+		CreatedBy:  pokemon.CreatedBy,
+		CreateTime: timestamppb.New(pokemon.CreatedAt),
+		UpdateTime: timestamppb.New(pokemon.UpdatedAt),
+	}
+}
+
+func encodePageToken(id uint) string {
+	return base64.StdEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodePageToken(token string) (uint64, error) {
+	raw, err := base64.StdEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), pageTokenPrefix) {
+		return 0, errors.New("invalid page token")
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(raw), pageTokenPrefix), 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid page token")
+	}
+	return id, nil
+}
@@ -0,0 +1,760 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pokemon/v1/pokemon.proto
+
+package pokemonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Pokemon struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type1          string                 `protobuf:"bytes,3,opt,name=type1,proto3" json:"type1,omitempty"`
+	Type2          string                 `protobuf:"bytes,4,opt,name=type2,proto3" json:"type2,omitempty"`
+	Height         int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Weight         int32                  `protobuf:"varint,6,opt,name=weight,proto3" json:"weight,omitempty"`
+	BaseExperience int32                  `protobuf:"varint,7,opt,name=base_experience,json=baseExperience,proto3" json:"base_experience,omitempty"`
+	Stats          *Stats                 `protobuf:"bytes,8,opt,name=stats,proto3" json:"stats,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreateTime     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// dex_number is the species' national Pokedex number, 0 if unknown.
+	DexNumber     int32 `protobuf:"varint,12,opt,name=dex_number,json=dexNumber,proto3" json:"dex_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pokemon) Reset() {
+	*x = Pokemon{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokemon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokemon) ProtoMessage() {}
+
+func (x *Pokemon) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokemon.ProtoReflect.Descriptor instead.
+func (*Pokemon) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pokemon) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pokemon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pokemon) GetType1() string {
+	if x != nil {
+		return x.Type1
+	}
+	return ""
+}
+
+func (x *Pokemon) GetType2() string {
+	if x != nil {
+		return x.Type2
+	}
+	return ""
+}
+
+func (x *Pokemon) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Pokemon) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Pokemon) GetBaseExperience() int32 {
+	if x != nil {
+		return x.BaseExperience
+	}
+	return 0
+}
+
+func (x *Pokemon) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Pokemon) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Pokemon) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Pokemon) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Pokemon) GetDexNumber() int32 {
+	if x != nil {
+		return x.DexNumber
+	}
+	return 0
+}
+
+type Stats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Hp             int32                  `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	Attack         int32                  `protobuf:"varint,2,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense        int32                  `protobuf:"varint,3,opt,name=defense,proto3" json:"defense,omitempty"`
+	SpecialAttack  int32                  `protobuf:"varint,4,opt,name=special_attack,json=specialAttack,proto3" json:"special_attack,omitempty"`
+	SpecialDefense int32                  `protobuf:"varint,5,opt,name=special_defense,json=specialDefense,proto3" json:"special_defense,omitempty"`
+	Speed          int32                  `protobuf:"varint,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Stats) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *Stats) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *Stats) GetDefense() int32 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *Stats) GetSpecialAttack() int32 {
+	if x != nil {
+		return x.SpecialAttack
+	}
+	return 0
+}
+
+func (x *Stats) GetSpecialDefense() int32 {
+	if x != nil {
+		return x.SpecialDefense
+	}
+	return 0
+}
+
+func (x *Stats) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type CreatePokemonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type1         string                 `protobuf:"bytes,2,opt,name=type1,proto3" json:"type1,omitempty"`
+	Type2         string                 `protobuf:"bytes,3,opt,name=type2,proto3" json:"type2,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePokemonRequest) Reset() {
+	*x = CreatePokemonRequest{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePokemonRequest) ProtoMessage() {}
+
+func (x *CreatePokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePokemonRequest.ProtoReflect.Descriptor instead.
+func (*CreatePokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePokemonRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePokemonRequest) GetType1() string {
+	if x != nil {
+		return x.Type1
+	}
+	return ""
+}
+
+func (x *CreatePokemonRequest) GetType2() string {
+	if x != nil {
+		return x.Type2
+	}
+	return ""
+}
+
+type CreatePokemonResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       *Pokemon               `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePokemonResponse) Reset() {
+	*x = CreatePokemonResponse{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePokemonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePokemonResponse) ProtoMessage() {}
+
+func (x *CreatePokemonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePokemonResponse.ProtoReflect.Descriptor instead.
+func (*CreatePokemonResponse) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePokemonResponse) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type GetPokemonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPokemonRequest) Reset() {
+	*x = GetPokemonRequest{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPokemonRequest) ProtoMessage() {}
+
+func (x *GetPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPokemonRequest.ProtoReflect.Descriptor instead.
+func (*GetPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPokemonRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetPokemonResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       *Pokemon               `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPokemonResponse) Reset() {
+	*x = GetPokemonResponse{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPokemonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPokemonResponse) ProtoMessage() {}
+
+func (x *GetPokemonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPokemonResponse.ProtoReflect.Descriptor instead.
+func (*GetPokemonResponse) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPokemonResponse) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+type ListPokemonRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size defaults to 50 and is capped at 500.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of a previous response; empty for the first page.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPokemonRequest) Reset() {
+	*x = ListPokemonRequest{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPokemonRequest) ProtoMessage() {}
+
+func (x *ListPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPokemonRequest.ProtoReflect.Descriptor instead.
+func (*ListPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPokemonRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPokemonRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListPokemonResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon []*Pokemon             `protobuf:"bytes,1,rep,name=pokemon,proto3" json:"pokemon,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalSize     int32  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPokemonResponse) Reset() {
+	*x = ListPokemonResponse{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPokemonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPokemonResponse) ProtoMessage() {}
+
+func (x *ListPokemonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPokemonResponse.ProtoReflect.Descriptor instead.
+func (*ListPokemonResponse) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPokemonResponse) GetPokemon() []*Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+func (x *ListPokemonResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListPokemonResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type StreamPokemonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPokemonRequest) Reset() {
+	*x = StreamPokemonRequest{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPokemonRequest) ProtoMessage() {}
+
+func (x *StreamPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPokemonRequest.ProtoReflect.Descriptor instead.
+func (*StreamPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{8}
+}
+
+type StreamPokemonResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemon       *Pokemon               `protobuf:"bytes,1,opt,name=pokemon,proto3" json:"pokemon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPokemonResponse) Reset() {
+	*x = StreamPokemonResponse{}
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPokemonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPokemonResponse) ProtoMessage() {}
+
+func (x *StreamPokemonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pokemon_v1_pokemon_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPokemonResponse.ProtoReflect.Descriptor instead.
+func (*StreamPokemonResponse) Descriptor() ([]byte, []int) {
+	return file_pokemon_v1_pokemon_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamPokemonResponse) GetPokemon() *Pokemon {
+	if x != nil {
+		return x.Pokemon
+	}
+	return nil
+}
+
+var File_pokemon_v1_pokemon_proto protoreflect.FileDescriptor
+
+const file_pokemon_v1_pokemon_proto_rawDesc = "" +
+	"\n" +
+	"\x18pokemon/v1/pokemon.proto\x12\n" +
+	"pokemon.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x03\n" +
+	"\aPokemon\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05type1\x18\x03 \x01(\tR\x05type1\x12\x14\n" +
+	"\x05type2\x18\x04 \x01(\tR\x05type2\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x05R\x06weight\x12'\n" +
+	"\x0fbase_experience\x18\a \x01(\x05R\x0ebaseExperience\x12'\n" +
+	"\x05stats\x18\b \x01(\v2\x11.pokemon.v1.StatsR\x05stats\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x12;\n" +
+	"\vcreate_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12\x1d\n" +
+	"\n" +
+	"dex_number\x18\f \x01(\x05R\tdexNumber\"\xaf\x01\n" +
+	"\x05Stats\x12\x0e\n" +
+	"\x02hp\x18\x01 \x01(\x05R\x02hp\x12\x16\n" +
+	"\x06attack\x18\x02 \x01(\x05R\x06attack\x12\x18\n" +
+	"\adefense\x18\x03 \x01(\x05R\adefense\x12%\n" +
+	"\x0especial_attack\x18\x04 \x01(\x05R\rspecialAttack\x12'\n" +
+	"\x0fspecial_defense\x18\x05 \x01(\x05R\x0especialDefense\x12\x14\n" +
+	"\x05speed\x18\x06 \x01(\x05R\x05speed\"V\n" +
+	"\x14CreatePokemonRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05type1\x18\x02 \x01(\tR\x05type1\x12\x14\n" +
+	"\x05type2\x18\x03 \x01(\tR\x05type2\"F\n" +
+	"\x15CreatePokemonResponse\x12-\n" +
+	"\apokemon\x18\x01 \x01(\v2\x13.pokemon.v1.PokemonR\apokemon\"#\n" +
+	"\x11GetPokemonRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"C\n" +
+	"\x12GetPokemonResponse\x12-\n" +
+	"\apokemon\x18\x01 \x01(\v2\x13.pokemon.v1.PokemonR\apokemon\"P\n" +
+	"\x12ListPokemonRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x8b\x01\n" +
+	"\x13ListPokemonResponse\x12-\n" +
+	"\apokemon\x18\x01 \x03(\v2\x13.pokemon.v1.PokemonR\apokemon\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x16\n" +
+	"\x14StreamPokemonRequest\"F\n" +
+	"\x15StreamPokemonResponse\x12-\n" +
+	"\apokemon\x18\x01 \x01(\v2\x13.pokemon.v1.PokemonR\apokemon2\xdb\x02\n" +
+	"\x0ePokemonService\x12T\n" +
+	"\rCreatePokemon\x12 .pokemon.v1.CreatePokemonRequest\x1a!.pokemon.v1.CreatePokemonResponse\x12K\n" +
+	"\n" +
+	"GetPokemon\x12\x1d.pokemon.v1.GetPokemonRequest\x1a\x1e.pokemon.v1.GetPokemonResponse\x12N\n" +
+	"\vListPokemon\x12\x1e.pokemon.v1.ListPokemonRequest\x1a\x1f.pokemon.v1.ListPokemonResponse\x12V\n" +
+	"\rStreamPokemon\x12 .pokemon.v1.StreamPokemonRequest\x1a!.pokemon.v1.StreamPokemonResponse0\x01B>Z<pokemon-api/internal/adapters/grpcserver/pokemonv1;pokemonv1b\x06proto3"
+
+var (
+	file_pokemon_v1_pokemon_proto_rawDescOnce sync.Once
+	file_pokemon_v1_pokemon_proto_rawDescData []byte
+)
+
+func file_pokemon_v1_pokemon_proto_rawDescGZIP() []byte {
+	file_pokemon_v1_pokemon_proto_rawDescOnce.Do(func() {
+		file_pokemon_v1_pokemon_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pokemon_v1_pokemon_proto_rawDesc), len(file_pokemon_v1_pokemon_proto_rawDesc)))
+	})
+	return file_pokemon_v1_pokemon_proto_rawDescData
+}
+
+var file_pokemon_v1_pokemon_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_pokemon_v1_pokemon_proto_goTypes = []any{
+	(*Pokemon)(nil),               // 0: pokemon.v1.Pokemon
+	(*Stats)(nil),                 // 1: pokemon.v1.Stats
+	(*CreatePokemonRequest)(nil),  // 2: pokemon.v1.CreatePokemonRequest
+	(*CreatePokemonResponse)(nil), // 3: pokemon.v1.CreatePokemonResponse
+	(*GetPokemonRequest)(nil),     // 4: pokemon.v1.GetPokemonRequest
+	(*GetPokemonResponse)(nil),    // 5: pokemon.v1.GetPokemonResponse
+	(*ListPokemonRequest)(nil),    // 6: pokemon.v1.ListPokemonRequest
+	(*ListPokemonResponse)(nil),   // 7: pokemon.v1.ListPokemonResponse
+	(*StreamPokemonRequest)(nil),  // 8: pokemon.v1.StreamPokemonRequest
+	(*StreamPokemonResponse)(nil), // 9: pokemon.v1.StreamPokemonResponse
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_pokemon_v1_pokemon_proto_depIdxs = []int32{
+	1,  // 0: pokemon.v1.Pokemon.stats:type_name -> pokemon.v1.Stats
+	10, // 1: pokemon.v1.Pokemon.create_time:type_name -> google.protobuf.Timestamp
+	10, // 2: pokemon.v1.Pokemon.update_time:type_name -> google.protobuf.Timestamp
+	0,  // 3: pokemon.v1.CreatePokemonResponse.pokemon:type_name -> pokemon.v1.Pokemon
+	0,  // 4: pokemon.v1.GetPokemonResponse.pokemon:type_name -> pokemon.v1.Pokemon
+	0,  // 5: pokemon.v1.ListPokemonResponse.pokemon:type_name -> pokemon.v1.Pokemon
+	0,  // 6: pokemon.v1.StreamPokemonResponse.pokemon:type_name -> pokemon.v1.Pokemon
+	2,  // 7: pokemon.v1.PokemonService.CreatePokemon:input_type -> pokemon.v1.CreatePokemonRequest
+	4,  // 8: pokemon.v1.PokemonService.GetPokemon:input_type -> pokemon.v1.GetPokemonRequest
+	6,  // 9: pokemon.v1.PokemonService.ListPokemon:input_type -> pokemon.v1.ListPokemonRequest
+	8,  // 10: pokemon.v1.PokemonService.StreamPokemon:input_type -> pokemon.v1.StreamPokemonRequest
+	3,  // 11: pokemon.v1.PokemonService.CreatePokemon:output_type -> pokemon.v1.CreatePokemonResponse
+	5,  // 12: pokemon.v1.PokemonService.GetPokemon:output_type -> pokemon.v1.GetPokemonResponse
+	7,  // 13: pokemon.v1.PokemonService.ListPokemon:output_type -> pokemon.v1.ListPokemonResponse
+	9,  // 14: pokemon.v1.PokemonService.StreamPokemon:output_type -> pokemon.v1.StreamPokemonResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_pokemon_v1_pokemon_proto_init() }
+func file_pokemon_v1_pokemon_proto_init() {
+	if File_pokemon_v1_pokemon_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pokemon_v1_pokemon_proto_rawDesc), len(file_pokemon_v1_pokemon_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pokemon_v1_pokemon_proto_goTypes,
+		DependencyIndexes: file_pokemon_v1_pokemon_proto_depIdxs,
+		MessageInfos:      file_pokemon_v1_pokemon_proto_msgTypes,
+	}.Build()
+	File_pokemon_v1_pokemon_proto = out.File
+	file_pokemon_v1_pokemon_proto_goTypes = nil
+	file_pokemon_v1_pokemon_proto_depIdxs = nil
+}
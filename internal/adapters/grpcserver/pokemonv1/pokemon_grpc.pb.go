@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pokemon/v1/pokemon.proto
+
+package pokemonv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PokemonService_CreatePokemon_FullMethodName = "/pokemon.v1.PokemonService/CreatePokemon"
+	PokemonService_GetPokemon_FullMethodName    = "/pokemon.v1.PokemonService/GetPokemon"
+	PokemonService_ListPokemon_FullMethodName   = "/pokemon.v1.PokemonService/ListPokemon"
+	PokemonService_StreamPokemon_FullMethodName = "/pokemon.v1.PokemonService/StreamPokemon"
+)
+
+// PokemonServiceClient is the client API for PokemonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PokemonService exposes the Pokemon collection over gRPC, backed by the same service as the REST API.
+type PokemonServiceClient interface {
+	// CreatePokemon fetches the Pokemon from PokeAPI and stores it. Requires the editor role.
+	CreatePokemon(ctx context.Context, in *CreatePokemonRequest, opts ...grpc.CallOption) (*CreatePokemonResponse, error)
+	GetPokemon(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*GetPokemonResponse, error)
+	// ListPokemon returns one page of Pokemon in ID order.
+	ListPokemon(ctx context.Context, in *ListPokemonRequest, opts ...grpc.CallOption) (*ListPokemonResponse, error)
+	// StreamPokemon sends every Pokemon in ID order without loading the collection at once.
+	StreamPokemon(ctx context.Context, in *StreamPokemonRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamPokemonResponse], error)
+}
+
+type pokemonServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPokemonServiceClient(cc grpc.ClientConnInterface) PokemonServiceClient {
+	return &pokemonServiceClient{cc}
+}
+
+func (c *pokemonServiceClient) CreatePokemon(ctx context.Context, in *CreatePokemonRequest, opts ...grpc.CallOption) (*CreatePokemonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePokemonResponse)
+	err := c.cc.Invoke(ctx, PokemonService_CreatePokemon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokemonServiceClient) GetPokemon(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*GetPokemonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPokemonResponse)
+	err := c.cc.Invoke(ctx, PokemonService_GetPokemon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokemonServiceClient) ListPokemon(ctx context.Context, in *ListPokemonRequest, opts ...grpc.CallOption) (*ListPokemonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPokemonResponse)
+	err := c.cc.Invoke(ctx, PokemonService_ListPokemon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokemonServiceClient) StreamPokemon(ctx context.Context, in *StreamPokemonRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamPokemonResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PokemonService_ServiceDesc.Streams[0], PokemonService_StreamPokemon_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPokemonRequest, StreamPokemonResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PokemonService_StreamPokemonClient = grpc.ServerStreamingClient[StreamPokemonResponse]
+
+// PokemonServiceServer is the server API for PokemonService service.
+// All implementations must embed UnimplementedPokemonServiceServer
+// for forward compatibility.
+//
+// PokemonService exposes the Pokemon collection over gRPC, backed by the same service as the REST API.
+type PokemonServiceServer interface {
+	// CreatePokemon fetches the Pokemon from PokeAPI and stores it. Requires the editor role.
+	CreatePokemon(context.Context, *CreatePokemonRequest) (*CreatePokemonResponse, error)
+	GetPokemon(context.Context, *GetPokemonRequest) (*GetPokemonResponse, error)
+	// ListPokemon returns one page of Pokemon in ID order.
+	ListPokemon(context.Context, *ListPokemonRequest) (*ListPokemonResponse, error)
+	// StreamPokemon sends every Pokemon in ID order without loading the collection at once.
+	StreamPokemon(*StreamPokemonRequest, grpc.ServerStreamingServer[StreamPokemonResponse]) error
+	mustEmbedUnimplementedPokemonServiceServer()
+}
+
+// UnimplementedPokemonServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPokemonServiceServer struct{}
+
+func (UnimplementedPokemonServiceServer) CreatePokemon(context.Context, *CreatePokemonRequest) (*CreatePokemonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePokemon not implemented")
+}
+func (UnimplementedPokemonServiceServer) GetPokemon(context.Context, *GetPokemonRequest) (*GetPokemonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPokemon not implemented")
+}
+func (UnimplementedPokemonServiceServer) ListPokemon(context.Context, *ListPokemonRequest) (*ListPokemonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPokemon not implemented")
+}
+func (UnimplementedPokemonServiceServer) StreamPokemon(*StreamPokemonRequest, grpc.ServerStreamingServer[StreamPokemonResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPokemon not implemented")
+}
+func (UnimplementedPokemonServiceServer) mustEmbedUnimplementedPokemonServiceServer() {}
+func (UnimplementedPokemonServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePokemonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PokemonServiceServer will
+// result in compilation errors.
+type UnsafePokemonServiceServer interface {
+	mustEmbedUnimplementedPokemonServiceServer()
+}
+
+func RegisterPokemonServiceServer(s grpc.ServiceRegistrar, srv PokemonServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPokemonServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PokemonService_ServiceDesc, srv)
+}
+
+func _PokemonService_CreatePokemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokemonServiceServer).CreatePokemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokemonService_CreatePokemon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokemonServiceServer).CreatePokemon(ctx, req.(*CreatePokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokemonService_GetPokemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokemonServiceServer).GetPokemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokemonService_GetPokemon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokemonServiceServer).GetPokemon(ctx, req.(*GetPokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokemonService_ListPokemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokemonServiceServer).ListPokemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokemonService_ListPokemon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokemonServiceServer).ListPokemon(ctx, req.(*ListPokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokemonService_StreamPokemon_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPokemonRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PokemonServiceServer).StreamPokemon(m, &grpc.GenericServerStream[StreamPokemonRequest, StreamPokemonResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PokemonService_StreamPokemonServer = grpc.ServerStreamingServer[StreamPokemonResponse]
+
+// PokemonService_ServiceDesc is the grpc.ServiceDesc for PokemonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PokemonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pokemon.v1.PokemonService",
+	HandlerType: (*PokemonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePokemon",
+			Handler:    _PokemonService_CreatePokemon_Handler,
+		},
+		{
+			MethodName: "GetPokemon",
+			Handler:    _PokemonService_GetPokemon_Handler,
+		},
+		{
+			MethodName: "ListPokemon",
+			Handler:    _PokemonService_ListPokemon_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPokemon",
+			Handler:       _PokemonService_StreamPokemon_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pokemon/v1/pokemon.proto",
+}
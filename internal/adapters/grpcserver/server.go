@@ -0,0 +1,253 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"math"
+	"pokemon-api/internal/adapters/grpcserver/pokemonv1"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles is the role each PokemonService method requires, mirroring the REST routes
+var methodRoles = map[string]string{
+	pokemonv1.PokemonService_CreatePokemon_FullMethodName: domain.RoleEditor,
+	pokemonv1.PokemonService_GetPokemon_FullMethodName:    domain.RoleReader,
+	pokemonv1.PokemonService_ListPokemon_FullMethodName:   domain.RoleReader,
+	pokemonv1.PokemonService_StreamPokemon_FullMethodName: domain.RoleReader,
+}
+
+type contextKey int
+
+const (
+	principalKey contextKey = iota
+	tenantKey
+)
+
+// RateLimits are the REST API's per-client limits. gRPC calls take their tokens from the same buckets, so a client
+// cannot get around a limit by switching APIs; a limit with no burst disables it.
+type RateLimits struct {
+	Store ports.RateLimitStore
+	// API applies to every Pokemon method, like the limit shared by every /api/v1 route
+	API domain.RateLimit
+	// CreatePokemon applies to CreatePokemon, which may call PokeAPI, like POST /api/v1/pokemon's own limit
+	CreatePokemon domain.RateLimit
+}
+
+// NewServer builds a gRPC server exposing the Pokemon service together with the standard health and
+// reflection services. Pokemon methods authenticate, pick their tenant and are rate limited like the REST API;
+// health and reflection are open.
+func NewServer(service ports.PokemonService, authService ports.AuthService, tenantService ports.TenantService, limits RateLimits) *grpc.Server {
+	authenticator := &authenticator{service: authService, tenants: tenantService}
+	limiter := &rateLimiter{limits: limits}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.unary, limiter.unary),
+		grpc.ChainStreamInterceptor(authenticator.stream, limiter.stream),
+	)
+
+	pokemonv1.RegisterPokemonServiceServer(server, NewPokemonServer(service))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pokemonv1.PokemonService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+	return server
+}
+
+type authenticator struct {
+	service ports.AuthService
+	tenants ports.TenantService
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize resolves the caller from x-api-key or authorization metadata, checks the method's role and resolves
+// the tenant
+func (a *authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	role, ok := methodRoles[method]
+	if !ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var (
+		principal *domain.Principal
+		err       error
+	)
+	apiKey := firstValue(md, "x-api-key")
+	token, hasToken := strings.CutPrefix(firstValue(md, "authorization"), "Bearer ")
+	switch {
+	case apiKey != "":
+		principal, err = a.service.AuthenticateAPIKey(apiKey)
+	case hasToken:
+		principal, err = a.service.AuthenticateToken(strings.TrimSpace(token))
+	default:
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if err != nil {
+		switch err.Error() {
+		case "invalid API key", "invalid token", "bearer tokens are not enabled":
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if !principal.HasRole(role) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient role")
+	}
+
+	tenantID, err := a.resolveTenant(principal, firstValue(md, "x-tenant-id"))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, principalKey, principal)
+	return context.WithValue(ctx, tenantKey, tenantID), nil
+}
+
+// rateLimiter takes a token from each of the caller's buckets for the method. The bucket names are the REST
+// routes' ("api" and "create-pokemon"), so both APIs count against the same limits.
+type rateLimiter struct {
+	limits RateLimits
+}
+
+func (r *rateLimiter) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := r.take(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (r *rateLimiter) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := r.take(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// take applies the method's limits to the principal authorize stored in ctx; open methods have none. Calls are let
+// through if the store fails, as REST requests are.
+func (r *rateLimiter) take(ctx context.Context, method string) error {
+	principal := principalFrom(ctx)
+	if principal == nil || r.limits.Store == nil {
+		return nil
+	}
+
+	limits := map[string]domain.RateLimit{"api": r.limits.API}
+	if method == pokemonv1.PokemonService_CreatePokemon_FullMethodName {
+		limits["create-pokemon"] = r.limits.CreatePokemon
+	}
+	for _, name := range []string{"api", "create-pokemon"} {
+		limit, ok := limits[name]
+		if !ok || limit.Burst <= 0 {
+			continue
+		}
+		decision, err := r.limits.Store.Take(name+":"+principal.ClientKey(), limit)
+		if err != nil {
+			log.Printf("rate limiter %s: %v", name, err)
+			continue
+		}
+		if !decision.Allowed {
+			retryAfter := strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds())))
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+	}
+	return nil
+}
+
+// resolveTenant applies the HTTP API's rules: operators pick a tenant with the x-tenant-id metadata, and every
+// other credential acts in its bound tenant, or the default tenant, and may not name another
+func (a *authenticator) resolveTenant(principal *domain.Principal, requested string) (string, error) {
+	tenantID := requested
+	if !principal.IsOperator() {
+		if requested != "" && requested != principal.HomeTenant() {
+			return "", status.Error(codes.PermissionDenied, "credential is bound to another tenant")
+		}
+		tenantID = principal.HomeTenant()
+	}
+	if tenantID == "" {
+		tenantID = domain.DefaultTenantID
+	}
+	if !domain.IsValidTenantID(tenantID) {
+		return "", status.Error(codes.InvalidArgument, "invalid tenant ID")
+	}
+
+	if _, err := a.tenants.GetTenant(tenantID); err != nil {
+		if err.Error() == "tenant not found" {
+			return "", status.Error(codes.NotFound, err.Error())
+		}
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	return tenantID, nil
+}
+
+// maxRequestIDLength matches the limit on the HTTP API's X-Request-ID header
+const maxRequestIDLength = 128
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// requestIDFrom returns the client's x-request-id metadata, ignoring values too long to store
+func requestIDFrom(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if id := firstValue(md, "x-request-id"); len(id) <= maxRequestIDLength {
+		return id
+	}
+	return ""
+}
+
+// principalFrom returns the caller authorize stored in ctx, or nil
+func principalFrom(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey).(*domain.Principal)
+	return principal
+}
+
+// tenantFrom returns the tenant authorize stored in ctx, or the default tenant
+func tenantFrom(ctx context.Context) string {
+	if tenantID, _ := ctx.Value(tenantKey).(string); tenantID != "" {
+		return tenantID
+	}
+	return domain.DefaultTenantID
+}
+
+// principalStream carries the authenticated context into streaming handlers
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
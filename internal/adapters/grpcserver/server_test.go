@@ -0,0 +1,552 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"pokemon-api/internal/adapters/grpcserver/pokemonv1"
+	"pokemon-api/internal/adapters/ratelimit"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type MockPokemonService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockPokemonService) ForTenant(tenantID string) ports.PokemonService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockPokemonService) CreatePokemon(req *domain.CreatePokemonRequest) (*domain.Pokemon, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) CreatePokemonFlexible(req *domain.FlexiblePokemonRequest) (*domain.Pokemon, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemon(id uint) (*domain.Pokemon, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonByName(name string) (*domain.Pokemon, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonByDexNumber(number int) (*domain.Pokemon, error) {
+	args := m.Called(number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) ListPokemon() ([]*domain.Pokemon, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) ListPokemonPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonPage), args.Error(1)
+}
+
+func (m *MockPokemonService) UpdatePokemon(id uint, req *domain.UpdatePokemonRequest, expected *time.Time, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, req, expected, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) DeletePokemon(id uint, expected *time.Time, by domain.Attribution) error {
+	args := m.Called(id, expected, by)
+	return args.Error(0)
+}
+
+func (m *MockPokemonService) ListDeletedPokemon() ([]*domain.Pokemon, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) RestorePokemon(id uint, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) PurgeDeletedPokemon(retention time.Duration) (int, error) {
+	args := m.Called(retention)
+	return args.Int(0), args.Error(1)
+}
+
+// ExportPokemon passes the Pokemon given to Return to fn, then returns the configured error
+func (m *MockPokemonService) ExportPokemon(fn func(*domain.Pokemon) error) error {
+	args := m.Called()
+	for _, pokemon := range args.Get(0).([]*domain.Pokemon) {
+		if err := fn(pokemon); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockPokemonService) ImportPokemon(rows []domain.PokemonImportRow, by domain.Attribution, dryRun bool) (*domain.PokemonImportReport, error) {
+	args := m.Called(rows, by, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonImportReport), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonHistory(id uint) ([]*domain.PokemonAudit, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PokemonAudit), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonAsOf(id uint, at time.Time) (*domain.Pokemon, error) {
+	args := m.Called(id, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) SearchPokemon(query *domain.PokemonSearchQuery) (*domain.PokemonSearchResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonSearchResult), args.Error(1)
+}
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) AuthenticateAPIKey(key string) (*domain.Principal, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func (m *MockAuthService) AuthenticateToken(token string) (*domain.Principal, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func (m *MockAuthService) CreateAPIKey(req *domain.APIKeyRequest) (*domain.CreatedAPIKey, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAuthService) EnsureAPIKey(name, key string, scopes []string) error {
+	args := m.Called(name, key, scopes)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ListAPIKeys() ([]*domain.APIKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockTenantService struct {
+	mock.Mock
+}
+
+func (m *MockTenantService) CreateTenant(req *domain.TenantRequest) (*domain.Tenant, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) GetTenant(id string) (*domain.Tenant, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) ListTenants() ([]*domain.Tenant, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) EnsureTenant(id, name string) error {
+	args := m.Called(id, name)
+	return args.Error(0)
+}
+
+var (
+	readerPrincipal   = &domain.Principal{Subject: "viewer", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleReader}}
+	editorPrincipal   = &domain.Principal{Subject: "ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}}
+	kantoPrincipal    = &domain.Principal{Subject: "kanto-ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}, TenantID: "kanto"}
+	operatorPrincipal = &domain.Principal{Subject: "ops", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleReader}, Operator: true}
+)
+
+// setupClient serves NewServer over an in-memory listener; the reader-key, editor-key, kanto-key and operator-key
+// API keys authenticate, and the default, kanto and johto tenants exist
+func setupClient(t *testing.T, service *MockPokemonService) *grpc.ClientConn {
+	return setupLimitedClient(t, service, RateLimits{})
+}
+
+// setupLimitedClient is setupClient with rate limits
+func setupLimitedClient(t *testing.T, service *MockPokemonService, limits RateLimits) *grpc.ClientConn {
+	authService := new(MockAuthService)
+	authService.On("AuthenticateAPIKey", "reader-key").Return(readerPrincipal, nil)
+	authService.On("AuthenticateAPIKey", "editor-key").Return(editorPrincipal, nil)
+	authService.On("AuthenticateAPIKey", "kanto-key").Return(kantoPrincipal, nil)
+	authService.On("AuthenticateAPIKey", "operator-key").Return(operatorPrincipal, nil)
+	authService.On("AuthenticateAPIKey", mock.Anything).Return(nil, errors.New("invalid API key"))
+
+	tenantService := new(MockTenantService)
+	for _, id := range []string{domain.DefaultTenantID, "kanto", "johto"} {
+		tenantService.On("GetTenant", id).Return(&domain.Tenant{ID: id}, nil)
+	}
+	tenantService.On("GetTenant", mock.Anything).Return(nil, errors.New("tenant not found"))
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(service, authService, tenantService, limits)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func withAPIKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func testPokemon(ids ...uint) []*domain.Pokemon {
+	pokemon := make([]*domain.Pokemon, 0, len(ids))
+	for _, id := range ids {
+		pokemon = append(pokemon, &domain.Pokemon{ID: id, Name: "pokemon-" + string(rune('a'+id)), Type1: "normal"})
+	}
+	return pokemon
+}
+
+func TestServer_Authorization(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectedCode codes.Code
+	}{
+		{name: "no credentials", ctx: context.Background(), expectedCode: codes.Unauthenticated},
+		{name: "invalid API key", ctx: withAPIKey("wrong"), expectedCode: codes.Unauthenticated},
+		{name: "reader cannot create", ctx: withAPIKey("reader-key"), expectedCode: codes.PermissionDenied},
+		{name: "editor can create", ctx: withAPIKey("editor-key"), expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			if tt.expectedCode == codes.OK {
+				mockService.On("CreatePokemon", &domain.CreatePokemonRequest{Name: "pikachu", Type1: "electric", CreatedBy: "ci"}).
+					Return(&domain.Pokemon{ID: 25, Name: "pikachu", Type1: "electric", CreatedBy: "ci"}, nil)
+			}
+			client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+			resp, err := client.CreatePokemon(tt.ctx, &pokemonv1.CreatePokemonRequest{Name: "pikachu", Type1: "electric"})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if err == nil {
+				assert.Equal(t, "ci", resp.GetPokemon().GetCreatedBy())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServer_Tenant(t *testing.T) {
+	withTenant := func(key, tenantID string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key, "x-tenant-id", tenantID)
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		expectedCode   codes.Code
+		expectedTenant string
+	}{
+		{name: "default tenant", ctx: withAPIKey("reader-key"), expectedCode: codes.OK, expectedTenant: domain.DefaultTenantID},
+		{name: "operator picks a tenant", ctx: withTenant("operator-key", "johto"), expectedCode: codes.OK, expectedTenant: "johto"},
+		{name: "unbound key names the default tenant", ctx: withTenant("reader-key", domain.DefaultTenantID), expectedCode: codes.OK, expectedTenant: domain.DefaultTenantID},
+		{name: "unbound key names another tenant", ctx: withTenant("reader-key", "johto"), expectedCode: codes.PermissionDenied},
+		{name: "bound key uses its tenant", ctx: withAPIKey("kanto-key"), expectedCode: codes.OK, expectedTenant: "kanto"},
+		{name: "bound key names its own tenant", ctx: withTenant("kanto-key", "kanto"), expectedCode: codes.OK, expectedTenant: "kanto"},
+		{name: "bound key names another tenant", ctx: withTenant("kanto-key", "johto"), expectedCode: codes.PermissionDenied},
+		{name: "unknown tenant", ctx: withTenant("operator-key", "hoenn"), expectedCode: codes.NotFound},
+		{name: "invalid tenant", ctx: withTenant("operator-key", "Not A Slug"), expectedCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			if tt.expectedCode == codes.OK {
+				mockService.On("GetPokemon", uint(25)).Return(&domain.Pokemon{ID: 25, Name: "pikachu", Type1: "electric"}, nil)
+			}
+			client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+			_, err := client.GetPokemon(tt.ctx, &pokemonv1.GetPokemonRequest{Id: 25})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedTenant, mockService.tenantID)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServer_RateLimits(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("CreatePokemon", mock.AnythingOfType("*domain.CreatePokemonRequest")).Return(&domain.Pokemon{ID: 25, Name: "pikachu", Type1: "electric"}, nil).Once()
+	mockService.On("GetPokemon", uint(25)).Return(&domain.Pokemon{ID: 25, Name: "pikachu", Type1: "electric"}, nil)
+	store := ratelimit.NewMemoryStore()
+	client := pokemonv1.NewPokemonServiceClient(setupLimitedClient(t, mockService, RateLimits{
+		Store:         store,
+		API:           domain.PerMinute(3),
+		CreatePokemon: domain.PerMinute(1),
+	}))
+
+	// The editor's first create uses up its create-pokemon bucket, shared with POST /api/v1/pokemon
+	_, err := client.CreatePokemon(withAPIKey("editor-key"), &pokemonv1.CreatePokemonRequest{Name: "pikachu", Type1: "electric"})
+	assert.NoError(t, err)
+	var header metadata.MD
+	_, err = client.CreatePokemon(withAPIKey("editor-key"), &pokemonv1.CreatePokemonRequest{Name: "pikachu", Type1: "electric"}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"60"}, header.Get("retry-after"))
+
+	// Both creates took from its api bucket too, which has one token left
+	_, err = client.GetPokemon(withAPIKey("editor-key"), &pokemonv1.GetPokemonRequest{Id: 25})
+	assert.NoError(t, err)
+	_, err = client.GetPokemon(withAPIKey("editor-key"), &pokemonv1.GetPokemonRequest{Id: 25})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Other clients have their own buckets
+	_, err = client.GetPokemon(withAPIKey("reader-key"), &pokemonv1.GetPokemonRequest{Id: 25})
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonServer_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode codes.Code
+	}{
+		{name: "duplicate", serviceErr: errors.New("pokemon with this name already exists"), expectedCode: codes.AlreadyExists},
+		{name: "unknown type", serviceErr: errors.New("type1 must be a known type"), expectedCode: codes.InvalidArgument},
+		{name: "unknown name", serviceErr: &domain.UnknownPokemonError{Name: "missingno", Suggestions: []string{}}, expectedCode: codes.InvalidArgument},
+		{name: "PokeAPI rate limit", serviceErr: errors.New("failed to fetch Pokemon data: PokeAPI rate limit exceeded"), expectedCode: codes.ResourceExhausted},
+		{name: "PokeAPI down", serviceErr: errors.New("failed to fetch Pokemon data: PokeAPI returned status 502"), expectedCode: codes.Unavailable},
+		{name: "storage failure", serviceErr: errors.New("failed to save Pokemon: connection refused"), expectedCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			mockService.On("CreatePokemon", mock.AnythingOfType("*domain.CreatePokemonRequest")).Return(nil, tt.serviceErr)
+			client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+			_, err := client.CreatePokemon(withAPIKey("editor-key"), &pokemonv1.CreatePokemonRequest{Name: "missingno", Type1: "normal"})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.serviceErr.Error(), status.Convert(err).Message())
+		})
+	}
+}
+
+func TestPokemonServer_CreatePokemon_Validation(t *testing.T) {
+	mockService := new(MockPokemonService)
+	client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+	_, err := client.CreatePokemon(withAPIKey("editor-key"), &pokemonv1.CreatePokemonRequest{Name: "pikachu"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockService.AssertNotCalled(t, "CreatePokemon", mock.Anything)
+}
+
+func TestPokemonServer_GetPokemon(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemon", uint(25)).Return(&domain.Pokemon{ID: 25, Name: "pikachu", Type1: "electric", Speed: 90}, nil)
+	mockService.On("GetPokemon", uint(999)).Return(nil, errors.New("pokemon not found"))
+	client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+	resp, err := client.GetPokemon(withAPIKey("reader-key"), &pokemonv1.GetPokemonRequest{Id: 25})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "pikachu", resp.GetPokemon().GetName())
+	assert.Equal(t, int32(90), resp.GetPokemon().GetStats().GetSpeed())
+
+	_, err = client.GetPokemon(withAPIKey("reader-key"), &pokemonv1.GetPokemonRequest{Id: 999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPokemonServer_ListPokemon(t *testing.T) {
+	mockService := new(MockPokemonService)
+	catalog := testPokemon(1, 2, 3, 4, 5)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{Limit: 2}).Return(&domain.PokemonPage{Pokemon: catalog[0:2], Total: 5, HasMore: true}, nil)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{After: 2, Limit: 2}).Return(&domain.PokemonPage{Pokemon: catalog[2:4], Total: 5, HasMore: true}, nil)
+	mockService.On("ListPokemonPage", domain.PokemonPageQuery{After: 4, Limit: 2}).Return(&domain.PokemonPage{Pokemon: catalog[4:], Total: 5}, nil)
+	client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+	ctx := withAPIKey("reader-key")
+
+	var ids []uint32
+	token := ""
+	for pages := 0; pages < 5; pages++ {
+		resp, err := client.ListPokemon(ctx, &pokemonv1.ListPokemonRequest{PageSize: 2, PageToken: token})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, int32(5), resp.GetTotalSize())
+		for _, pokemon := range resp.GetPokemon() {
+			ids = append(ids, pokemon.GetId())
+		}
+		token = resp.GetNextPageToken()
+		if token == "" {
+			break
+		}
+	}
+	assert.Equal(t, []uint32{1, 2, 3, 4, 5}, ids)
+
+	mockService.AssertExpectations(t)
+
+	_, err := client.ListPokemon(ctx, &pokemonv1.ListPokemonRequest{PageToken: "not-a-token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPokemonServer_ListPokemon_PageSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		pageSize      int32
+		expectedLimit int
+	}{
+		{name: "default", pageSize: 0, expectedLimit: defaultPageSize},
+		{name: "capped", pageSize: 1000, expectedLimit: maxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			mockService.On("ListPokemonPage", domain.PokemonPageQuery{Limit: tt.expectedLimit}).Return(&domain.PokemonPage{Pokemon: testPokemon(1), Total: 1}, nil)
+			client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+			resp, err := client.ListPokemon(withAPIKey("reader-key"), &pokemonv1.ListPokemonRequest{PageSize: tt.pageSize})
+
+			assert.NoError(t, err)
+			assert.Len(t, resp.GetPokemon(), 1)
+			assert.Empty(t, resp.GetNextPageToken())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonServer_StreamPokemon(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("ExportPokemon").Return(testPokemon(1, 2, 3), nil)
+	client := pokemonv1.NewPokemonServiceClient(setupClient(t, mockService))
+
+	stream, err := client.StreamPokemon(withAPIKey("reader-key"), &pokemonv1.StreamPokemonRequest{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var ids []uint32
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids = append(ids, resp.GetPokemon().GetId())
+	}
+	assert.Equal(t, []uint32{1, 2, 3}, ids)
+}
+
+func TestServer_HealthIsOpen(t *testing.T) {
+	client := healthpb.NewHealthClient(setupClient(t, new(MockPokemonService)))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pokemonv1.PokemonService_ServiceDesc.ServiceName})
+
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
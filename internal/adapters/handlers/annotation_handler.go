@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type annotationHandler struct {
+	service ports.AnnotationService
+}
+
+func NewAnnotationHandler(service ports.AnnotationService) *annotationHandler {
+	return &annotationHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *annotationHandler) serviceFor(c *gin.Context) ports.AnnotationService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Get a Pokemon's annotations
+// @Description Get a Pokemon's tags and note, and whether the caller has favorited it
+// @Tags annotations
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Success 200 {object} domain.PokemonAnnotations
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/annotations [get]
+func (h *annotationHandler) GetAnnotations(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	annotations, err := h.serviceFor(c).GetAnnotations(id, attribution(c).Actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, annotations)
+}
+
+// @Summary Tag a Pokemon
+// @Description Attach a tag to a Pokemon. Tags are lowercased with their whitespace collapsed, and are shared by everyone in the tenant. Tagging a Pokemon twice changes nothing.
+// @Tags annotations
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Param tag path string true "Tag, such as \"ou viable\""
+// @Success 200 {object} map[string][]string "The Pokemon's tags"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/tags/{tag} [put]
+func (h *annotationHandler) AddTag(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	tags, err := h.serviceFor(c).AddTag(id, c.Param("tag"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// @Summary Untag a Pokemon
+// @Description Remove a tag from a Pokemon. Removing a tag the Pokemon does not carry changes nothing.
+// @Tags annotations
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Param tag path string true "Tag"
+// @Success 200 {object} map[string][]string "The Pokemon's remaining tags"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/tags/{tag} [delete]
+func (h *annotationHandler) RemoveTag(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	tags, err := h.serviceFor(c).RemoveTag(id, c.Param("tag"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// @Summary Favorite a Pokemon
+// @Description Add a Pokemon to the caller's favorites. Favorites are personal to each API key or token subject.
+// @Tags annotations
+// @Param id path int true "Pokemon ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/favorite [put]
+func (h *annotationHandler) AddFavorite(c *gin.Context) {
+	h.setFavorite(c, true)
+}
+
+// @Summary Unfavorite a Pokemon
+// @Description Remove a Pokemon from the caller's favorites
+// @Tags annotations
+// @Param id path int true "Pokemon ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/favorite [delete]
+func (h *annotationHandler) RemoveFavorite(c *gin.Context) {
+	h.setFavorite(c, false)
+}
+
+func (h *annotationHandler) setFavorite(c *gin.Context, favorite bool) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	if err := h.serviceFor(c).SetFavorite(id, attribution(c).Actor, favorite); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Set a Pokemon's note
+// @Description Replace a Pokemon's markdown note, shared by everyone in the tenant. An empty body removes the note.
+// @Tags annotations
+// @Accept json
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Param note body domain.PokemonNoteRequest true "Markdown note"
+// @Success 200 {object} domain.PokemonNote
+// @Success 204 "The note was removed"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/note [put]
+func (h *annotationHandler) SetNote(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	var req domain.PokemonNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.serviceFor(c).SetNote(id, &req, attribution(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if note == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// @Summary Get the tag cloud
+// @Description Count the stored Pokemon carrying each tag, most used first. Tags no stored Pokemon carries are left out.
+// @Tags annotations
+// @Produce json
+// @Success 200 {array} domain.TagCount
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tags [get]
+func (h *annotationHandler) TagCloud(c *gin.Context) {
+	counts, err := h.serviceFor(c).TagCloud()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+func (h *annotationHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "pokemon not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "tag must be 1 to 50 characters",
+		"note cannot be longer than 10000 characters",
+		"favorites need an authenticated user":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
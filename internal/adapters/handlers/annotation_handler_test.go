@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAnnotationService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockAnnotationService) ForTenant(tenantID string) ports.AnnotationService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockAnnotationService) GetAnnotations(pokemonID uint, subject string) (*domain.PokemonAnnotations, error) {
+	args := m.Called(pokemonID, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonAnnotations), args.Error(1)
+}
+
+func (m *MockAnnotationService) AddTag(pokemonID uint, tag string) ([]string, error) {
+	args := m.Called(pokemonID, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAnnotationService) RemoveTag(pokemonID uint, tag string) ([]string, error) {
+	args := m.Called(pokemonID, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAnnotationService) SetFavorite(pokemonID uint, subject string, favorite bool) error {
+	args := m.Called(pokemonID, subject, favorite)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationService) SetNote(pokemonID uint, req *domain.PokemonNoteRequest, by domain.Attribution) (*domain.PokemonNote, error) {
+	args := m.Called(pokemonID, req, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonNote), args.Error(1)
+}
+
+func (m *MockAnnotationService) TagCloud() ([]domain.TagCount, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TagCount), args.Error(1)
+}
+
+func (m *MockAnnotationService) ListPokemon(filter *domain.PokemonFilter) ([]*domain.Pokemon, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func setupAnnotationRouter(service *MockAnnotationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), func(c *gin.Context) {
+		c.Set(principalKey, &domain.Principal{Subject: "ash", Method: domain.AuthMethodJWT, Roles: []string{domain.RoleEditor}})
+	})
+	handler := NewAnnotationHandler(service)
+	pokemonHandler := NewPokemonHandler(new(MockPokemonService), service)
+	api := router.Group("/api/v1")
+	{
+		api.GET("/tags", handler.TagCloud)
+		api.GET("/pokemon", pokemonHandler.ListPokemon)
+		api.GET("/pokemon/:id/annotations", handler.GetAnnotations)
+		api.PUT("/pokemon/:id/tags/:tag", handler.AddTag)
+		api.DELETE("/pokemon/:id/tags/:tag", handler.RemoveTag)
+		api.PUT("/pokemon/:id/note", handler.SetNote)
+		api.PUT("/pokemon/:id/favorite", handler.AddFavorite)
+		api.DELETE("/pokemon/:id/favorite", handler.RemoveFavorite)
+	}
+	return router
+}
+
+func TestAnnotationHandler_GetAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockAnnotationService)
+		expectedStatus int
+	}{
+		{
+			name: "annotated Pokemon",
+			path: "/api/v1/pokemon/1/annotations",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("GetAnnotations", uint(1), "ash").Return(&domain.PokemonAnnotations{PokemonID: 1, Tags: []string{"ou viable"}, Favorite: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid ID",
+			path:           "/api/v1/pokemon/abc/annotations",
+			setupMock:      func(service *MockAnnotationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown Pokemon",
+			path: "/api/v1/pokemon/9/annotations",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("GetAnnotations", uint(9), "ash").Return(nil, errors.New("pokemon not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			tt.setupMock(mockService)
+			router := setupAnnotationRouter(mockService)
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationHandler_Tags(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		setupMock      func(*MockAnnotationService)
+		expectedStatus int
+		expectedTags   []string
+	}{
+		{
+			name:   "add a tag",
+			method: "PUT",
+			path:   "/api/v1/pokemon/1/tags/OU%20Viable",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("AddTag", uint(1), "OU Viable").Return([]string{"fast", "ou viable"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTags:   []string{"fast", "ou viable"},
+		},
+		{
+			name:   "tag too long",
+			method: "PUT",
+			path:   "/api/v1/pokemon/1/tags/long",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("AddTag", uint(1), "long").Return(nil, errors.New("tag must be 1 to 50 characters"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "remove a tag",
+			method: "DELETE",
+			path:   "/api/v1/pokemon/1/tags/fast",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("RemoveTag", uint(1), "fast").Return([]string{"ou viable"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTags:   []string{"ou viable"},
+		},
+		{
+			name:   "unknown Pokemon",
+			method: "DELETE",
+			path:   "/api/v1/pokemon/9/tags/fast",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("RemoveTag", uint(9), "fast").Return(nil, errors.New("pokemon not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			tt.setupMock(mockService)
+			router := setupAnnotationRouter(mockService)
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedTags != nil {
+				var response map[string][]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedTags, response["tags"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationHandler_Favorite(t *testing.T) {
+	mockService := new(MockAnnotationService)
+	mockService.On("SetFavorite", uint(1), "ash", true).Return(nil).Once()
+	mockService.On("SetFavorite", uint(1), "ash", false).Return(nil).Once()
+	router := setupAnnotationRouter(mockService)
+
+	for _, method := range []string{"PUT", "DELETE"} {
+		req, _ := http.NewRequest(method, "/api/v1/pokemon/1/favorite", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAnnotationHandler_SetNote(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockAnnotationService)
+		expectedStatus int
+	}{
+		{
+			name: "save a note",
+			body: `{"body": "needs **EV spread**"}`,
+			setupMock: func(service *MockAnnotationService) {
+				service.On("SetNote", uint(1), &domain.PokemonNoteRequest{Body: "needs **EV spread**"}, mock.MatchedBy(func(by domain.Attribution) bool {
+					return by.Actor == "ash"
+				})).Return(&domain.PokemonNote{PokemonID: 1, Body: "needs **EV spread**", UpdatedBy: "ash"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "empty body removes the note",
+			body: `{"body": ""}`,
+			setupMock: func(service *MockAnnotationService) {
+				service.On("SetNote", uint(1), &domain.PokemonNoteRequest{}, mock.AnythingOfType("domain.Attribution")).Return(nil, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "note too long",
+			body: `{"body": "..."}`,
+			setupMock: func(service *MockAnnotationService) {
+				service.On("SetNote", uint(1), mock.AnythingOfType("*domain.PokemonNoteRequest"), mock.AnythingOfType("domain.Attribution")).Return(nil, errors.New("note cannot be longer than 10000 characters"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"body":`,
+			setupMock:      func(service *MockAnnotationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			tt.setupMock(mockService)
+			router := setupAnnotationRouter(mockService)
+
+			req, _ := http.NewRequest("PUT", "/api/v1/pokemon/1/note", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationHandler_TagCloud(t *testing.T) {
+	mockService := new(MockAnnotationService)
+	mockService.On("TagCloud").Return([]domain.TagCount{{Name: "ou viable", Count: 2}}, nil)
+	router := setupAnnotationRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"name": "ou viable", "count": 2}]`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonHandler_ListPokemon_Filtered(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockAnnotationService)
+		expectedStatus int
+	}{
+		{
+			name:  "by tags",
+			query: "?tag=ou+viable&tag=fast",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("ListPokemon", &domain.PokemonFilter{Tags: []string{"ou viable", "fast"}}).Return([]*domain.Pokemon{{ID: 1, Name: "pikachu"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "caller's favorites",
+			query: "?favorite=true",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("ListPokemon", &domain.PokemonFilter{FavoriteOf: "ash"}).Return([]*domain.Pokemon{{ID: 1, Name: "pikachu"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid favorite",
+			query:          "?favorite=maybe",
+			setupMock:      func(service *MockAnnotationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid tag",
+			query: "?tag=+",
+			setupMock: func(service *MockAnnotationService) {
+				service.On("ListPokemon", mock.AnythingOfType("*domain.PokemonFilter")).Return(nil, errors.New("tag must be 1 to 50 characters"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnnotationService)
+			tt.setupMock(mockService)
+			router := setupAnnotationRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockAnnotationService)
+	mockService.On("TagCloud").Return([]domain.TagCount{}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/tags", NewAnnotationHandler(mockService).TagCloud)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type authHandler struct {
+	service ports.AuthService
+}
+
+func NewAuthHandler(service ports.AuthService) *authHandler {
+	return &authHandler{
+		service: service,
+	}
+}
+
+// @Summary Get the current principal
+// @Description Show who the request is authenticated as and which roles it holds
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.Principal
+// @Failure 401 {object} map[string]string
+// @Router /api/v1/auth/me [get]
+func (h *authHandler) Me(c *gin.Context) {
+	principal := currentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	c.JSON(http.StatusOK, principal)
+}
+
+// @Summary Create an API key
+// @Description Issue a new API key. The plaintext key is only returned in this response.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param key body domain.APIKeyRequest true "Key name and scopes"
+// @Success 201 {object} domain.CreatedAPIKey
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/auth/keys [post]
+func (h *authHandler) CreateAPIKey(c *gin.Context) {
+	var req domain.APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.service.CreateAPIKey(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// @Summary List API keys
+// @Description List issued API keys without their secrets
+// @Tags auth
+// @Produce json
+// @Success 200 {array} domain.APIKey
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/auth/keys [get]
+func (h *authHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.service.ListAPIKeys()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// @Summary Revoke an API key
+// @Description Delete an API key so it can no longer authenticate
+// @Tags auth
+// @Param id path int true "API key ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/auth/keys/{id} [delete]
+func (h *authHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid API key ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *authHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "api key not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "api key with this name already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "api key name is required",
+		"api key requires at least one scope",
+		"scopes must be reader, editor or admin",
+		"tenant ID must be a lowercase slug of at most 63 characters":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAuthRouter(service *MockAuthService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAuthHandler(service)
+	router.GET("/api/v1/auth/me", func(c *gin.Context) {
+		c.Set(principalKey, &domain.Principal{Subject: "admin", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleAdmin}})
+	}, handler.Me)
+	router.POST("/api/v1/auth/keys", handler.CreateAPIKey)
+	router.GET("/api/v1/auth/keys", handler.ListAPIKeys)
+	router.DELETE("/api/v1/auth/keys/:id", handler.RevokeAPIKey)
+	return router
+}
+
+func TestAuthHandler_Me(t *testing.T) {
+	router := setupAuthRouter(new(MockAuthService))
+
+	req, _ := http.NewRequest("GET", "/api/v1/auth/me", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "admin", response["subject"])
+	assert.Equal(t, []interface{}{"admin"}, response["roles"])
+}
+
+func TestAuthHandler_CreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMock      func(*MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: map[string]interface{}{"name": "ci", "scopes": []string{"editor"}},
+			setupMock: func(service *MockAuthService) {
+				service.On("CreateAPIKey", mock.AnythingOfType("*domain.APIKeyRequest")).Return(&domain.CreatedAPIKey{
+					APIKey: domain.APIKey{ID: 2, Name: "ci", Prefix: "pk_0123456", KeyHash: "hash", Scopes: []string{"editor"}},
+					Key:    "pk_0123456789",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing scopes",
+			requestBody:    map[string]interface{}{"name": "ci"},
+			setupMock:      func(service *MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown scope",
+			requestBody: map[string]interface{}{"name": "ci", "scopes": []string{"root"}},
+			setupMock: func(service *MockAuthService) {
+				service.On("CreateAPIKey", mock.AnythingOfType("*domain.APIKeyRequest")).Return(nil, errors.New("scopes must be reader, editor or admin"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "duplicate name",
+			requestBody: map[string]interface{}{"name": "ci", "scopes": []string{"reader"}},
+			setupMock: func(service *MockAuthService) {
+				service.On("CreateAPIKey", mock.AnythingOfType("*domain.APIKeyRequest")).Return(nil, errors.New("api key with this name already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAuthService)
+			tt.setupMock(mockService)
+			router := setupAuthRouter(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest("POST", "/api/v1/auth/keys", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "pk_0123456789", response["key"])
+				assert.NotContains(t, response, "key_hash")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_ListAPIKeys(t *testing.T) {
+	mockService := new(MockAuthService)
+	mockService.On("ListAPIKeys").Return([]*domain.APIKey{{ID: 1, Name: "admin", KeyHash: "hash", Scopes: []string{"admin"}}}, nil)
+	router := setupAuthRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/auth/keys", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "hash")
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_RevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		keyID          string
+		setupMock      func(*MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name:  "successful revoke",
+			keyID: "1",
+			setupMock: func(service *MockAuthService) {
+				service.On("RevokeAPIKey", uint(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "key not found",
+			keyID: "2",
+			setupMock: func(service *MockAuthService) {
+				service.On("RevokeAPIKey", uint(2)).Return(errors.New("api key not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid key ID",
+			keyID:          "abc",
+			setupMock:      func(service *MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAuthService)
+			tt.setupMock(mockService)
+			router := setupAuthRouter(mockService)
+
+			req, _ := http.NewRequest("DELETE", "/api/v1/auth/keys/"+tt.keyID, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
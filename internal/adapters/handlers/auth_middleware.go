@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey is the gin context key holding the authenticated *domain.Principal
+const principalKey = "principal"
+
+type authMiddleware struct {
+	service ports.AuthService
+}
+
+func NewAuthMiddleware(service ports.AuthService) *authMiddleware {
+	return &authMiddleware{
+		service: service,
+	}
+}
+
+// Authenticate resolves the caller from an X-API-Key header or an Authorization bearer token
+func (m *authMiddleware) Authenticate(c *gin.Context) {
+	var (
+		principal *domain.Principal
+		err       error
+	)
+
+	apiKey := c.GetHeader("X-API-Key")
+	token, hasToken := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	switch {
+	case apiKey != "":
+		principal, err = m.service.AuthenticateAPIKey(apiKey)
+	case hasToken:
+		principal, err = m.service.AuthenticateToken(strings.TrimSpace(token))
+	default:
+		c.Header("WWW-Authenticate", "Bearer")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if err != nil {
+		switch err.Error() {
+		case "invalid API key", "invalid token", "bearer tokens are not enabled":
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Set(principalKey, principal)
+	c.Next()
+}
+
+// RequireRole rejects callers whose roles do not include the required role or a higher one
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := currentPrincipal(c)
+		if principal == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+		if !principal.HasRole(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// currentPrincipal returns the authenticated caller, or nil when the route is not behind Authenticate
+func currentPrincipal(c *gin.Context) *domain.Principal {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil
+	}
+	principal, _ := value.(*domain.Principal)
+	return principal
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) AuthenticateAPIKey(key string) (*domain.Principal, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func (m *MockAuthService) AuthenticateToken(token string) (*domain.Principal, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func (m *MockAuthService) CreateAPIKey(req *domain.APIKeyRequest) (*domain.CreatedAPIKey, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAuthService) EnsureAPIKey(name, key string, scopes []string) error {
+	args := m.Called(name, key, scopes)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ListAPIKeys() ([]*domain.APIKey, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupAuthMiddlewareRouter(service *MockAuthService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1", NewAuthMiddleware(service).Authenticate)
+	ok := func(c *gin.Context) {
+		c.JSON(http.StatusOK, currentPrincipal(c))
+	}
+	api.GET("/pokemon", RequireRole(domain.RoleReader), ok)
+	api.POST("/pokemon", RequireRole(domain.RoleEditor), ok)
+	api.GET("/auth/keys", RequireRole(domain.RoleAdmin), ok)
+	return router
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	reader := &domain.Principal{Subject: "dashboard", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleReader}}
+	editor := &domain.Principal{Subject: "ash", Method: domain.AuthMethodJWT, Roles: []string{domain.RoleEditor}}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		headers        map[string]string
+		setupMock      func(*MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name:           "anonymous request",
+			method:         "GET",
+			path:           "/api/v1/pokemon",
+			setupMock:      func(service *MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:    "reader API key can read",
+			method:  "GET",
+			path:    "/api/v1/pokemon",
+			headers: map[string]string{"X-API-Key": "pk_reader"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateAPIKey", "pk_reader").Return(reader, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "reader API key cannot write",
+			method:  "POST",
+			path:    "/api/v1/pokemon",
+			headers: map[string]string{"X-API-Key": "pk_reader"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateAPIKey", "pk_reader").Return(reader, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:    "editor token can write",
+			method:  "POST",
+			path:    "/api/v1/pokemon",
+			headers: map[string]string{"Authorization": "Bearer token"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateToken", "token").Return(editor, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "editor token cannot manage keys",
+			method:  "GET",
+			path:    "/api/v1/auth/keys",
+			headers: map[string]string{"Authorization": "Bearer token"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateToken", "token").Return(editor, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:    "invalid API key",
+			method:  "GET",
+			path:    "/api/v1/pokemon",
+			headers: map[string]string{"X-API-Key": "pk_wrong"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateAPIKey", "pk_wrong").Return(nil, errors.New("invalid API key"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:    "invalid token",
+			method:  "GET",
+			path:    "/api/v1/pokemon",
+			headers: map[string]string{"Authorization": "Bearer expired"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateToken", "expired").Return(nil, errors.New("invalid token"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unsupported authorization scheme",
+			method:         "GET",
+			path:           "/api/v1/pokemon",
+			headers:        map[string]string{"Authorization": "Basic YXNoOnBpa2FjaHU="},
+			setupMock:      func(service *MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:    "key lookup failure",
+			method:  "GET",
+			path:    "/api/v1/pokemon",
+			headers: map[string]string{"X-API-Key": "pk_reader"},
+			setupMock: func(service *MockAuthService) {
+				service.On("AuthenticateAPIKey", "pk_reader").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAuthService)
+			tt.setupMock(mockService)
+			router := setupAuthMiddlewareRouter(mockService)
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
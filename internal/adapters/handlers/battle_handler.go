@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type battleHandler struct {
+	service ports.BattleService
+}
+
+func NewBattleHandler(service ports.BattleService) *battleHandler {
+	return &battleHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *battleHandler) serviceFor(c *gin.Context) ports.BattleService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Simulate a battle
+// @Description Simulate a 1v1 or 6v6 singles battle between two stored teams. Sending the same seed replays the same battle.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Param battle body domain.BattleRequest true "Teams, format, level and optional seed"
+// @Success 201 {object} domain.Battle
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /api/v1/battles [post]
+func (h *battleHandler) CreateBattle(c *gin.Context) {
+	var req domain.BattleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	battle, err := h.serviceFor(c).CreateBattle(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, battle)
+}
+
+// @Summary Get battle by ID
+// @Description Retrieve a simulated battle with its turn-by-turn log
+// @Tags battles
+// @Produce json
+// @Param id path int true "Battle ID"
+// @Success 200 {object} domain.Battle
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/battles/{id} [get]
+func (h *battleHandler) GetBattle(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid battle ID")
+	if !ok {
+		return
+	}
+
+	battle, err := h.serviceFor(c).GetBattle(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, battle)
+}
+
+func (h *battleHandler) handleError(c *gin.Context, err error) {
+	if externalFailed(c, err) {
+		return
+	}
+
+	switch err.Error() {
+	case "battle not found", "team not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "unknown battle format",
+		"level must be between 1 and 100",
+		"team has no members",
+		"team member pokemon not found":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBattleService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockBattleService) ForTenant(tenantID string) ports.BattleService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockBattleService) CreateBattle(req *domain.BattleRequest) (*domain.Battle, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Battle), args.Error(1)
+}
+
+func (m *MockBattleService) GetBattle(id uint) (*domain.Battle, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Battle), args.Error(1)
+}
+
+func setupBattleRouter(service *MockBattleService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewBattleHandler(service)
+	router.POST("/api/v1/battles", handler.CreateBattle)
+	router.GET("/api/v1/battles/:id", handler.GetBattle)
+	return router
+}
+
+func TestBattleHandler_CreateBattle(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           map[string]interface{}
+		setupMock      func(*MockBattleService)
+		expectedStatus int
+	}{
+		{
+			name: "successful battle",
+			body: map[string]interface{}{"team_a_id": 1, "team_b_id": 2, "format": "1v1", "seed": 42},
+			setupMock: func(service *MockBattleService) {
+				service.On("CreateBattle", mock.MatchedBy(func(req *domain.BattleRequest) bool {
+					return req.Seed != nil && *req.Seed == 42 && req.Format == "1v1"
+				})).Return(&domain.Battle{ID: 1, Seed: 42, Winner: domain.BattleWinnerTeamA}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing team",
+			body:           map[string]interface{}{"team_a_id": 1},
+			setupMock:      func(service *MockBattleService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown format",
+			body: map[string]interface{}{"team_a_id": 1, "team_b_id": 2, "format": "doubles"},
+			setupMock: func(service *MockBattleService) {
+				service.On("CreateBattle", mock.AnythingOfType("*domain.BattleRequest")).Return(nil, errors.New("unknown battle format"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "team not found",
+			body: map[string]interface{}{"team_a_id": 1, "team_b_id": 99},
+			setupMock: func(service *MockBattleService) {
+				service.On("CreateBattle", mock.AnythingOfType("*domain.BattleRequest")).Return(nil, errors.New("team not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown move on a team",
+			body: map[string]interface{}{"team_a_id": 1, "team_b_id": 2},
+			setupMock: func(service *MockBattleService) {
+				service.On("CreateBattle", mock.AnythingOfType("*domain.BattleRequest")).Return(nil, &domain.ExternalNotFoundError{Resource: "move", Identifier: "hyper-punch"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "move lookup failure",
+			body: map[string]interface{}{"team_a_id": 1, "team_b_id": 2},
+			setupMock: func(service *MockBattleService) {
+				err := &domain.ExternalServiceError{Err: errors.New("PokeAPI returned status 503")}
+				service.On("CreateBattle", mock.AnythingOfType("*domain.BattleRequest")).Return(nil, fmt.Errorf("failed to fetch move data: %w", err))
+			},
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name: "save failure",
+			body: map[string]interface{}{"team_a_id": 1, "team_b_id": 2},
+			setupMock: func(service *MockBattleService) {
+				service.On("CreateBattle", mock.AnythingOfType("*domain.BattleRequest")).Return(nil, errors.New("failed to save battle: database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBattleService)
+			tt.setupMock(mockService)
+			router := setupBattleRouter(mockService)
+
+			body, _ := json.Marshal(tt.body)
+			req, _ := http.NewRequest("POST", "/api/v1/battles", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBattleHandler_GetBattle(t *testing.T) {
+	tests := []struct {
+		name           string
+		battleID       string
+		setupMock      func(*MockBattleService)
+		expectedStatus int
+	}{
+		{
+			name:     "successful get",
+			battleID: "1",
+			setupMock: func(service *MockBattleService) {
+				service.On("GetBattle", uint(1)).Return(&domain.Battle{ID: 1, Log: []domain.BattleEvent{{Type: domain.BattleEventEnd, Winner: domain.BattleDraw}}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "battle not found",
+			battleID: "2",
+			setupMock: func(service *MockBattleService) {
+				service.On("GetBattle", uint(2)).Return(nil, errors.New("battle not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid battle ID",
+			battleID:       "abc",
+			setupMock:      func(service *MockBattleService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBattleService)
+			tt.setupMock(mockService)
+			router := setupBattleRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/battles/"+tt.battleID, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBattleHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockBattleService)
+	mockService.On("GetBattle", uint(1)).Return(&domain.Battle{ID: 1}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/battles/:id", NewBattleHandler(mockService).GetBattle)
+
+	req, _ := http.NewRequest("GET", "/api/v1/battles/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type calcHandler struct {
+	service ports.CalcService
+}
+
+func NewCalcHandler(service ports.CalcService) *calcHandler {
+	return &calcHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *calcHandler) serviceFor(c *gin.Context) ports.CalcService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Calculate battle damage
+// @Description Deterministic damage calculation returning all 16 damage rolls, percentages and KO chance
+// @Tags calc
+// @Accept json
+// @Produce json
+// @Param calc body domain.DamageCalcRequest true "Attacker, defender, move and modifiers"
+// @Success 200 {object} domain.DamageResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /api/v1/calc/damage [post]
+func (h *calcHandler) CalculateDamage(c *gin.Context) {
+	var req domain.DamageCalcRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.serviceFor(c).CalculateDamage(&req)
+	if err != nil {
+		if externalFailed(c, err) {
+			return
+		}
+		switch err.Error() {
+		case "pokemon not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "combatant requires a pokemon_id or types and base_stats",
+			"move requires a name or a type and power",
+			"unknown move type",
+			"move does not deal damage",
+			"level must be between 1 and 100",
+			"unknown nature",
+			"IVs must be between 0 and 31",
+			"EVs must be between 0 and 252",
+			"EVs cannot total more than 510":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalcService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockCalcService) ForTenant(tenantID string) ports.CalcService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockCalcService) CalculateDamage(req *domain.DamageCalcRequest) (*domain.DamageResult, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DamageResult), args.Error(1)
+}
+
+func setupCalcRouter(service *MockCalcService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewCalcHandler(service)
+	router.POST("/api/v1/calc/damage", handler.CalculateDamage)
+	return router
+}
+
+func TestCalcHandler_CalculateDamage(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*MockCalcService)
+		expectedStatus int
+	}{
+		{
+			name: "successful calculation",
+			setupMock: func(service *MockCalcService) {
+				service.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(&domain.DamageResult{
+					Rolls:       []int{90, 100},
+					Min:         90,
+					Max:         100,
+					DefenderHP:  100,
+					KOChance:    0.5,
+					Description: "50.0% chance to OHKO",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "validation error",
+			setupMock: func(service *MockCalcService) {
+				service.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(nil, errors.New("move does not deal damage"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "stored pokemon not found",
+			setupMock: func(service *MockCalcService) {
+				service.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(nil, errors.New("pokemon not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown move",
+			setupMock: func(service *MockCalcService) {
+				service.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(nil, &domain.ExternalNotFoundError{Resource: "move", Identifier: "thunder-punch"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "move lookup error",
+			setupMock: func(service *MockCalcService) {
+				err := &domain.ExternalServiceError{Err: errors.New("PokeAPI returned status 500")}
+				service.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(nil, fmt.Errorf("failed to fetch move data: %w", err))
+			},
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name: "internal error",
+			setupMock: func(service *MockCalcService) {
+				service.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCalcService)
+			tt.setupMock(mockService)
+			router := setupCalcRouter(mockService)
+
+			body, _ := json.Marshal(map[string]interface{}{
+				"attacker":  map[string]interface{}{"pokemon_id": 1, "level": 50, "nature": "adamant", "evs": map[string]int{"attack": 252}},
+				"defender":  map[string]interface{}{"types": []string{"water"}, "base_stats": map[string]int{"hp": 79, "defense": 100}},
+				"move":      map[string]interface{}{"name": "thunder-punch"},
+				"modifiers": map[string]interface{}{"critical": true, "weather": "rain"},
+			})
+			req, _ := http.NewRequest("POST", "/api/v1/calc/damage", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalcHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockCalcService)
+	mockService.On("CalculateDamage", mock.AnythingOfType("*domain.DamageCalcRequest")).Return(&domain.DamageResult{Rolls: []int{90, 100}, Min: 90, Max: 100}, nil)
+	router := tenantRouter("kanto")
+	router.POST("/api/v1/calc/damage", NewCalcHandler(mockService).CalculateDamage)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"attacker": map[string]interface{}{"pokemon_id": 1, "level": 50},
+		"defender": map[string]interface{}{"pokemon_id": 2, "level": 50},
+		"move":     map[string]interface{}{"name": "thunder-punch"},
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/calc/damage", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
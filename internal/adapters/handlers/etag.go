@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondWithETag writes body as JSON with a strong ETag hashed from the encoded content.
+// A request whose If-None-Match already holds the ETag gets 304 Not Modified without a body.
+func respondWithETag(c *gin.Context, status int, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	etag := etagFor(data)
+	c.Header("ETag", etag)
+	cacheable := c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead
+	if cacheable && status == http.StatusOK && etagMatches(c.GetHeader("If-None-Match"), etag, false) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(status, "application/json; charset=utf-8", data)
+}
+
+// checkIfMatch writes 412 Precondition Failed and returns false if the request's If-Match
+// does not hold the ETag of current, so a client cannot overwrite changes it has not seen
+func checkIfMatch(c *gin.Context, current any) bool {
+	data, err := json.Marshal(current)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if !etagMatches(c.GetHeader("If-Match"), etagFor(data), true) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "resource has been modified"})
+		return false
+	}
+	return true
+}
+
+// preconditionFailed writes 412 Precondition Failed and returns true if err reports that the record changed
+// between checkIfMatch and the conditional write that followed it
+func preconditionFailed(c *gin.Context, err error) bool {
+	var modified *domain.ModifiedError
+	if !errors.As(err, &modified) {
+		return false
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "resource has been modified"})
+	return true
+}
+
+func etagFor(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether the header lists etag or "*". Strong comparison, used for If-Match,
+// never matches weak validators; weak comparison, used for If-None-Match, ignores the W/ prefix.
+func etagMatches(header, etag string, strong bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if strong {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
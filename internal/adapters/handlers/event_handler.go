@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// heartbeatInterval keeps idle event streams from being closed by proxies
+const heartbeatInterval = 15 * time.Second
+
+type eventHandler struct {
+	service   ports.EventService
+	heartbeat time.Duration
+}
+
+func NewEventHandler(service ports.EventService) *eventHandler {
+	return &eventHandler{
+		service:   service,
+		heartbeat: heartbeatInterval,
+	}
+}
+
+// @Summary Stream Pokemon changes
+// @Description Server-Sent Events stream of pokemon.created, pokemon.updated and pokemon.deleted events. Reconnecting with Last-Event-ID replays the events missed while the log still holds them.
+// @Tags events
+// @Produce text/event-stream
+// @Param types query string false "Comma-separated event types to receive"
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Param last_event_id query int false "ID of the last event received, for clients that cannot set headers"
+// @Success 200 {object} domain.Event
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/events [get]
+func (h *eventHandler) Stream(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	write := func(text string) error {
+		if _, err := io.WriteString(c.Writer, text); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+	send := func(event *domain.Event) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		return write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data))
+	}
+	ping := func() error {
+		return write(": keepalive\n\n")
+	}
+
+	if err := write("retry: 3000\n\n"); err != nil {
+		return
+	}
+	h.deliver(c.Request.Context(), sub, send, ping)
+}
+
+// @Summary Stream Pokemon changes over WebSocket
+// @Description WebSocket alternative to the event stream; every event is sent as a JSON text message. Accepts the same filters and resume parameters.
+// @Tags events
+// @Param types query string false "Comma-separated event types to receive"
+// @Param last_event_id query int false "ID of the last event received"
+// @Success 101
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/events/ws [get]
+func (h *eventHandler) WebSocket(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		// Clients authenticate with an API key or bearer token rather than cookies, so any origin may connect
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+
+			// Clients never send anything but a close, so a finished read means they went away
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				cancel()
+			}()
+
+			send := func(event *domain.Event) error {
+				return websocket.JSON.Send(conn, event)
+			}
+			h.deliver(ctx, sub, send, nil)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// subscribe reads the type filter and resume position, writing a 400 response if either is invalid
+func (h *eventHandler) subscribe(c *gin.Context) (*domain.EventSubscription, bool) {
+	var types []string
+	if param := c.Query("types"); param != "" {
+		for _, eventType := range strings.Split(param, ",") {
+			types = append(types, strings.TrimSpace(eventType))
+		}
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var afterID uint64
+	if lastEventID != "" {
+		var err error
+		afterID, err = strconv.ParseUint(lastEventID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid last event ID"})
+			return nil, false
+		}
+	}
+
+	sub, err := h.service.Subscribe(currentTenant(c), types, uint(afterID))
+	if err != nil {
+		if err.Error() == "unknown event type" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return sub, true
+}
+
+// deliver sends the replayed events and then live ones until the client goes away, a send fails
+// or the subscription is dropped for falling behind. ping, when set, runs on every heartbeat.
+func (h *eventHandler) deliver(ctx context.Context, sub *domain.EventSubscription, send func(*domain.Event) error, ping func() error) {
+	for _, event := range sub.Backlog {
+		if err := send(event); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Live:
+			if !ok {
+				return
+			}
+			if err := send(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if ping != nil {
+				if err := ping(); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/websocket"
+)
+
+type MockEventService struct {
+	mock.Mock
+}
+
+func (m *MockEventService) Publish(message *domain.OutboxMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MockEventService) Subscribe(tenantID string, types []string, lastEventID uint) (*domain.EventSubscription, error) {
+	args := m.Called(tenantID, types, lastEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EventSubscription), args.Error(1)
+}
+
+// testSubscription returns a subscription with the given backlog whose live events are sent on the returned channel
+func testSubscription(backlog ...*domain.Event) (*domain.EventSubscription, chan *domain.Event, chan struct{}) {
+	live := make(chan *domain.Event, 8)
+	closed := make(chan struct{})
+	return &domain.EventSubscription{
+		Backlog: backlog,
+		Live:    live,
+		Close:   func() { close(closed) },
+	}, live, closed
+}
+
+func setupEventRouter(service *MockEventService, heartbeat time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewEventHandler(service)
+	handler.heartbeat = heartbeat
+	router.GET("/api/v1/events", handler.Stream)
+	router.GET("/api/v1/events/ws", handler.WebSocket)
+	return router
+}
+
+func TestEventHandler_Stream(t *testing.T) {
+	created := &domain.Event{ID: 8, Type: domain.EventPokemonCreated, PokemonID: 1, Pokemon: &domain.Pokemon{ID: 1, Name: "pikachu"}}
+	deleted := &domain.Event{ID: 9, Type: domain.EventPokemonDeleted, PokemonID: 1}
+	sub, live, closed := testSubscription(created)
+
+	mockService := new(MockEventService)
+	mockService.On("Subscribe", domain.DefaultTenantID, []string{domain.EventPokemonCreated, domain.EventPokemonDeleted}, uint(7)).Return(sub, nil)
+	server := httptest.NewServer(setupEventRouter(mockService, 20*time.Millisecond))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/events?types=pokemon.created,%20pokemon.deleted", nil)
+	req.Header.Set("Last-Event-ID", "7")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readMessage := func() string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			assert.NoError(t, err)
+			if line == "\n" {
+				return strings.Join(lines, "")
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	assert.Equal(t, "retry: 3000\n", readMessage())
+	replayed := readMessage()
+	assert.True(t, strings.HasPrefix(replayed, "id: 8\nevent: pokemon.created\ndata: {\"id\":8,"), replayed)
+	assert.Contains(t, replayed, `"name":"pikachu"`)
+
+	live <- deleted
+	message := readMessage()
+	for message == ": keepalive\n" {
+		message = readMessage()
+	}
+	assert.True(t, strings.HasPrefix(message, "id: 9\nevent: pokemon.deleted\n"), message)
+
+	cancel()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed after the client went away")
+	}
+}
+
+func TestEventHandler_Stream_Heartbeat(t *testing.T) {
+	sub, _, _ := testSubscription()
+	mockService := new(MockEventService)
+	mockService.On("Subscribe", domain.DefaultTenantID, []string(nil), uint(0)).Return(sub, nil)
+	router := setupEventRouter(mockService, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/api/v1/events", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Contains(t, w.Body.String(), ": keepalive\n\n")
+}
+
+func TestEventHandler_Stream_SubscriptionDropped(t *testing.T) {
+	sub, live, closed := testSubscription()
+	close(live)
+	mockService := new(MockEventService)
+	mockService.On("Subscribe", domain.DefaultTenantID, []string(nil), uint(0)).Return(sub, nil)
+	router := setupEventRouter(mockService, time.Minute)
+
+	req, _ := http.NewRequest("GET", "/api/v1/events", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	<-closed
+}
+
+func TestEventHandler_Stream_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*MockEventService)
+		expectedStatus int
+	}{
+		{
+			name:           "invalid last event ID",
+			url:            "/api/v1/events?last_event_id=abc",
+			setupMock:      func(service *MockEventService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown event type",
+			url:  "/api/v1/events?types=pokemon.evolved",
+			setupMock: func(service *MockEventService) {
+				service.On("Subscribe", domain.DefaultTenantID, []string{"pokemon.evolved"}, uint(0)).Return(nil, errors.New("unknown event type"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			url:  "/api/v1/events?last_event_id=3",
+			setupMock: func(service *MockEventService) {
+				service.On("Subscribe", domain.DefaultTenantID, []string(nil), uint(3)).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockEventService)
+			tt.setupMock(mockService)
+			router := setupEventRouter(mockService, time.Minute)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventHandler_WebSocket(t *testing.T) {
+	created := &domain.Event{ID: 4, Type: domain.EventPokemonCreated, PokemonID: 1, Pokemon: &domain.Pokemon{ID: 1, Name: "pikachu"}}
+	sub, live, closed := testSubscription(created)
+
+	mockService := new(MockEventService)
+	mockService.On("Subscribe", domain.DefaultTenantID, []string{domain.EventPokemonUpdated}, uint(3)).Return(sub, nil)
+	server := httptest.NewServer(setupEventRouter(mockService, time.Minute))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/events/ws?types=pokemon.updated&last_event_id=3"
+	conn, err := websocket.Dial(url, "", server.URL)
+	assert.NoError(t, err)
+
+	var event domain.Event
+	assert.NoError(t, websocket.JSON.Receive(conn, &event))
+	assert.Equal(t, uint(4), event.ID)
+	assert.Equal(t, "pikachu", event.Pokemon.Name)
+
+	live <- &domain.Event{ID: 5, Type: domain.EventPokemonUpdated, PokemonID: 1}
+	assert.NoError(t, websocket.JSON.Receive(conn, &event))
+	assert.Equal(t, uint(5), event.ID)
+	assert.Equal(t, domain.EventPokemonUpdated, event.Type)
+
+	conn.Close()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed after the client went away")
+	}
+}
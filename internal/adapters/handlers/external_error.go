@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"pokemon-api/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// externalFailed answers a request that failed on PokeAPI and reports whether it did: 400 when the request named
+// something PokeAPI does not have, such as an unknown move, and 502 when PokeAPI could not answer
+func externalFailed(c *gin.Context, err error) bool {
+	var notFound *domain.ExternalNotFoundError
+	if errors.As(err, &notFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": notFound.Error()})
+		return true
+	}
+	var unavailable *domain.ExternalServiceError
+	if errors.As(err, &unavailable) {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/adapters/graph"
+
+	"github.com/gin-gonic/gin"
+)
+
+type graphqlHandler struct {
+	executor *graph.Executor
+}
+
+func NewGraphQLHandler(executor *graph.Executor) *graphqlHandler {
+	return &graphqlHandler{
+		executor: executor,
+	}
+}
+
+// @Summary Run a GraphQL query or mutation
+// @Description Query stored Pokemon, their types and stats, choosing exactly the fields to return. Errors are reported in the response's errors list, next to any data that did resolve.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "Query, optional operation name and variables"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /graphql [post]
+func (h *graphqlHandler) Query(c *gin.Context) {
+	var req graph.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query is required"})
+		return
+	}
+	req.RequestID = currentRequestID(c)
+	req.TenantID = currentTenant(c)
+
+	c.JSON(http.StatusOK, h.executor.Execute(c.Request.Context(), currentPrincipal(c), req))
+}
+
+// GraphiQL serves an in-browser IDE for exploring the schema; it is only routed in development
+func (h *graphqlHandler) GraphiQL(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(graphiQLPage))
+}
+
+// graphiQLPage loads GraphiQL from a CDN; add an X-API-Key or Authorization header in its Headers tab
+const graphiQLPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Pokemon API GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+  <style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+  <div id="graphiql">Loading...</div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: window.location.pathname });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(
+      React.createElement(GraphiQL, {
+        fetcher: fetcher,
+        defaultHeaders: '{"X-API-Key": ""}',
+        isHeadersEditorEnabled: true,
+      })
+    );
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/adapters/graph"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupGraphQLRouter(t *testing.T, service *MockPokemonService, principal *domain.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	executor, err := graph.NewExecutor(service, nil, domain.RateLimit{})
+	assert.NoError(t, err)
+	handler := NewGraphQLHandler(executor)
+
+	authenticate := func(c *gin.Context) {
+		if principal != nil {
+			c.Set(principalKey, principal)
+		}
+	}
+	router.POST("/graphql", authenticate, handler.Query)
+	router.GET("/graphql", handler.GraphiQL)
+	return router
+}
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	editor := &domain.Principal{Subject: "misty", Roles: []string{domain.RoleEditor}}
+
+	tests := []struct {
+		name           string
+		body           string
+		principal      *domain.Principal
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "query",
+			body: `{"query": "query($id: ID) { pokemon(id: $id) { name } }", "variables": {"id": "25"}}`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemon", uint(25)).Return(&domain.Pokemon{ID: 25, Name: "pikachu"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"data": map[string]interface{}{"pokemon": map[string]interface{}{"name": "pikachu"}}},
+		},
+		{
+			name:      "mutation uses the principal",
+			body:      `{"query": "mutation { createPokemon(name: \"eevee\", type1: \"normal\") { name } }"}`,
+			principal: editor,
+			setupMock: func(service *MockPokemonService) {
+				service.On("CreatePokemon", mock.MatchedBy(func(req *domain.CreatePokemonRequest) bool {
+					return req.CreatedBy == "misty"
+				})).Return(&domain.Pokemon{ID: 133, Name: "eevee"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"data": map[string]interface{}{"createPokemon": map[string]interface{}{"name": "eevee"}}},
+		},
+		{
+			name:           "syntax errors are GraphQL errors",
+			body:           `{"query": "{ pokemon("}`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing query",
+			body:           `{"variables": {}}`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"error": "query is required"},
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupGraphQLRouter(t, mockService, tt.principal)
+
+			req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedBody != nil {
+				assert.Equal(t, tt.expectedBody, response)
+			} else if tt.expectedStatus == http.StatusOK {
+				assert.NotEmpty(t, response["errors"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGraphQLHandler_GraphiQL(t *testing.T) {
+	router := setupGraphQLRouter(t, new(MockPokemonService), nil)
+
+	req, _ := http.NewRequest("GET", "/graphql", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "graphiql")
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyMiddleware struct {
+	service ports.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(service ports.IdempotencyService) *idempotencyMiddleware {
+	return &idempotencyMiddleware{
+		service: service,
+	}
+}
+
+// Handle replays the stored response for POST requests that repeat an Idempotency-Key.
+// Keys are scoped per client and tenant, and a key reused with a different request is rejected.
+func (m *idempotencyMiddleware) Handle(c *gin.Context) {
+	key := c.GetHeader(idempotencyKeyHeader)
+	if c.Request.Method != http.MethodPost || key == "" {
+		c.Next()
+		return
+	}
+
+	// The body is read before any handler can limit it, so it is held to the largest body a route accepts, an import
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body is too large"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	scope := idempotencyScope(c)
+	record, err := m.service.Begin(scope, key, fingerprint(c.Request.Method, c.Request.URL.RequestURI(), c.GetHeader("Content-Type"), body))
+	if err != nil {
+		switch err.Error() {
+		case "idempotency key was already used with a different request":
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case "a request with this idempotency key is still in progress":
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case "idempotency key must be between 1 and 255 characters":
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if record != nil {
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(record.StatusCode, record.ContentType, record.Body)
+		c.Abort()
+		return
+	}
+
+	// A handler that panics never finishes the request, so its key is released rather than left in progress;
+	// the panic carries on to the recovery middleware
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := m.service.Release(scope, key); err != nil {
+			log.Printf("idempotency key %s: %v", key, err)
+		}
+	}()
+
+	writer := &capturingWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+	completed = true
+
+	// Server errors and rate limiting are transient, so the key is freed for a retry instead of replaying them
+	status := writer.Status()
+	if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+		err = m.service.Release(scope, key)
+	} else {
+		err = m.service.Complete(scope, key, status, writer.Header().Get("Content-Type"), writer.body.Bytes())
+	}
+	if err != nil {
+		log.Printf("idempotency key %s: %v", key, err)
+	}
+}
+
+// fingerprint identifies a request by method, path with query string, Content-Type and body; the query and
+// Content-Type matter because they can change what a request does, such as an import's dry_run and format
+func fingerprint(method, requestURI, contentType string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + requestURI + "\n" + contentType + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// capturingWriter keeps a copy of the response body so it can be stored for replay
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencyScope separates an operator's keys by tenant; the default tenant keeps the plain client key, which is
+// how keys were scoped before tenants existed
+func idempotencyScope(c *gin.Context) string {
+	if tenantID := currentTenant(c); tenantID != domain.DefaultTenantID {
+		return clientKey(c) + "@" + tenantID
+	}
+	return clientKey(c)
+}
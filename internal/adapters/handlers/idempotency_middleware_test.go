@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyService struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyService) Begin(scope, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	args := m.Called(scope, key, fingerprint)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyService) Complete(scope, key string, statusCode int, contentType string, body []byte) error {
+	args := m.Called(scope, key, statusCode, contentType, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyService) Release(scope, key string) error {
+	args := m.Called(scope, key)
+	return args.Error(0)
+}
+
+func setupIdempotencyRouter(service *MockIdempotencyService, status int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	middleware := NewIdempotencyMiddleware(service)
+	authenticate := func(c *gin.Context) {
+		c.Set(principalKey, &domain.Principal{Subject: "ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}})
+	}
+	create := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(status, gin.H{"received": string(body)})
+	}
+	router.POST("/api/v1/pokemon", authenticate, middleware.Handle, create)
+	router.GET("/api/v1/pokemon", authenticate, middleware.Handle, create)
+	return router
+}
+
+func TestIdempotencyMiddleware_Handle(t *testing.T) {
+	requestFingerprint := fingerprint("POST", "/api/v1/pokemon", "", []byte(`{"name":"pikachu"}`))
+	createdBody := []byte(`{"received":"{\"name\":\"pikachu\"}"}`)
+
+	tests := []struct {
+		name           string
+		method         string
+		key            string
+		status         int
+		setupMock      func(*MockIdempotencyService)
+		expectedStatus int
+		expectedBody   string
+		expectReplay   bool
+	}{
+		{
+			name:           "request without key is passed through",
+			method:         "POST",
+			status:         http.StatusCreated,
+			setupMock:      func(service *MockIdempotencyService) {},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   string(createdBody),
+		},
+		{
+			name:           "non-POST request is passed through",
+			method:         "GET",
+			key:            "abc",
+			status:         http.StatusOK,
+			setupMock:      func(service *MockIdempotencyService) {},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "first request stores the response",
+			method: "POST",
+			key:    "abc",
+			status: http.StatusCreated,
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, nil)
+				service.On("Complete", "api_key:ci", "abc", http.StatusCreated, "application/json; charset=utf-8", createdBody).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   string(createdBody),
+		},
+		{
+			name:   "client errors are stored",
+			method: "POST",
+			key:    "abc",
+			status: http.StatusBadRequest,
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, nil)
+				service.On("Complete", "api_key:ci", "abc", http.StatusBadRequest, mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "server errors release the key",
+			method: "POST",
+			key:    "abc",
+			status: http.StatusInternalServerError,
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, nil)
+				service.On("Release", "api_key:ci", "abc").Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "repeated request is replayed",
+			method: "POST",
+			key:    "abc",
+			status: http.StatusCreated,
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(&domain.IdempotencyRecord{
+					StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"id":1}`),
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":1}`,
+			expectReplay:   true,
+		},
+		{
+			name:   "key reused with a different request",
+			method: "POST",
+			key:    "abc",
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, errors.New("idempotency key was already used with a different request"))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "request still in progress",
+			method: "POST",
+			key:    "abc",
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, errors.New("a request with this idempotency key is still in progress"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "key too long",
+			method: "POST",
+			key:    "abc",
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, errors.New("idempotency key must be between 1 and 255 characters"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "storage failure",
+			method: "POST",
+			key:    "abc",
+			setupMock: func(service *MockIdempotencyService) {
+				service.On("Begin", "api_key:ci", "abc", requestFingerprint).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockIdempotencyService)
+			tt.setupMock(mockService)
+			router := setupIdempotencyRouter(mockService, tt.status)
+
+			req, _ := http.NewRequest(tt.method, "/api/v1/pokemon", strings.NewReader(`{"name":"pikachu"}`))
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+			if tt.expectReplay {
+				assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+			} else {
+				assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	body := []byte("name,type1\npikachu,electric\n")
+	base := fingerprint("POST", "/api/v1/pokemon/import", "text/csv", body)
+
+	assert.Equal(t, base, fingerprint("POST", "/api/v1/pokemon/import", "text/csv", body))
+	// A dry run and the real import are different requests
+	assert.NotEqual(t, base, fingerprint("POST", "/api/v1/pokemon/import?dry_run=true", "text/csv", body))
+	assert.NotEqual(t, base, fingerprint("POST", "/api/v1/pokemon/import", "application/x-ndjson", body))
+	assert.NotEqual(t, base, fingerprint("POST", "/api/v1/pokemon/import", "text/csv", []byte("name,type1\n")))
+}
+
+func TestIdempotencyMiddleware_BodyTooLarge(t *testing.T) {
+	mockService := new(MockIdempotencyService)
+	router := setupIdempotencyRouter(mockService, http.StatusCreated)
+
+	req, _ := http.NewRequest("POST", "/api/v1/pokemon", strings.NewReader(strings.Repeat("a", maxImportSize+1)))
+	req.Header.Set("Idempotency-Key", "abc")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(t, `{"error":"request body is too large"}`, w.Body.String())
+	mockService.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotencyMiddleware_HandlePanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockIdempotencyService)
+	mockService.On("Begin", "api_key:ci", "abc", mock.Anything).Return(nil, nil)
+	mockService.On("Release", "api_key:ci", "abc").Return(nil)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	authenticate := func(c *gin.Context) {
+		c.Set(principalKey, &domain.Principal{Subject: "ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}})
+	}
+	router.POST("/api/v1/pokemon", authenticate, NewIdempotencyMiddleware(mockService).Handle, func(c *gin.Context) {
+		panic("handler failed")
+	})
+
+	req, _ := http.NewRequest("POST", "/api/v1/pokemon", strings.NewReader(`{"name":"pikachu"}`))
+	req.Header.Set("Idempotency-Key", "abc")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
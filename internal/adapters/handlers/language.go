@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"pokemon-api/internal/core/domain"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// requestLanguages returns the languages the client prefers, most preferred first, as lowercase tags such as
+// "ja" or "es-mx". The lang query parameter, a comma-separated list, overrides Accept-Language.
+func requestLanguages(c *gin.Context) []string {
+	var languages []string
+	if lang := c.Query("lang"); lang != "" {
+		for _, tag := range strings.Split(lang, ",") {
+			if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+				languages = append(languages, tag)
+			}
+		}
+		return languages
+	}
+
+	// Tags come back ordered by q value; a malformed header is ignored rather than rejected
+	tags, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil {
+		return nil
+	}
+	for _, tag := range tags {
+		if tag != language.Und {
+			languages = append(languages, strings.ToLower(tag.String()))
+		}
+	}
+	return languages
+}
+
+// localize shows each Pokemon's name and flavor text in the request's language. A single Pokemon also gets a
+// Content-Language header naming the language shown.
+func localize(c *gin.Context, pokemon ...*domain.Pokemon) {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	languages := requestLanguages(c)
+	for _, p := range pokemon {
+		shown := p.Localize(languages)
+		if len(pokemon) == 1 && shown != "" {
+			c.Header("Content-Language", shown)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type pokedexHandler struct {
+	service ports.PokedexService
+}
+
+func NewPokedexHandler(service ports.PokedexService) *pokedexHandler {
+	return &pokedexHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *pokedexHandler) serviceFor(c *gin.Context) ports.PokedexService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Get Pokedex completion
+// @Description Compare the stored Pokemon against the national dex, with counts and missing entries by generation
+// @Tags pokedex
+// @Produce json
+// @Success 200 {object} domain.PokedexCompletion
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokedex [get]
+func (h *pokedexHandler) GetCompletion(c *gin.Context) {
+	completion, err := h.serviceFor(c).GetCompletion()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, completion)
+}
+
+// @Summary Get a trainer's Pokedex completion
+// @Description Compare a trainer's seen and caught species against the national dex. Species the trainer owns count as caught.
+// @Tags pokedex
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Success 200 {object} domain.PokedexCompletion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokedex [get]
+func (h *pokedexHandler) GetTrainerCompletion(c *gin.Context) {
+	trainerID, ok := parseIDParam(c, "id", "invalid trainer ID")
+	if !ok {
+		return
+	}
+
+	completion, err := h.serviceFor(c).GetTrainerCompletion(trainerID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, completion)
+}
+
+// @Summary Mark a species as seen or caught
+// @Description Set a trainer's seen and caught flags for a national dex number. Caught implies seen.
+// @Tags pokedex
+// @Accept json
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Param number path int true "National dex number"
+// @Param flag body domain.PokedexFlagRequest true "Flags to set"
+// @Success 200 {object} domain.PokedexFlag
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokedex/{number} [put]
+func (h *pokedexHandler) SetFlag(c *gin.Context) {
+	trainerID, ok := parseIDParam(c, "id", "invalid trainer ID")
+	if !ok {
+		return
+	}
+	dexNumber, ok := parseIDParam(c, "number", "invalid dex number")
+	if !ok {
+		return
+	}
+
+	var req domain.PokedexFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flag, err := h.serviceFor(c).SetFlag(trainerID, int(dexNumber), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+func (h *pokedexHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "trainer not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "pokemon not in national dex":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
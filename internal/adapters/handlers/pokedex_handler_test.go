@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPokedexService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockPokedexService) ForTenant(tenantID string) ports.PokedexService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockPokedexService) GetCompletion() (*domain.PokedexCompletion, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokedexCompletion), args.Error(1)
+}
+
+func (m *MockPokedexService) GetTrainerCompletion(trainerID uint) (*domain.PokedexCompletion, error) {
+	args := m.Called(trainerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokedexCompletion), args.Error(1)
+}
+
+func (m *MockPokedexService) SetFlag(trainerID uint, dexNumber int, req *domain.PokedexFlagRequest) (*domain.PokedexFlag, error) {
+	args := m.Called(trainerID, dexNumber, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokedexFlag), args.Error(1)
+}
+
+func setupPokedexRouter(service *MockPokedexService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewPokedexHandler(service)
+	router.GET("/api/v1/pokedex", handler.GetCompletion)
+	router.GET("/api/v1/trainers/:id/pokedex", handler.GetTrainerCompletion)
+	router.PUT("/api/v1/trainers/:id/pokedex/:number", handler.SetFlag)
+	return router
+}
+
+func TestPokedexHandler_GetCompletion(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*MockPokedexService)
+		expectedStatus int
+	}{
+		{
+			name: "successful report",
+			setupMock: func(service *MockPokedexService) {
+				service.On("GetCompletion").Return(&domain.PokedexCompletion{
+					Total:      151,
+					Registered: 3,
+					Percent:    2,
+					Generations: []domain.GenerationCompletion{
+						{Generation: 1, Total: 151, Registered: 3, Percent: 2, Missing: []domain.DexEntry{{Number: 1, Name: "bulbasaur"}}},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "PokeAPI error",
+			setupMock: func(service *MockPokedexService) {
+				service.On("GetCompletion").Return(nil, errors.New("failed to fetch national dex: PokeAPI returned status 503"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokedexService)
+			tt.setupMock(mockService)
+			router := setupPokedexRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokedex", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokedexHandler_GetTrainerCompletion(t *testing.T) {
+	tests := []struct {
+		name           string
+		trainerID      string
+		setupMock      func(*MockPokedexService)
+		expectedStatus int
+	}{
+		{
+			name:      "successful report",
+			trainerID: "1",
+			setupMock: func(service *MockPokedexService) {
+				service.On("GetTrainerCompletion", uint(1)).Return(&domain.PokedexCompletion{TrainerID: 1, Total: 1025, Registered: 10, Seen: 40}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "trainer not found",
+			trainerID: "2",
+			setupMock: func(service *MockPokedexService) {
+				service.On("GetTrainerCompletion", uint(2)).Return(nil, errors.New("trainer not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid trainer ID",
+			trainerID:      "abc",
+			setupMock:      func(service *MockPokedexService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokedexService)
+			tt.setupMock(mockService)
+			router := setupPokedexRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/trainers/"+tt.trainerID+"/pokedex", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokedexHandler_SetFlag(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockPokedexService)
+		expectedStatus int
+	}{
+		{
+			name: "successful update",
+			path: "/api/v1/trainers/1/pokedex/25",
+			setupMock: func(service *MockPokedexService) {
+				service.On("SetFlag", uint(1), 25, mock.MatchedBy(func(req *domain.PokedexFlagRequest) bool {
+					return req.Caught != nil && *req.Caught && req.Seen == nil
+				})).Return(&domain.PokedexFlag{TrainerID: 1, DexNumber: 25, Seen: true, Caught: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "not in national dex",
+			path: "/api/v1/trainers/1/pokedex/2000",
+			setupMock: func(service *MockPokedexService) {
+				service.On("SetFlag", uint(1), 2000, mock.AnythingOfType("*domain.PokedexFlagRequest")).Return(nil, errors.New("pokemon not in national dex"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid dex number",
+			path:           "/api/v1/trainers/1/pokedex/pikachu",
+			setupMock:      func(service *MockPokedexService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokedexService)
+			tt.setupMock(mockService)
+			router := setupPokedexRouter(mockService)
+
+			body, _ := json.Marshal(map[string]bool{"caught": true})
+			req, _ := http.NewRequest("PUT", tt.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokedexHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockPokedexService)
+	mockService.On("GetTrainerCompletion", uint(1)).Return(&domain.PokedexCompletion{TrainerID: 1}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/trainers/:id/pokedex", NewPokedexHandler(mockService).GetTrainerCompletion)
+
+	req, _ := http.NewRequest("GET", "/api/v1/trainers/1/pokedex", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
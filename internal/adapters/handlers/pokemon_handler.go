@@ -1,27 +1,42 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"pokemon-api/internal/adapters/formats"
 	"pokemon-api/internal/core/domain"
 	"pokemon-api/internal/core/ports"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// maxImportSize bounds the size of an uploaded import file
+const maxImportSize = 10 << 20
+
 type pokemonHandler struct {
 	service ports.PokemonService
+	// annotations serves listings filtered by tag or favorite
+	annotations ports.AnnotationService
 }
 
-func NewPokemonHandler(service ports.PokemonService) *pokemonHandler {
+func NewPokemonHandler(service ports.PokemonService, annotations ports.AnnotationService) *pokemonHandler {
 	return &pokemonHandler{
-		service: service,
+		service:     service,
+		annotations: annotations,
 	}
 }
 
+// serviceFor scopes the service to the request's tenant
+func (h *pokemonHandler) serviceFor(c *gin.Context) ports.PokemonService {
+	return h.service.ForTenant(currentTenant(c))
+}
 
 // @Summary Create a new Pokemon
-// @Description Create a new Pokemon with data from PokeAPI
+// @Description Create a new Pokemon with data from PokeAPI. Display names such as "Mr. Mime" and localized names such as "ピカチュウ" are converted to PokeAPI names; an unknown name returns 422 with the closest known names.
 // @Tags pokemon
 // @Accept json
 // @Produce json
@@ -29,6 +44,7 @@ func NewPokemonHandler(service ports.PokemonService) *pokemonHandler {
 // @Success 201 {object} domain.Pokemon
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]interface{} "Unknown name, with suggestions"
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/pokemon [post]
 func (h *pokemonHandler) CreatePokemon(c *gin.Context) {
@@ -37,14 +53,13 @@ func (h *pokemonHandler) CreatePokemon(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	by := attribution(c)
+	req.CreatedBy = by.Actor
+	req.RequestID = by.RequestID
 
-	pokemon, err := h.service.CreatePokemon(&req)
+	pokemon, err := h.serviceFor(c).CreatePokemon(&req)
 	if err != nil {
-		if err.Error() == "pokemon with this name already exists" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleCreateError(c, err)
 		return
 	}
 
@@ -52,7 +67,7 @@ func (h *pokemonHandler) CreatePokemon(c *gin.Context) {
 }
 
 // @Summary Create a new Pokemon with flexible name format
-// @Description Create a new Pokemon supporting both direct name and nested pokemon.name formats
+// @Description Create a new Pokemon supporting both direct name and nested pokemon.name formats. An unknown name returns 422 with the closest known names.
 // @Tags pokemon
 // @Accept json
 // @Produce json
@@ -60,6 +75,7 @@ func (h *pokemonHandler) CreatePokemon(c *gin.Context) {
 // @Success 201 {object} domain.Pokemon
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]interface{} "Unknown name, with suggestions"
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/pokemon [post]
 func (h *pokemonHandler) CreatePokemonFlexible(c *gin.Context) {
@@ -68,14 +84,13 @@ func (h *pokemonHandler) CreatePokemonFlexible(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	by := attribution(c)
+	req.CreatedBy = by.Actor
+	req.RequestID = by.RequestID
 
-	pokemon, err := h.service.CreatePokemonFlexible(&req)
+	pokemon, err := h.serviceFor(c).CreatePokemonFlexible(&req)
 	if err != nil {
-		if err.Error() == "pokemon with this name already exists" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.handleCreateError(c, err)
 		return
 	}
 
@@ -83,12 +98,17 @@ func (h *pokemonHandler) CreatePokemonFlexible(c *gin.Context) {
 }
 
 // @Summary Get Pokemon by ID
-// @Description Retrieve a Pokemon by its ID
+// @Description Retrieve a Pokemon by its ID, with its name and flavor text in the requested language. With as_of, returns the Pokemon as it was at that time, from its change history.
 // @Tags pokemon
 // @Accept json
 // @Produce json
 // @Param id path int true "Pokemon ID"
+// @Param as_of query string false "RFC 3339 time"
+// @Param lang query string false "Preferred languages, such as ja-hrkt or es; overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages; English is shown when none is available"
+// @Param If-None-Match header string false "ETag from an earlier response"
 // @Success 200 {object} domain.Pokemon
+// @Success 304
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -101,7 +121,17 @@ func (h *pokemonHandler) GetPokemon(c *gin.Context) {
 		return
 	}
 
-	pokemon, err := h.service.GetPokemon(uint(id))
+	var pokemon *domain.Pokemon
+	if asOf := c.Query("as_of"); asOf != "" {
+		at, parseErr := time.Parse(time.RFC3339, asOf)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "as_of must be an RFC 3339 time"})
+			return
+		}
+		pokemon, err = h.serviceFor(c).GetPokemonAsOf(uint(id), at)
+	} else {
+		pokemon, err = h.serviceFor(c).GetPokemon(uint(id))
+	}
 	if err != nil {
 		if err.Error() == "pokemon not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -111,25 +141,452 @@ func (h *pokemonHandler) GetPokemon(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, pokemon)
+	localize(c, pokemon)
+	respondWithETag(c, http.StatusOK, pokemon)
+}
+
+// @Summary Get Pokemon by name
+// @Description Retrieve a stored Pokemon by name. Display names such as "Mr. Mime" and localized names such as "ピカチュウ" are matched to PokeAPI names.
+// @Tags pokemon
+// @Produce json
+// @Param name path string true "Pokemon name"
+// @Param lang query string false "Preferred languages, such as ja-hrkt or es; overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages; English is shown when none is available"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {object} domain.Pokemon
+// @Success 304
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/by-name/{name} [get]
+func (h *pokemonHandler) GetPokemonByName(c *gin.Context) {
+	pokemon, err := h.serviceFor(c).GetPokemonByName(c.Param("name"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	localize(c, pokemon)
+	respondWithETag(c, http.StatusOK, pokemon)
+}
+
+// @Summary Get Pokemon by national dex number
+// @Description Retrieve a stored Pokemon by its national Pokedex number. If several forms of the species are stored, the first one stored is returned.
+// @Tags pokemon
+// @Produce json
+// @Param number path int true "National dex number"
+// @Param lang query string false "Preferred languages, such as ja-hrkt or es; overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages; English is shown when none is available"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {object} domain.Pokemon
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/by-dex/{number} [get]
+func (h *pokemonHandler) GetPokemonByDexNumber(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dex number"})
+		return
+	}
+
+	pokemon, err := h.serviceFor(c).GetPokemonByDexNumber(number)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	localize(c, pokemon)
+	respondWithETag(c, http.StatusOK, pokemon)
 }
 
 // @Summary List all Pokemon
-// @Description Retrieve all Pokemon from the database
+// @Description Retrieve all Pokemon from the database. Send Accept: text/csv, application/x-ndjson or application/yaml to stream an export instead of JSON. Filtering by tag or favorite lists matching Pokemon in ID order, always as JSON.
 // @Tags pokemon
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson,application/yaml
+// @Param tag query []string false "Only Pokemon carrying every given tag" collectionFormat(multi)
+// @Param favorite query bool false "Only the caller's favorites"
+// @Param lang query string false "Preferred languages, such as ja-hrkt or es; overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages; English is shown when none is available"
+// @Param If-None-Match header string false "ETag from an earlier response"
 // @Success 200 {array} domain.Pokemon
+// @Success 304
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/pokemon [get]
 func (h *pokemonHandler) ListPokemon(c *gin.Context) {
-	pokemon, err := h.service.ListPokemon()
+	c.Header("Vary", "Accept")
+	if c.Query("tag") != "" || c.Query("favorite") != "" {
+		h.listAnnotatedPokemon(c)
+		return
+	}
+	if format := formats.Negotiate(c.GetHeader("Accept")); format != "" {
+		h.exportPokemon(c, format)
+		return
+	}
+
+	pokemon, err := h.serviceFor(c).ListPokemon()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, pokemon...)
+	respondWithETag(c, http.StatusOK, pokemon)
+}
+
+// listAnnotatedPokemon lists the Pokemon matching the tag and favorite query parameters
+func (h *pokemonHandler) listAnnotatedPokemon(c *gin.Context) {
+	filter := &domain.PokemonFilter{Tags: c.QueryArray("tag")}
+	if value := c.Query("favorite"); value != "" {
+		favorite, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "favorite must be true or false"})
+			return
+		}
+		if favorite {
+			filter.FavoriteOf = attribution(c).Actor
+			if filter.FavoriteOf == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "favorites need an authenticated user"})
+				return
+			}
+		}
+	}
+
+	pokemon, err := h.annotations.ForTenant(currentTenant(c)).ListPokemon(filter)
+	if err != nil {
+		if err.Error() == "tag must be 1 to 50 characters" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, pokemon...)
+	respondWithETag(c, http.StatusOK, pokemon)
+}
+
+// @Summary Search Pokemon
+// @Description Full-text search of stored Pokemon across name, types, abilities and flavor text, best match first. Misspelt names still match similar ones. Facets count every hit by type and generation; type and generation narrow the hits, and each facet is narrowed by the other's filter only.
+// @Tags pokemon
+// @Produce json
+// @Param q query string true "Search text"
+// @Param type query string false "Only Pokemon with this type"
+// @Param generation query int false "Only Pokemon introduced in this generation"
+// @Param limit query int false "Hits per page (default 20, at most 100)"
+// @Param offset query int false "Hits to skip"
+// @Success 200 {object} domain.PokemonSearchResult
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/search [get]
+func (h *pokemonHandler) SearchPokemon(c *gin.Context) {
+	query := &domain.PokemonSearchQuery{
+		Text: c.Query("q"),
+		Type: strings.ToLower(c.Query("type")),
+	}
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{
+		{"generation", &query.Generation},
+		{"limit", &query.Limit},
+		{"offset", &query.Offset},
+	} {
+		value, err := strconv.Atoi(c.DefaultQuery(param.name, "0"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": param.name + " must be a whole number"})
+			return
+		}
+		*param.target = value
+	}
+
+	result, err := h.serviceFor(c).SearchPokemon(query)
+	if err != nil {
+		switch err.Error() {
+		case "search query is required",
+			"search query cannot be longer than 200 characters",
+			"type must be a known type",
+			"generation cannot be negative",
+			"offset cannot be negative":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Update a Pokemon
+// @Description Change a stored Pokemon's types
+// @Tags pokemon
+// @Accept json
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Param pokemon body domain.UpdatePokemonRequest true "New types"
+// @Param If-Match header string false "ETag the change is based on"
+// @Success 200 {object} domain.Pokemon
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id} [put]
+func (h *pokemonHandler) UpdatePokemon(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	var req domain.UpdatePokemonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, id)
+	if !ok {
+		return
+	}
+
+	pokemon, err := h.serviceFor(c).UpdatePokemon(id, &req, expected, attribution(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	localize(c, pokemon)
+	respondWithETag(c, http.StatusOK, pokemon)
+}
+
+// @Summary Delete a Pokemon
+// @Description Delete a stored Pokemon
+// @Tags pokemon
+// @Param id path int true "Pokemon ID"
+// @Param If-Match header string false "ETag the change is based on"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id} [delete]
+func (h *pokemonHandler) DeletePokemon(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, id)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceFor(c).DeletePokemon(id, expected, attribution(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get a Pokemon's change history
+// @Description Retrieve every recorded change to a Pokemon, oldest first, with the record before and after, who made it, the request ID and the source (api, import, or job for the trash purge). History is kept after the Pokemon is deleted or purged.
+// @Tags pokemon
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Success 200 {array} domain.PokemonAudit
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/history [get]
+func (h *pokemonHandler) GetPokemonHistory(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	entries, err := h.serviceFor(c).GetPokemonHistory(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// @Summary List deleted Pokemon
+// @Description Retrieve the Pokemon in the trash, most recently deleted first. They are purged once the retention period has passed.
+// @Tags pokemon
+// @Produce json
+// @Success 200 {array} domain.DeletedPokemon
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/trash [get]
+func (h *pokemonHandler) ListTrash(c *gin.Context) {
+	pokemon, err := h.serviceFor(c).ListDeletedPokemon()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, pokemon)
+	trash := make([]domain.DeletedPokemon, 0, len(pokemon))
+	for _, p := range pokemon {
+		trash = append(trash, domain.DeletedPokemon{Pokemon: p, DeletedAt: p.DeletedAt.Time})
+	}
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Restore a deleted Pokemon
+// @Description Take a Pokemon out of the trash. Fails if a Pokemon with the same name has been added since.
+// @Tags pokemon
+// @Produce json
+// @Param id path int true "Pokemon ID"
+// @Success 200 {object} domain.Pokemon
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/restore [post]
+func (h *pokemonHandler) RestorePokemon(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	pokemon, err := h.serviceFor(c).RestorePokemon(id, attribution(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	localize(c, pokemon)
+	respondWithETag(c, http.StatusOK, pokemon)
+}
+
+// checkIfMatch compares If-Match, when sent, with the Pokemon's current ETag. The Pokemon is localized as GET
+// shows it in the request's languages, since that is the representation the client's ETag came from. It returns
+// the version the change must still apply to, so a write racing the check fails too; nil without If-Match.
+func (h *pokemonHandler) checkIfMatch(c *gin.Context, id uint) (*time.Time, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return nil, true
+	}
+
+	pokemon, err := h.serviceFor(c).GetPokemon(id)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+	pokemon.Localize(requestLanguages(c))
+	if !checkIfMatch(c, pokemon) {
+		return nil, false
+	}
+	return &pokemon.UpdatedAt, true
+}
+
+func (h *pokemonHandler) handleCreateError(c *gin.Context, err error) {
+	var unknown *domain.UnknownPokemonError
+	switch {
+	case errors.As(err, &unknown):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "suggestions": unknown.Suggestions})
+	case err.Error() == "pokemon with this name already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err.Error() == "pokemon name is required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *pokemonHandler) handleError(c *gin.Context, err error) {
+	if preconditionFailed(c, err) {
+		return
+	}
+
+	switch err.Error() {
+	case "pokemon not found", "pokemon not found in trash":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "pokemon with this name already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "type1 must be a known type",
+		"type2 must be a known type different from type1",
+		"dex number must be between 1 and 1025":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// exportPokemon streams the collection in format as it is read, without loading it all first
+func (h *pokemonHandler) exportPokemon(c *gin.Context, format string) {
+	c.Header("Content-Type", format+"; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="pokemon.`+formats.Extension(format)+`"`)
+	c.Status(http.StatusOK)
+
+	encoder := formats.NewEncoder(c.Writer, format)
+	err := h.serviceFor(c).ExportPokemon(encoder.Encode)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "")
+			c.Header("Content-Disposition", "")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// The status line has been sent, so the client only sees a truncated file
+		log.Printf("pokemon export failed: %v", err)
+	}
+}
+
+// @Summary Import Pokemon
+// @Description Import Pokemon from a CSV (with a header row), NDJSON or YAML file, chosen by Content-Type. Columns match the export; ids and timestamps are ignored. Every rejected row is listed in the report, and dry_run validates without saving.
+// @Tags pokemon
+// @Accept text/csv,application/x-ndjson,application/yaml
+// @Produce json
+// @Param dry_run query bool false "Validate only"
+// @Success 200 {object} domain.PokemonImportReport
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/import [post]
+func (h *pokemonHandler) ImportPokemon(c *gin.Context) {
+	format, ok := formats.FromContentType(c.GetHeader("Content-Type"))
+	if !ok {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be text/csv, application/x-ndjson or application/yaml"})
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be true or false"})
+		return
+	}
+
+	rows, err := formats.Decode(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize), format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "import file is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.serviceFor(c).ImportPokemon(rows, attribution(c), dryRun)
+	if err != nil {
+		if err.Error() == "import cannot have more than 5000 rows" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
 }
 
 // @Summary Health check endpoint
@@ -4,19 +4,33 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 type MockPokemonService struct {
 	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockPokemonService) ForTenant(tenantID string) ports.PokemonService {
+	m.tenantID = tenantID
+	return m
 }
 
 func (m *MockPokemonService) CreatePokemon(req *domain.CreatePokemonRequest) (*domain.Pokemon, error) {
@@ -43,6 +57,22 @@ func (m *MockPokemonService) GetPokemon(id uint) (*domain.Pokemon, error) {
 	return args.Get(0).(*domain.Pokemon), args.Error(1)
 }
 
+func (m *MockPokemonService) GetPokemonByName(name string) (*domain.Pokemon, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonByDexNumber(number int) (*domain.Pokemon, error) {
+	args := m.Called(number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
 func (m *MockPokemonService) ListPokemon() ([]*domain.Pokemon, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -51,19 +81,114 @@ func (m *MockPokemonService) ListPokemon() ([]*domain.Pokemon, error) {
 	return args.Get(0).([]*domain.Pokemon), args.Error(1)
 }
 
+func (m *MockPokemonService) ListPokemonPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonPage), args.Error(1)
+}
+
+func (m *MockPokemonService) UpdatePokemon(id uint, req *domain.UpdatePokemonRequest, expected *time.Time, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, req, expected, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) DeletePokemon(id uint, expected *time.Time, by domain.Attribution) error {
+	args := m.Called(id, expected, by)
+	return args.Error(0)
+}
+
+func (m *MockPokemonService) ListDeletedPokemon() ([]*domain.Pokemon, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) RestorePokemon(id uint, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) PurgeDeletedPokemon(retention time.Duration) (int, error) {
+	args := m.Called(retention)
+	return args.Int(0), args.Error(1)
+}
+
+// ExportPokemon hands the Pokemon given to Return to fn, then returns the error given to Return
+func (m *MockPokemonService) ExportPokemon(fn func(*domain.Pokemon) error) error {
+	args := m.Called()
+	for _, pokemon := range args.Get(0).([]*domain.Pokemon) {
+		if err := fn(pokemon); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockPokemonService) ImportPokemon(rows []domain.PokemonImportRow, by domain.Attribution, dryRun bool) (*domain.PokemonImportReport, error) {
+	args := m.Called(rows, by, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonImportReport), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonHistory(id uint) ([]*domain.PokemonAudit, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PokemonAudit), args.Error(1)
+}
+
+func (m *MockPokemonService) GetPokemonAsOf(id uint, at time.Time) (*domain.Pokemon, error) {
+	args := m.Called(id, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonService) SearchPokemon(query *domain.PokemonSearchQuery) (*domain.PokemonSearchResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonSearchResult), args.Error(1)
+}
+
 func setupRouter(service *MockPokemonService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	handler := NewPokemonHandler(service)
+	router.Use(RequestID())
+	handler := NewPokemonHandler(service, new(MockAnnotationService))
 
 	router.GET("/health", handler.HealthCheck)
 	api := router.Group("/api/v1")
 	{
+		api.GET("/search", handler.SearchPokemon)
 		pokemon := api.Group("/pokemon")
 		{
 			pokemon.POST("", handler.CreatePokemonFlexible)
+			pokemon.POST("/import", handler.ImportPokemon)
 			pokemon.GET("/:id", handler.GetPokemon)
+			pokemon.GET("/by-name/:name", handler.GetPokemonByName)
+			pokemon.GET("/by-dex/:number", handler.GetPokemonByDexNumber)
 			pokemon.GET("", handler.ListPokemon)
+			pokemon.PUT("/:id", handler.UpdatePokemon)
+			pokemon.DELETE("/:id", handler.DeletePokemon)
+			pokemon.GET("/:id/history", handler.GetPokemonHistory)
+			pokemon.GET("/trash", handler.ListTrash)
+			pokemon.POST("/:id/restore", handler.RestorePokemon)
 		}
 	}
 
@@ -146,6 +271,24 @@ func TestPokemonHandler_CreatePokemonFlexible(t *testing.T) {
 				"error": "pokemon with this name already exists",
 			},
 		},
+		{
+			name: "unknown name with suggestions",
+			requestBody: map[string]interface{}{
+				"name":  "charzard",
+				"type1": "fire",
+			},
+			setupMock: func(service *MockPokemonService) {
+				service.On("CreatePokemonFlexible", mock.AnythingOfType("*domain.FlexiblePokemonRequest")).Return(nil, &domain.UnknownPokemonError{
+					Name:        "charzard",
+					Suggestions: []string{"charizard", "charmander"},
+				})
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: map[string]interface{}{
+				"error":       `unknown pokemon "charzard", did you mean charizard, charmander?`,
+				"suggestions": []interface{}{"charizard", "charmander"},
+			},
+		},
 		{
 			name: "external API error",
 			requestBody: map[string]interface{}{
@@ -201,6 +344,33 @@ func TestPokemonHandler_CreatePokemonFlexible(t *testing.T) {
 	}
 }
 
+func TestPokemonHandler_CreatePokemonFlexible_RecordsPrincipal(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("CreatePokemonFlexible", mock.MatchedBy(func(req *domain.FlexiblePokemonRequest) bool {
+		return req.CreatedBy == "ash"
+	})).Return(&domain.Pokemon{ID: 1, Name: "pikachu", Type1: "electric", CreatedBy: "ash"}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewPokemonHandler(mockService, new(MockAnnotationService))
+	router.POST("/api/v1/pokemon", func(c *gin.Context) {
+		c.Set(principalKey, &domain.Principal{Subject: "ash", Method: domain.AuthMethodJWT, Roles: []string{domain.RoleEditor}})
+	}, handler.CreatePokemonFlexible)
+
+	// A created_by value in the body is ignored
+	body, _ := json.Marshal(map[string]interface{}{"name": "pikachu", "type1": "electric", "created_by": "gary"})
+	req, _ := http.NewRequest("POST", "/api/v1/pokemon", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "ash", response["created_by"])
+	mockService.AssertExpectations(t)
+}
+
 func TestPokemonHandler_GetPokemon(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -397,3 +567,788 @@ func TestParseUint(t *testing.T) {
 		})
 	}
 }
+
+func TestPokemonHandler_GetPokemon_ConditionalRequests(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemon", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "pikachu", Type1: "electric"}, nil)
+	router := setupRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	tests := []struct {
+		name           string
+		ifNoneMatch    string
+		expectedStatus int
+	}{
+		{name: "matching ETag", ifNoneMatch: etag, expectedStatus: http.StatusNotModified},
+		{name: "weak form of the ETag", ifNoneMatch: "W/" + etag, expectedStatus: http.StatusNotModified},
+		{name: "one of several ETags", ifNoneMatch: `"stale", ` + etag, expectedStatus: http.StatusNotModified},
+		{name: "stale ETag", ifNoneMatch: `"stale"`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon/1", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPokemonHandler_GetPokemon_Localized(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		acceptLanguage   string
+		expectedLanguage string
+		expectedName     string
+		expectedFlavor   string
+	}{
+		{name: "Accept-Language", acceptLanguage: "ja;q=0.9, fr;q=0.5", expectedLanguage: "ja-hrkt", expectedName: "ピカチュウ", expectedFlavor: "でんきを ためこむ。"},
+		{name: "lang overrides Accept-Language", query: "?lang=es", acceptLanguage: "ja", expectedLanguage: "es", expectedName: "Pikachu", expectedFlavor: "Almacena electricidad."},
+		{name: "regional variant", acceptLanguage: "es-MX", expectedLanguage: "es", expectedName: "Pikachu", expectedFlavor: "Almacena electricidad."},
+		{name: "name without a Pokedex entry keeps the English one", query: "?lang=ko", expectedLanguage: "ko", expectedName: "피카츄", expectedFlavor: "It stores electricity."},
+		{name: "falls back to English", acceptLanguage: "de", expectedLanguage: "en", expectedName: "Pikachu", expectedFlavor: "It stores electricity."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			mockService.On("GetPokemon", uint(1)).Return(&domain.Pokemon{
+				ID:         1,
+				Name:       "pikachu",
+				FlavorText: "It stores electricity.",
+				Translations: map[string]domain.PokemonTranslation{
+					"en":      {Name: "Pikachu", FlavorText: "It stores electricity."},
+					"es":      {Name: "Pikachu", FlavorText: "Almacena electricidad."},
+					"ja-hrkt": {Name: "ピカチュウ", FlavorText: "でんきを ためこむ。"},
+					"ko":      {Name: "피카츄"},
+				},
+			}, nil)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon/1"+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedLanguage, w.Header().Get("Content-Language"))
+			assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedLanguage, body["language"])
+			assert.Equal(t, tt.expectedName, body["localized_name"])
+			assert.Equal(t, tt.expectedFlavor, body["flavor_text"])
+			assert.NotContains(t, body, "translations")
+		})
+	}
+
+	t.Run("stored before translations were kept", func(t *testing.T) {
+		mockService := new(MockPokemonService)
+		mockService.On("GetPokemon", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "pikachu"}, nil)
+		router := setupRouter(mockService)
+
+		req, _ := http.NewRequest("GET", "/api/v1/pokemon/1?lang=ja", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Language"))
+		assert.NotContains(t, w.Body.String(), "localized_name")
+	})
+}
+
+func TestPokemonHandler_ListPokemon_ETagChangesWithContent(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("ListPokemon").Return([]*domain.Pokemon{{ID: 1, Name: "pikachu"}}, nil).Once()
+	mockService.On("ListPokemon").Return([]*domain.Pokemon{{ID: 1, Name: "pikachu"}, {ID: 2, Name: "eevee"}}, nil).Once()
+	router := setupRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+
+	req, _ = http.NewRequest("GET", "/api/v1/pokemon", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonHandler_ListPokemon_Export(t *testing.T) {
+	pokemon := []*domain.Pokemon{{ID: 1, Name: "pikachu", Type1: "electric", HP: 35}}
+
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "csv",
+			accept:              "text/csv",
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "id,name,type1,type2,height,weight,base_experience,hp,attack,defense,special_attack,special_defense,speed,created_by,created_at,updated_at\n1,pikachu,electric,,0,0,0,35,0,0,0,0,0,,0001-01-01T00:00:00Z,0001-01-01T00:00:00Z\n",
+		},
+		{
+			name:                "ndjson",
+			accept:              "application/x-ndjson",
+			expectedContentType: "application/x-ndjson; charset=utf-8",
+			expectedBody:        `{"id":1,"name":"pikachu","type1":"electric","height":0,"weight":0,"base_experience":0,"hp":35,"attack":0,"defense":0,"special_attack":0,"special_defense":0,"speed":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			mockService.On("ExportPokemon").Return(pokemon, nil)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon", nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			mockService.AssertNotCalled(t, "ListPokemon")
+		})
+	}
+}
+
+func TestPokemonHandler_ListPokemon_ExportError(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("ExportPokemon").Return([]*domain.Pokemon{}, errors.New("database error"))
+	router := setupRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon", nil)
+	req.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+}
+
+func TestPokemonHandler_ImportPokemon(t *testing.T) {
+	csvBody := "name,type1,hp\npikachu,electric,35\n"
+
+	tests := []struct {
+		name           string
+		contentType    string
+		query          string
+		body           string
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+	}{
+		{
+			name:        "csv import",
+			contentType: "text/csv",
+			body:        csvBody,
+			setupMock: func(service *MockPokemonService) {
+				service.On("ImportPokemon", mock.MatchedBy(func(rows []domain.PokemonImportRow) bool {
+					return len(rows) == 1 && rows[0].Pokemon.Name == "pikachu" && rows[0].Pokemon.HP == 35
+				}), domain.Attribution{RequestID: "req-1"}, false).Return(&domain.PokemonImportReport{Total: 1, Valid: 1, Imported: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "yaml dry run",
+			contentType: "application/yaml",
+			query:       "?dry_run=true",
+			body:        "- name: pikachu\n  type1: electric\n",
+			setupMock: func(service *MockPokemonService) {
+				service.On("ImportPokemon", mock.AnythingOfType("[]domain.PokemonImportRow"), mock.AnythingOfType("domain.Attribution"), true).Return(&domain.PokemonImportReport{DryRun: true, Total: 1, Valid: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unsupported content type",
+			contentType:    "application/json",
+			body:           `[{"name":"pikachu"}]`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "invalid dry_run",
+			contentType:    "text/csv",
+			query:          "?dry_run=maybe",
+			body:           csvBody,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unreadable file",
+			contentType:    "application/yaml",
+			body:           "name: pikachu\n",
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "file too large",
+			contentType:    "application/x-ndjson",
+			body:           strings.Repeat(`{"name":"pikachu"}`+"\n", maxImportSize/18+1),
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "too many rows",
+			contentType: "text/csv",
+			body:        csvBody,
+			setupMock: func(service *MockPokemonService) {
+				service.On("ImportPokemon", mock.Anything, mock.AnythingOfType("domain.Attribution"), false).Return(nil, errors.New("import cannot have more than 5000 rows"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			contentType: "text/csv",
+			body:        csvBody,
+			setupMock: func(service *MockPokemonService) {
+				service.On("ImportPokemon", mock.Anything, mock.AnythingOfType("domain.Attribution"), false).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/v1/pokemon/import"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("X-Request-ID", "req-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonHandler_UpdatePokemon(t *testing.T) {
+	rotom := &domain.Pokemon{ID: 1, Name: "rotom", Type1: "electric", Type2: "ghost"}
+	rotomBody, _ := json.Marshal(rotom)
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		ifMatch        string
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+	}{
+		{
+			name: "successful update",
+			id:   "1",
+			body: `{"type1":"electric","type2":"ghost"}`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("UpdatePokemon", uint(1), &domain.UpdatePokemonRequest{Type1: "electric", Type2: "ghost"}, (*time.Time)(nil), mock.AnythingOfType("domain.Attribution")).Return(rotom, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "matching If-Match",
+			id:      "1",
+			body:    `{"type1":"electric","type2":"fire"}`,
+			ifMatch: etagFor(rotomBody),
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemon", uint(1)).Return(rotom, nil)
+				service.On("UpdatePokemon", uint(1), mock.AnythingOfType("*domain.UpdatePokemonRequest"), &rotom.UpdatedAt, mock.AnythingOfType("domain.Attribution")).Return(rotom, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "changed between the If-Match check and the update",
+			id:      "1",
+			body:    `{"type1":"electric","type2":"fire"}`,
+			ifMatch: etagFor(rotomBody),
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemon", uint(1)).Return(rotom, nil)
+				service.On("UpdatePokemon", uint(1), mock.AnythingOfType("*domain.UpdatePokemonRequest"), &rotom.UpdatedAt, mock.AnythingOfType("domain.Attribution")).Return(nil, fmt.Errorf("failed to save Pokemon: %w", &domain.ModifiedError{Resource: "pokemon"}))
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "stale If-Match",
+			id:      "1",
+			body:    `{"type1":"electric","type2":"fire"}`,
+			ifMatch: `"stale"`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemon", uint(1)).Return(rotom, nil)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "missing type1",
+			id:             "1",
+			body:           `{"type2":"ghost"}`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid ID",
+			id:             "abc",
+			body:           `{"type1":"electric"}`,
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown type",
+			id:   "1",
+			body: `{"type1":"sound"}`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("UpdatePokemon", uint(1), mock.AnythingOfType("*domain.UpdatePokemonRequest"), (*time.Time)(nil), mock.AnythingOfType("domain.Attribution")).Return(nil, errors.New("type1 must be a known type"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "pokemon not found",
+			id:   "1",
+			body: `{"type1":"electric"}`,
+			setupMock: func(service *MockPokemonService) {
+				service.On("UpdatePokemon", uint(1), mock.AnythingOfType("*domain.UpdatePokemonRequest"), (*time.Time)(nil), mock.AnythingOfType("domain.Attribution")).Return(nil, errors.New("pokemon not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("PUT", "/api/v1/pokemon/"+tt.id, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// The ETag a client gets from a localized GET or PUT is the one If-Match is checked against
+func TestPokemonHandler_IfMatch_Localized(t *testing.T) {
+	pikachu := func() *domain.Pokemon {
+		return &domain.Pokemon{
+			ID:         25,
+			Name:       "pikachu",
+			Type1:      "electric",
+			FlavorText: "It stores electricity.",
+			Translations: map[string]domain.PokemonTranslation{
+				"en":      {Name: "Pikachu", FlavorText: "It stores electricity."},
+				"ja-hrkt": {Name: "ピカチュウ", FlavorText: "でんきを ためこむ。"},
+			},
+		}
+	}
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemon", uint(25)).Return(pikachu(), nil).Once()
+	mockService.On("GetPokemon", uint(25)).Return(pikachu(), nil).Once()
+	mockService.On("UpdatePokemon", uint(25), mock.AnythingOfType("*domain.UpdatePokemonRequest"), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("domain.Attribution")).Return(pikachu(), nil)
+	mockService.On("GetPokemon", uint(25)).Return(pikachu(), nil).Once()
+	mockService.On("DeletePokemon", uint(25), mock.AnythingOfType("*time.Time"), mock.AnythingOfType("domain.Attribution")).Return(nil)
+	router := setupRouter(mockService)
+
+	send := func(method, ifMatch string) *httptest.ResponseRecorder {
+		var body io.Reader
+		if method == "PUT" {
+			body = strings.NewReader(`{"type1":"electric"}`)
+		}
+		req, _ := http.NewRequest(method, "/api/v1/pokemon/25", body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "ja")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	get := send("GET", "")
+	assert.Equal(t, http.StatusOK, get.Code)
+
+	put := send("PUT", get.Header().Get("ETag"))
+	assert.Equal(t, http.StatusOK, put.Code)
+	assert.Equal(t, get.Header().Get("ETag"), put.Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusNoContent, send("DELETE", put.Header().Get("ETag")).Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonHandler_DeletePokemon(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "successful delete", expectedStatus: http.StatusNoContent},
+		{name: "pokemon not found", err: errors.New("pokemon not found"), expectedStatus: http.StatusNotFound},
+		{name: "database error", err: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			mockService.On("DeletePokemon", uint(1), (*time.Time)(nil), domain.Attribution{RequestID: "req-1"}).Return(tt.err)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("DELETE", "/api/v1/pokemon/1", nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonHandler_SearchPokemon(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+	}{
+		{
+			name: "search with filters",
+			path: "/api/v1/search?q=fire&type=Fire&generation=1&limit=5&offset=5",
+			setupMock: func(service *MockPokemonService) {
+				service.On("SearchPokemon", &domain.PokemonSearchQuery{Text: "fire", Type: "fire", Generation: 1, Limit: 5, Offset: 5}).Return(&domain.PokemonSearchResult{
+					Query: "fire",
+					Total: 6,
+					Hits:  []domain.PokemonSearchHit{{Pokemon: &domain.Pokemon{ID: 6, Name: "charizard"}, Score: 1.2}},
+					Facets: domain.PokemonSearchFacets{
+						Type:       []domain.FacetCount{{Value: "fire", Count: 6}},
+						Generation: []domain.FacetCount{{Value: "1", Count: 6}},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "missing query",
+			path: "/api/v1/search",
+			setupMock: func(service *MockPokemonService) {
+				service.On("SearchPokemon", &domain.PokemonSearchQuery{}).Return(nil, errors.New("search query is required"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non-numeric limit",
+			path:           "/api/v1/search?q=fire&limit=ten",
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if w.Code == http.StatusOK {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, float64(6), response["total"])
+				facets := response["facets"].(map[string]interface{})
+				assert.Equal(t, "fire", facets["type"].([]interface{})[0].(map[string]interface{})["value"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonHandler_GetPokemonByNameAndDex(t *testing.T) {
+	pikachu := &domain.Pokemon{ID: 7, DexNumber: 25, Name: "pikachu", Type1: "electric"}
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+	}{
+		{
+			name: "by name",
+			path: "/api/v1/pokemon/by-name/Pikachu",
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonByName", "Pikachu").Return(pikachu, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "by name not found",
+			path: "/api/v1/pokemon/by-name/missingno",
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonByName", "missingno").Return(nil, errors.New("pokemon not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "by dex number",
+			path: "/api/v1/pokemon/by-dex/25",
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonByDexNumber", 25).Return(pikachu, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "dex number out of range",
+			path: "/api/v1/pokemon/by-dex/2000",
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonByDexNumber", 2000).Return(nil, errors.New("dex number must be between 1 and 1025"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "dex number not a number",
+			path:           "/api/v1/pokemon/by-dex/pikachu",
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if w.Code == http.StatusOK {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, float64(7), response["id"])
+				assert.Equal(t, float64(25), response["dex_number"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonHandler_ListTrash(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService := new(MockPokemonService)
+	mockService.On("ListDeletedPokemon").Return([]*domain.Pokemon{{ID: 3, Name: "eevee", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}}, nil)
+	router := setupRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon/trash", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "eevee", response[0]["name"])
+	assert.Equal(t, "2026-03-01T12:00:00Z", response[0]["deleted_at"])
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonHandler_GetPokemon_NoDeletedAt(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemon", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "pikachu", Type1: "electric"}, nil)
+	router := setupRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotContains(t, response, "deleted_at")
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonHandler_RestorePokemon(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+	}{
+		{
+			name: "successful restore",
+			path: "/api/v1/pokemon/3/restore",
+			setupMock: func(service *MockPokemonService) {
+				service.On("RestorePokemon", uint(3), mock.AnythingOfType("domain.Attribution")).Return(&domain.Pokemon{ID: 3, Name: "eevee"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "not in trash",
+			path: "/api/v1/pokemon/3/restore",
+			setupMock: func(service *MockPokemonService) {
+				service.On("RestorePokemon", uint(3), mock.AnythingOfType("domain.Attribution")).Return(nil, errors.New("pokemon not found in trash"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "name taken by a live Pokemon",
+			path: "/api/v1/pokemon/3/restore",
+			setupMock: func(service *MockPokemonService) {
+				service.On("RestorePokemon", uint(3), mock.AnythingOfType("domain.Attribution")).Return(nil, errors.New("pokemon with this name already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid ID",
+			path:           "/api/v1/pokemon/eevee/restore",
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonHandler_GetPokemonHistory(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemonHistory", uint(1)).Return([]*domain.PokemonAudit{
+		{ID: 1, PokemonID: 1, Action: domain.AuditActionCreated, After: &domain.Pokemon{ID: 1, Type1: "electric"}, Actor: "ash", Source: domain.AuditSourceAPI},
+		{ID: 2, PokemonID: 1, Action: domain.AuditActionDeleted, Before: &domain.Pokemon{ID: 1, Type1: "electric"}, Source: domain.AuditSourceAPI},
+	}, nil)
+	mockService.On("GetPokemonHistory", uint(9)).Return(nil, errors.New("pokemon not found"))
+	router := setupRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon/1/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+	assert.Equal(t, "created", response[0]["action"])
+	assert.Nil(t, response[0]["before"])
+	assert.Equal(t, "ash", response[0]["actor"])
+	assert.Nil(t, response[1]["after"])
+
+	req, _ = http.NewRequest("GET", "/api/v1/pokemon/9/history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestPokemonHandler_GetPokemon_AsOf(t *testing.T) {
+	asOf := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockPokemonService)
+		expectedStatus int
+	}{
+		{
+			name:  "past version",
+			query: "?as_of=2024-03-01T12:00:00Z",
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonAsOf", uint(1), asOf).Return(&domain.Pokemon{ID: 1, Type2: "ghost"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "did not exist yet",
+			query: "?as_of=2024-03-01T12:00:00Z",
+			setupMock: func(service *MockPokemonService) {
+				service.On("GetPokemonAsOf", uint(1), asOf).Return(nil, errors.New("pokemon not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid time",
+			query:          "?as_of=yesterday",
+			setupMock:      func(service *MockPokemonService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPokemonService)
+			tt.setupMock(mockService)
+			router := setupRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon/1"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockPokemonService)
+	mockService.On("GetPokemon", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "bulbasaur"}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/pokemon/:id", NewPokemonHandler(mockService, new(MockAnnotationService)).GetPokemon)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
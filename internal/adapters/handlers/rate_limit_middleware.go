@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rateLimiter struct {
+	store ports.RateLimitStore
+}
+
+func NewRateLimiter(store ports.RateLimitStore) *rateLimiter {
+	return &rateLimiter{
+		store: store,
+	}
+}
+
+// Limit applies a token bucket per client to the routes it guards; name keeps each route's buckets apart.
+// A limit with no burst disables limiting, and requests are let through if the store fails.
+func (r *rateLimiter) Limit(name string, limit domain.RateLimit) gin.HandlerFunc {
+	return r.limit(name, limit, clientKey)
+}
+
+// LimitByIP applies a token bucket per client IP address. It goes before authentication, so requests with
+// missing or invalid credentials are limited too.
+func (r *rateLimiter) LimitByIP(name string, limit domain.RateLimit) gin.HandlerFunc {
+	return r.limit(name, limit, func(c *gin.Context) string {
+		return "ip:" + c.ClientIP()
+	})
+}
+
+func (r *rateLimiter) limit(name string, limit domain.RateLimit, key func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit.Burst <= 0 {
+			c.Next()
+			return
+		}
+
+		decision, err := r.store.Take(name+":"+key(c), limit)
+		if err != nil {
+			log.Printf("rate limiter %s: %v", name, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+
+		if !decision.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientKey identifies the caller by API key or token subject when authenticated, and by IP otherwise
+func clientKey(c *gin.Context) string {
+	if principal := currentPrincipal(c); principal != nil {
+		return principal.ClientKey()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRateLimitStore struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitStore) Take(key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	args := m.Called(key, limit)
+	return args.Get(0).(domain.RateLimitDecision), args.Error(1)
+}
+
+func setupRateLimitRouter(store *MockRateLimitStore, principal *domain.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	limiter := NewRateLimiter(store)
+	authenticate := func(c *gin.Context) {
+		if principal != nil {
+			c.Set(principalKey, principal)
+		}
+	}
+	ok := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+	router.POST("/api/v1/pokemon", authenticate, limiter.Limit("create-pokemon", domain.PerMinute(10)), ok)
+	router.GET("/api/v1/pokemon", authenticate, limiter.Limit("api", domain.RateLimit{}), ok)
+	return router
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	apiKeyPrincipal := &domain.Principal{Subject: "ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}}
+
+	tests := []struct {
+		name            string
+		method          string
+		principal       *domain.Principal
+		setupMock       func(*MockRateLimitStore)
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:      "allowed request keyed by API key",
+			method:    "POST",
+			principal: apiKeyPrincipal,
+			setupMock: func(store *MockRateLimitStore) {
+				store.On("Take", "create-pokemon:api_key:ci", domain.PerMinute(10)).Return(domain.RateLimitDecision{
+					Allowed: true, Limit: 10, Remaining: 9, Reset: 6 * time.Second,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+				"RateLimit-Reset":     "6",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:   "limited request keyed by IP",
+			method: "POST",
+			setupMock: func(store *MockRateLimitStore) {
+				store.On("Take", "create-pokemon:ip:192.0.2.1", domain.PerMinute(10)).Return(domain.RateLimitDecision{
+					Limit: 10, Reset: time.Minute, RetryAfter: 5500 * time.Millisecond,
+				}, nil)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "60",
+				"Retry-After":         "6",
+			},
+		},
+		{
+			name:      "store failure lets the request through",
+			method:    "POST",
+			principal: apiKeyPrincipal,
+			setupMock: func(store *MockRateLimitStore) {
+				store.On("Take", "create-pokemon:api_key:ci", domain.PerMinute(10)).Return(domain.RateLimitDecision{}, errors.New("connection refused"))
+			},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+		{
+			name:            "zero limit disables limiting",
+			method:          "GET",
+			setupMock:       func(store *MockRateLimitStore) {},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockRateLimitStore)
+			tt.setupMock(mockStore)
+			router := setupRateLimitRouter(mockStore, tt.principal)
+
+			req, _ := http.NewRequest(tt.method, "/api/v1/pokemon", nil)
+			req.RemoteAddr = "192.0.2.1:54321"
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for name, value := range tt.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(name), name)
+			}
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRateLimiter_LimitByIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowed        bool
+		expectedStatus int
+	}{
+		// Requests that fail authentication still use up the address's bucket
+		{name: "allowed request reaches authentication", allowed: true, expectedStatus: http.StatusUnauthorized},
+		{name: "limited request is rejected before authentication", expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockRateLimitStore)
+			mockStore.On("Take", "client-ip:ip:192.0.2.1", domain.PerMinute(600)).Return(domain.RateLimitDecision{
+				Allowed: tt.allowed, Limit: 600, Reset: time.Second, RetryAfter: time.Second,
+			}, nil)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/api/v1/pokemon", NewRateLimiter(mockStore).LimitByIP("client-ip", domain.PerMinute(600)), func(c *gin.Context) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+			})
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon", nil)
+			req.RemoteAddr = "192.0.2.1:54321"
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRateLimiter_LimitByIP_ForwardedFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		expectedKeys   []string
+	}{
+		// A client rotating X-Forwarded-For still lands in its own address's bucket
+		{name: "no trusted proxies", expectedKeys: []string{"client-ip:ip:192.0.2.1", "client-ip:ip:192.0.2.1"}},
+		{name: "trusted proxy", trustedProxies: []string{"192.0.2.1"}, expectedKeys: []string{"client-ip:ip:198.51.100.7", "client-ip:ip:198.51.100.8"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockRateLimitStore)
+			for _, key := range tt.expectedKeys {
+				mockStore.On("Take", key, domain.PerMinute(600)).Return(domain.RateLimitDecision{Allowed: true, Limit: 600}, nil).Once()
+			}
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			assert.NoError(t, router.SetTrustedProxies(tt.trustedProxies))
+			router.GET("/api/v1/pokemon", NewRateLimiter(mockStore).LimitByIP("client-ip", domain.PerMinute(600)), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			for _, forwardedFor := range []string{"198.51.100.7", "198.51.100.8"} {
+				req, _ := http.NewRequest("GET", "/api/v1/pokemon", nil)
+				req.RemoteAddr = "192.0.2.1:54321"
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				assert.Equal(t, http.StatusOK, w.Code)
+			}
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
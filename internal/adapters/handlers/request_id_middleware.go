@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"pokemon-api/internal/core/domain"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+)
+
+// validRequestID keeps client-chosen IDs short and printable, since they are stored and echoed back
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID tags each request with the client's X-Request-ID, or a random one, and echoes it in the response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// currentRequestID returns the request's ID, or "" when the route is not behind RequestID
+func currentRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// attribution says who is making a change in this request
+func attribution(c *gin.Context) domain.Attribution {
+	by := domain.Attribution{RequestID: currentRequestID(c)}
+	if principal := currentPrincipal(c); principal != nil {
+		by.Actor = principal.Subject
+	}
+	return by
+}
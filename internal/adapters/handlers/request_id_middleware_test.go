@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, currentRequestID(c))
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "client ID is kept", header: "req-42", expected: "req-42"},
+		{name: "missing ID is generated"},
+		{name: "unsafe ID is replaced", header: "a b\tc"},
+		{name: "long ID is replaced", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Request-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get("X-Request-ID")
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, id)
+			} else {
+				assert.Len(t, id, 32)
+			}
+			assert.Equal(t, id, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// spriteCacheControl lets browsers keep sprites for a day; they only change if PokeAPI replaces an image
+const spriteCacheControl = "private, max-age=86400"
+
+type spriteHandler struct {
+	service ports.SpriteService
+}
+
+func NewSpriteHandler(service ports.SpriteService) *spriteHandler {
+	return &spriteHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *spriteHandler) serviceFor(c *gin.Context) ports.SpriteService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Get a Pokemon's sprite
+// @Description Serve a Pokemon's image from the local cache. The image is downloaded from PokeAPI's sprite host on first use and served locally after that.
+// @Tags pokemon
+// @Produce image/png
+// @Param id path int true "Pokemon ID"
+// @Param variant query string false "default, shiny or artwork (official artwork)" default(default)
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {file} binary
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/pokemon/{id}/sprite [get]
+func (h *spriteHandler) GetSprite(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid Pokemon ID")
+	if !ok {
+		return
+	}
+
+	blob, err := h.serviceFor(c).GetSprite(id, c.DefaultQuery("variant", domain.SpriteDefault))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("ETag", blob.ETag)
+	c.Header("Cache-Control", spriteCacheControl)
+	if etagMatches(c.GetHeader("If-None-Match"), blob.ETag, false) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, blob.ContentType, blob.Data)
+}
+
+func (h *spriteHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "pokemon not found", err.Error() == "pokemon has no sprite of this variant":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err.Error() == "variant must be default, shiny or artwork":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "failed to fetch sprite"):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSpriteService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockSpriteService) ForTenant(tenantID string) ports.SpriteService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockSpriteService) GetSprite(pokemonID uint, variant string) (*domain.Blob, error) {
+	args := m.Called(pokemonID, variant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blob), args.Error(1)
+}
+
+func setupSpriteRouter(service *MockSpriteService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/pokemon/:id/sprite", NewSpriteHandler(service).GetSprite)
+	return router
+}
+
+func TestSpriteHandler_GetSprite(t *testing.T) {
+	png := &domain.Blob{ContentType: "image/png", ETag: `"0f4636c7"`, Data: []byte("\x89PNG")}
+
+	tests := []struct {
+		name           string
+		path           string
+		ifNoneMatch    string
+		setupMock      func(*MockSpriteService)
+		expectedStatus int
+	}{
+		{
+			name: "default variant",
+			path: "/api/v1/pokemon/1/sprite",
+			setupMock: func(service *MockSpriteService) {
+				service.On("GetSprite", uint(1), "default").Return(png, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "shiny variant",
+			path: "/api/v1/pokemon/1/sprite?variant=shiny",
+			setupMock: func(service *MockSpriteService) {
+				service.On("GetSprite", uint(1), "shiny").Return(png, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "not modified",
+			path:        "/api/v1/pokemon/1/sprite",
+			ifNoneMatch: `"0f4636c7"`,
+			setupMock: func(service *MockSpriteService) {
+				service.On("GetSprite", uint(1), "default").Return(png, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "invalid ID",
+			path:           "/api/v1/pokemon/abc/sprite",
+			setupMock:      func(service *MockSpriteService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown variant",
+			path: "/api/v1/pokemon/1/sprite?variant=back",
+			setupMock: func(service *MockSpriteService) {
+				service.On("GetSprite", uint(1), "back").Return(nil, errors.New("variant must be default, shiny or artwork"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "no image for the variant",
+			path: "/api/v1/pokemon/1/sprite?variant=artwork",
+			setupMock: func(service *MockSpriteService) {
+				service.On("GetSprite", uint(1), "artwork").Return(nil, errors.New("pokemon has no sprite of this variant"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "download failure",
+			path: "/api/v1/pokemon/1/sprite",
+			setupMock: func(service *MockSpriteService) {
+				service.On("GetSprite", uint(1), "default").Return(nil, errors.New("failed to fetch sprite: image host returned status 503"))
+			},
+			expectedStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSpriteService)
+			tt.setupMock(mockService)
+			router := setupSpriteRouter(mockService)
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			switch tt.expectedStatus {
+			case http.StatusOK:
+				assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+				assert.Equal(t, `"0f4636c7"`, w.Header().Get("ETag"))
+				assert.Equal(t, "private, max-age=86400", w.Header().Get("Cache-Control"))
+				assert.Equal(t, png.Data, w.Body.Bytes())
+			case http.StatusNotModified:
+				assert.Empty(t, w.Body.Bytes())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSpriteHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockSpriteService)
+	mockService.On("GetSprite", uint(1), "default").Return(&domain.Blob{ContentType: "image/png", ETag: `"0f4636c7"`, Data: []byte("\x89PNG")}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/pokemon/:id/sprite", NewSpriteHandler(mockService).GetSprite)
+
+	req, _ := http.NewRequest("GET", "/api/v1/pokemon/1/sprite", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
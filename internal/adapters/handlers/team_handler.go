@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type teamHandler struct {
+	service ports.TeamService
+}
+
+func NewTeamHandler(service ports.TeamService) *teamHandler {
+	return &teamHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *teamHandler) serviceFor(c *gin.Context) ports.TeamService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Create a team
+// @Description Create a competitive team of up to six stored Pokemon with their chosen moves
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param team body domain.TeamRequest true "Team data"
+// @Success 201 {object} domain.Team
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/teams [post]
+func (h *teamHandler) CreateTeam(c *gin.Context) {
+	var req domain.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	team, err := h.serviceFor(c).CreateTeam(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+// @Summary Get team by ID
+// @Description Retrieve a team and its members by ID
+// @Tags teams
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {object} domain.Team
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/teams/{id} [get]
+func (h *teamHandler) GetTeam(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid team ID")
+	if !ok {
+		return
+	}
+
+	team, err := h.serviceFor(c).GetTeam(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, team)
+}
+
+// @Summary List all teams
+// @Description Retrieve all teams with their members
+// @Tags teams
+// @Produce json
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {array} domain.Team
+// @Success 304
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/teams [get]
+func (h *teamHandler) ListTeams(c *gin.Context) {
+	teams, err := h.serviceFor(c).ListTeams()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, teams)
+}
+
+// @Summary Update a team
+// @Description Replace a team's name, owner and members
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param team body domain.TeamRequest true "Team data"
+// @Param If-Match header string false "ETag the change is based on"
+// @Success 200 {object} domain.Team
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/teams/{id} [put]
+func (h *teamHandler) UpdateTeam(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid team ID")
+	if !ok {
+		return
+	}
+
+	var req domain.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, id)
+	if !ok {
+		return
+	}
+
+	team, err := h.serviceFor(c).UpdateTeam(id, &req, expected)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, team)
+}
+
+// @Summary Delete a team
+// @Description Delete a team and its members
+// @Tags teams
+// @Param id path int true "Team ID"
+// @Param If-Match header string false "ETag the change is based on"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/teams/{id} [delete]
+func (h *teamHandler) DeleteTeam(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid team ID")
+	if !ok {
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, id)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceFor(c).DeleteTeam(id, expected); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Analyze a team
+// @Description Report shared weaknesses, offensive coverage gaps from the members' moves, speed tiers and stat totals for a team, with a warning for each member whose Pokemon is in the trash
+// @Tags teams
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} domain.TeamAnalysis
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /api/v1/teams/{id}/analysis [get]
+func (h *teamHandler) AnalyzeTeam(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid team ID")
+	if !ok {
+		return
+	}
+
+	analysis, err := h.serviceFor(c).AnalyzeTeam(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// checkIfMatch compares If-Match, when sent, with the team's current ETag and returns the version the change must
+// still apply to, nil without If-Match
+func (h *teamHandler) checkIfMatch(c *gin.Context, id uint) (*time.Time, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return nil, true
+	}
+
+	team, err := h.serviceFor(c).GetTeam(id)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+	if !checkIfMatch(c, team) {
+		return nil, false
+	}
+	return &team.UpdatedAt, true
+}
+
+func (h *teamHandler) handleError(c *gin.Context, err error) {
+	if preconditionFailed(c, err) || externalFailed(c, err) {
+		return
+	}
+
+	switch err.Error() {
+	case "team not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "team cannot have more than 6 members",
+		"team member cannot know more than 4 moves",
+		"team member pokemon not found":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
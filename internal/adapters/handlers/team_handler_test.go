@@ -0,0 +1,436 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTeamService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockTeamService) ForTenant(tenantID string) ports.TeamService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockTeamService) CreateTeam(req *domain.TeamRequest) (*domain.Team, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) GetTeam(id uint) (*domain.Team, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) ListTeams() ([]*domain.Team, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) UpdateTeam(id uint, req *domain.TeamRequest, expected *time.Time) (*domain.Team, error) {
+	args := m.Called(id, req, expected)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) DeleteTeam(id uint, expected *time.Time) error {
+	args := m.Called(id, expected)
+	return args.Error(0)
+}
+
+func (m *MockTeamService) AnalyzeTeam(id uint) (*domain.TeamAnalysis, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamAnalysis), args.Error(1)
+}
+
+func setupTeamRouter(service *MockTeamService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewTeamHandler(service)
+
+	teams := router.Group("/api/v1/teams")
+	{
+		teams.POST("", handler.CreateTeam)
+		teams.GET("", handler.ListTeams)
+		teams.GET("/:id", handler.GetTeam)
+		teams.PUT("/:id", handler.UpdateTeam)
+		teams.DELETE("/:id", handler.DeleteTeam)
+		teams.GET("/:id/analysis", handler.AnalyzeTeam)
+	}
+
+	return router
+}
+
+func TestTeamHandler_CreateTeam(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*MockTeamService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "successful creation",
+			requestBody: map[string]interface{}{
+				"name":    "rain",
+				"members": []map[string]interface{}{{"pokemon_id": 1, "moves": []string{"surf"}}},
+			},
+			setupMock: func(service *MockTeamService) {
+				service.On("CreateTeam", mock.AnythingOfType("*domain.TeamRequest")).Return(&domain.Team{ID: 1, Name: "rain"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "too many members",
+			requestBody: map[string]interface{}{
+				"name": "overfull",
+			},
+			setupMock: func(service *MockTeamService) {
+				service.On("CreateTeam", mock.AnythingOfType("*domain.TeamRequest")).Return(nil, errors.New("team cannot have more than 6 members"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "team cannot have more than 6 members",
+		},
+		{
+			name: "unknown member",
+			requestBody: map[string]interface{}{
+				"name": "ghosts",
+			},
+			setupMock: func(service *MockTeamService) {
+				service.On("CreateTeam", mock.AnythingOfType("*domain.TeamRequest")).Return(nil, errors.New("team member pokemon not found"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "team member pokemon not found",
+		},
+		{
+			name:           "missing name",
+			requestBody:    map[string]interface{}{"owner": "red"},
+			setupMock:      func(service *MockTeamService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTeamService)
+			tt.setupMock(mockService)
+			router := setupTeamRouter(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest("POST", "/api/v1/teams", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response["error"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamHandler_GetTeam(t *testing.T) {
+	tests := []struct {
+		name           string
+		teamID         string
+		setupMock      func(*MockTeamService)
+		expectedStatus int
+	}{
+		{
+			name:   "successful get",
+			teamID: "1",
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(1)).Return(&domain.Team{ID: 1, Name: "rain"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "team not found",
+			teamID: "2",
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(2)).Return(nil, errors.New("team not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid team ID",
+			teamID:         "abc",
+			setupMock:      func(service *MockTeamService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTeamService)
+			tt.setupMock(mockService)
+			router := setupTeamRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/teams/"+tt.teamID, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamHandler_ListTeams(t *testing.T) {
+	mockService := new(MockTeamService)
+	mockService.On("ListTeams").Return([]*domain.Team{{ID: 1}, {ID: 2}}, nil)
+	router := setupTeamRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/teams", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
+
+func TestTeamHandler_UpdateTeam(t *testing.T) {
+	mockService := new(MockTeamService)
+	mockService.On("UpdateTeam", uint(1), mock.AnythingOfType("*domain.TeamRequest"), (*time.Time)(nil)).Return(&domain.Team{ID: 1, Name: "sun"}, nil)
+	router := setupTeamRouter(mockService)
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "sun"})
+	req, _ := http.NewRequest("PUT", "/api/v1/teams/1", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTeamHandler_DeleteTeam(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "successful delete", expectedStatus: http.StatusNoContent},
+		{name: "team not found", err: errors.New("team not found"), expectedStatus: http.StatusNotFound},
+		{name: "database error", err: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTeamService)
+			mockService.On("DeleteTeam", uint(1), (*time.Time)(nil)).Return(tt.err)
+			router := setupTeamRouter(mockService)
+
+			req, _ := http.NewRequest("DELETE", "/api/v1/teams/1", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamHandler_AnalyzeTeam(t *testing.T) {
+	mockService := new(MockTeamService)
+	mockService.On("AnalyzeTeam", uint(1)).Return(&domain.TeamAnalysis{
+		TeamID:           1,
+		SharedWeaknesses: []string{"rock"},
+		CoverageGaps:     []string{"dragon"},
+	}, nil)
+	router := setupTeamRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/teams/1/analysis", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []interface{}{"rock"}, response["shared_weaknesses"])
+	assert.Equal(t, []interface{}{"dragon"}, response["coverage_gaps"])
+	mockService.AssertExpectations(t)
+}
+
+func TestTeamHandler_AnalyzeTeam_MoveErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "unknown move", err: &domain.ExternalNotFoundError{Resource: "move", Identifier: "hyper-punch"}, expectedStatus: http.StatusBadRequest},
+		{name: "PokeAPI unavailable", err: &domain.ExternalServiceError{Err: errors.New("PokeAPI returned status 503")}, expectedStatus: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTeamService)
+			mockService.On("AnalyzeTeam", uint(1)).Return(nil, tt.err)
+			router := setupTeamRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/teams/1/analysis", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTeamHandler_UpdateTeam_IfMatch(t *testing.T) {
+	current := &domain.Team{ID: 1, Name: "rain"}
+	currentBody, _ := json.Marshal(current)
+	currentETag := etagFor(currentBody)
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		setupMock      func(*MockTeamService)
+		expectedStatus int
+	}{
+		{
+			name:    "matching ETag",
+			ifMatch: currentETag,
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(1)).Return(current, nil)
+				service.On("UpdateTeam", uint(1), mock.AnythingOfType("*domain.TeamRequest"), &current.UpdatedAt).Return(&domain.Team{ID: 1, Name: "sun"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "any current version",
+			ifMatch: "*",
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(1)).Return(current, nil)
+				service.On("UpdateTeam", uint(1), mock.AnythingOfType("*domain.TeamRequest"), &current.UpdatedAt).Return(&domain.Team{ID: 1, Name: "sun"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "team changed since it was read",
+			ifMatch: `"stale"`,
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(1)).Return(current, nil)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "weak ETags never match",
+			ifMatch: "W/" + currentETag,
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(1)).Return(current, nil)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "team not found",
+			ifMatch: currentETag,
+			setupMock: func(service *MockTeamService) {
+				service.On("GetTeam", uint(1)).Return(nil, errors.New("team not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTeamService)
+			tt.setupMock(mockService)
+			router := setupTeamRouter(mockService)
+
+			body, _ := json.Marshal(map[string]interface{}{"name": "sun"})
+			req, _ := http.NewRequest("PUT", "/api/v1/teams/1", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", tt.ifMatch)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamHandler_DeleteTeam_StaleIfMatch(t *testing.T) {
+	mockService := new(MockTeamService)
+	mockService.On("GetTeam", uint(1)).Return(&domain.Team{ID: 1, Name: "rain"}, nil)
+	router := setupTeamRouter(mockService)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/teams/1", nil)
+	req.Header.Set("If-Match", `"stale"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockService.AssertNotCalled(t, "DeleteTeam", uint(1), mock.Anything)
+}
+
+func TestTeamHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockTeamService)
+	mockService.On("ListTeams").Return([]*domain.Team{}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/teams", NewTeamHandler(mockService).ListTeams)
+
+	req, _ := http.NewRequest("GET", "/api/v1/teams", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
+
+func TestTeamHandler_DeleteTeam_ChangedAfterIfMatch(t *testing.T) {
+	current := &domain.Team{ID: 1, Name: "rain"}
+	currentBody, _ := json.Marshal(current)
+	mockService := new(MockTeamService)
+	mockService.On("GetTeam", uint(1)).Return(current, nil)
+	mockService.On("DeleteTeam", uint(1), &current.UpdatedAt).Return(&domain.ModifiedError{Resource: "team"})
+	router := setupTeamRouter(mockService)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/teams/1", nil)
+	req.Header.Set("If-Match", etagFor(currentBody))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockService.AssertExpectations(t)
+}
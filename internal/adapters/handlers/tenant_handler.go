@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type tenantHandler struct {
+	service ports.TenantService
+}
+
+func NewTenantHandler(service ports.TenantService) *tenantHandler {
+	return &tenantHandler{
+		service: service,
+	}
+}
+
+// @Summary Create a tenant
+// @Description Provision a workspace. Its ID is a lowercase slug that callers send in the X-Tenant-ID header or bind to an API key.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param tenant body domain.TenantRequest true "Tenant ID and name"
+// @Success 201 {object} domain.Tenant
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tenants [post]
+func (h *tenantHandler) CreateTenant(c *gin.Context) {
+	var req domain.TenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tenant, err := h.service.CreateTenant(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tenant)
+}
+
+// @Summary List tenants
+// @Description List every workspace of the deployment
+// @Tags tenants
+// @Produce json
+// @Success 200 {array} domain.Tenant
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tenants [get]
+func (h *tenantHandler) ListTenants(c *gin.Context) {
+	tenants, err := h.service.ListTenants()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tenants)
+}
+
+// @Summary Get a tenant
+// @Description Get a workspace by ID
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} domain.Tenant
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tenants/{id} [get]
+func (h *tenantHandler) GetTenant(c *gin.Context) {
+	tenant, err := h.service.GetTenant(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tenant)
+}
+
+func (h *tenantHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "tenant not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "tenant already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "tenant ID must be a lowercase slug of at most 63 characters", "tenant name is required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTenantRouter(service *MockTenantService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewTenantHandler(service)
+	router.POST("/api/v1/tenants", handler.CreateTenant)
+	router.GET("/api/v1/tenants", handler.ListTenants)
+	router.GET("/api/v1/tenants/:id", handler.GetTenant)
+	return router
+}
+
+func TestTenantHandler_CreateTenant(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		setupMock      func(*MockTenantService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: map[string]interface{}{"id": "kanto", "name": "Kanto League"},
+			setupMock: func(service *MockTenantService) {
+				service.On("CreateTenant", &domain.TenantRequest{ID: "kanto", Name: "Kanto League"}).Return(&domain.Tenant{ID: "kanto", Name: "Kanto League"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing name",
+			requestBody:    map[string]interface{}{"id": "kanto"},
+			setupMock:      func(service *MockTenantService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid ID",
+			requestBody: map[string]interface{}{"id": "Kanto League", "name": "Kanto League"},
+			setupMock: func(service *MockTenantService) {
+				service.On("CreateTenant", mock.AnythingOfType("*domain.TenantRequest")).Return(nil, errors.New("tenant ID must be a lowercase slug of at most 63 characters"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "duplicate ID",
+			requestBody: map[string]interface{}{"id": "kanto", "name": "Kanto League"},
+			setupMock: func(service *MockTenantService) {
+				service.On("CreateTenant", mock.AnythingOfType("*domain.TenantRequest")).Return(nil, errors.New("tenant already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTenantService)
+			tt.setupMock(mockService)
+			router := setupTenantRouter(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest("POST", "/api/v1/tenants", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_ListTenants(t *testing.T) {
+	mockService := new(MockTenantService)
+	mockService.On("ListTenants").Return([]*domain.Tenant{{ID: "default", Name: "Default"}, {ID: "kanto", Name: "Kanto League"}}, nil)
+	router := setupTenantRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tenants", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []domain.Tenant
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+	mockService.AssertExpectations(t)
+}
+
+func TestTenantHandler_GetTenant(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		setupMock      func(*MockTenantService)
+		expectedStatus int
+	}{
+		{
+			name: "existing tenant",
+			id:   "kanto",
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", "kanto").Return(&domain.Tenant{ID: "kanto", Name: "Kanto League"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "unknown tenant",
+			id:   "hoenn",
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", "hoenn").Return(nil, errors.New("tenant not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTenantService)
+			tt.setupMock(mockService)
+			router := setupTenantRouter(mockService)
+
+			req, _ := http.NewRequest("GET", "/api/v1/tenants/"+tt.id, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tenantHeader = "X-Tenant-ID"
+	// tenantKey is the gin context key holding the ID of the request's tenant
+	tenantKey = "tenant"
+)
+
+type tenantMiddleware struct {
+	service ports.TenantService
+}
+
+func NewTenantMiddleware(service ports.TenantService) *tenantMiddleware {
+	return &tenantMiddleware{
+		service: service,
+	}
+}
+
+// Resolve picks the tenant a request acts on. Operator credentials may pick any with the X-Tenant-ID header;
+// every other credential acts in the tenant it is bound to, or the default tenant, and may not name another.
+// It must run after Authenticate.
+func (m *tenantMiddleware) Resolve(c *gin.Context) {
+	tenantID := c.GetHeader(tenantHeader)
+	if principal := currentPrincipal(c); principal == nil || !principal.IsOperator() {
+		home := domain.DefaultTenantID
+		if principal != nil {
+			home = principal.HomeTenant()
+		}
+		if tenantID != "" && tenantID != home {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "credential is bound to another tenant"})
+			return
+		}
+		tenantID = home
+	}
+	if tenantID == "" {
+		tenantID = domain.DefaultTenantID
+	}
+	if !domain.IsValidTenantID(tenantID) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid tenant ID"})
+		return
+	}
+
+	if _, err := m.service.GetTenant(tenantID); err != nil {
+		if err.Error() == "tenant not found" {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Set(tenantKey, tenantID)
+	c.Next()
+}
+
+// RequireOperator rejects tenant-bound credentials from routes that manage the whole deployment
+func RequireOperator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := currentPrincipal(c)
+		if principal == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+		if !principal.IsOperator() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "operator credentials required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// currentTenant returns the request's tenant, or the default tenant when the route is not behind Resolve
+func currentTenant(c *gin.Context) string {
+	if tenantID := c.GetString(tenantKey); tenantID != "" {
+		return tenantID
+	}
+	return domain.DefaultTenantID
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTenantService struct {
+	mock.Mock
+}
+
+func (m *MockTenantService) CreateTenant(req *domain.TenantRequest) (*domain.Tenant, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) GetTenant(id string) (*domain.Tenant, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) ListTenants() ([]*domain.Tenant, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) EnsureTenant(id, name string) error {
+	args := m.Called(id, name)
+	return args.Error(0)
+}
+
+func setupTenantMiddlewareRouter(service *MockTenantService, principal *domain.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1", func(c *gin.Context) {
+		if principal != nil {
+			c.Set(principalKey, principal)
+		}
+	}, NewTenantMiddleware(service).Resolve)
+	api.GET("/pokemon", func(c *gin.Context) {
+		c.String(http.StatusOK, currentTenant(c))
+	})
+	api.GET("/tenants", RequireOperator(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestTenantMiddleware_Resolve(t *testing.T) {
+	operator := &domain.Principal{Subject: "ops", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleAdmin}, Operator: true}
+	kanto := &domain.Principal{Subject: "ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}, TenantID: "kanto"}
+	// Keys issued before operators were an explicit grant, and tokens without a tenant claim, look like this
+	unbound := &domain.Principal{Subject: "viewer", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleReader}}
+
+	tests := []struct {
+		name           string
+		principal      *domain.Principal
+		header         string
+		setupMock      func(*MockTenantService)
+		expectedStatus int
+		expectedTenant string
+	}{
+		{
+			name:      "no header uses the default tenant",
+			principal: operator,
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", domain.DefaultTenantID).Return(&domain.Tenant{ID: domain.DefaultTenantID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTenant: domain.DefaultTenantID,
+		},
+		{
+			name:      "operator picks a tenant by header",
+			principal: operator,
+			header:    "kanto",
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", "kanto").Return(&domain.Tenant{ID: "kanto"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTenant: "kanto",
+		},
+		{
+			name:      "bound credential uses its tenant",
+			principal: kanto,
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", "kanto").Return(&domain.Tenant{ID: "kanto"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTenant: "kanto",
+		},
+		{
+			name:           "bound credential cannot switch tenants",
+			principal:      kanto,
+			header:         "johto",
+			setupMock:      func(service *MockTenantService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "unbound credential uses the default tenant",
+			principal: unbound,
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", domain.DefaultTenantID).Return(&domain.Tenant{ID: domain.DefaultTenantID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTenant: domain.DefaultTenantID,
+		},
+		{
+			name:      "unbound credential may name the default tenant",
+			principal: unbound,
+			header:    domain.DefaultTenantID,
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", domain.DefaultTenantID).Return(&domain.Tenant{ID: domain.DefaultTenantID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTenant: domain.DefaultTenantID,
+		},
+		{
+			name:           "unbound reader cannot switch tenants",
+			principal:      unbound,
+			header:         "kanto",
+			setupMock:      func(service *MockTenantService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid tenant ID",
+			principal:      operator,
+			header:         "Kanto League",
+			setupMock:      func(service *MockTenantService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "unknown tenant",
+			principal: operator,
+			header:    "hoenn",
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", "hoenn").Return(nil, errors.New("tenant not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "lookup failure",
+			principal: operator,
+			header:    "kanto",
+			setupMock: func(service *MockTenantService) {
+				service.On("GetTenant", "kanto").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTenantService)
+			tt.setupMock(mockService)
+			router := setupTenantMiddlewareRouter(mockService, tt.principal)
+
+			req, _ := http.NewRequest("GET", "/api/v1/pokemon", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedTenant, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRequireOperator(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      *domain.Principal
+		expectedStatus int
+	}{
+		{
+			name:           "operator credential",
+			principal:      &domain.Principal{Subject: "ops", Roles: []string{domain.RoleAdmin}, Operator: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unbound credential without the operator grant",
+			principal:      &domain.Principal{Subject: "ops", Roles: []string{domain.RoleAdmin}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "tenant-bound credential",
+			principal:      &domain.Principal{Subject: "ci", Roles: []string{domain.RoleAdmin}, TenantID: "kanto"},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTenantService)
+			mockService.On("GetTenant", mock.Anything).Return(&domain.Tenant{}, nil)
+			router := setupTenantMiddlewareRouter(mockService, tt.principal)
+
+			req, _ := http.NewRequest("GET", "/api/v1/tenants", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+// tenantRouter returns a router that resolves every request to the tenant, as the tenant middleware would
+func tenantRouter(tenantID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(tenantKey, tenantID)
+	})
+	return router
+}
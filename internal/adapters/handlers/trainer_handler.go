@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type trainerHandler struct {
+	service ports.TrainerService
+}
+
+func NewTrainerHandler(service ports.TrainerService) *trainerHandler {
+	return &trainerHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *trainerHandler) serviceFor(c *gin.Context) ports.TrainerService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Create a trainer
+// @Description Register a trainer who can own Pokemon
+// @Tags trainers
+// @Accept json
+// @Produce json
+// @Param trainer body domain.TrainerRequest true "Trainer data"
+// @Success 201 {object} domain.Trainer
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers [post]
+func (h *trainerHandler) CreateTrainer(c *gin.Context) {
+	var req domain.TrainerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	trainer, err := h.serviceFor(c).CreateTrainer(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, trainer)
+}
+
+// @Summary Get trainer by ID
+// @Description Retrieve a trainer by ID
+// @Tags trainers
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {object} domain.Trainer
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id} [get]
+func (h *trainerHandler) GetTrainer(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid trainer ID")
+	if !ok {
+		return
+	}
+
+	trainer, err := h.serviceFor(c).GetTrainer(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, trainer)
+}
+
+// @Summary List all trainers
+// @Description Retrieve all trainers
+// @Tags trainers
+// @Produce json
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {array} domain.Trainer
+// @Success 304
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers [get]
+func (h *trainerHandler) ListTrainers(c *gin.Context) {
+	trainers, err := h.serviceFor(c).ListTrainers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, trainers)
+}
+
+// @Summary Add a Pokemon to a trainer
+// @Description Catch a Pokemon of a stored species with its nickname, level, nature, IVs, EVs, held item and shininess
+// @Tags trainers
+// @Accept json
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Param pokemon body domain.OwnedPokemonRequest true "Owned Pokemon data"
+// @Success 201 {object} domain.OwnedPokemon
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokemon [post]
+func (h *trainerHandler) AddPokemon(c *gin.Context) {
+	trainerID, ok := parseIDParam(c, "id", "invalid trainer ID")
+	if !ok {
+		return
+	}
+
+	var req domain.OwnedPokemonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	owned, err := h.serviceFor(c).AddPokemon(trainerID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, owned)
+}
+
+// @Summary List a trainer's Pokemon
+// @Description Retrieve every Pokemon owned by a trainer, with computed stats
+// @Tags trainers
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {array} domain.OwnedPokemon
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokemon [get]
+func (h *trainerHandler) ListPokemon(c *gin.Context) {
+	trainerID, ok := parseIDParam(c, "id", "invalid trainer ID")
+	if !ok {
+		return
+	}
+
+	owned, err := h.serviceFor(c).ListPokemon(trainerID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, owned)
+}
+
+// @Summary Get a trainer's Pokemon
+// @Description Retrieve one owned Pokemon with computed stats
+// @Tags trainers
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Param pokemonId path int true "Owned Pokemon ID"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Success 200 {object} domain.OwnedPokemon
+// @Success 304
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokemon/{pokemonId} [get]
+func (h *trainerHandler) GetPokemon(c *gin.Context) {
+	trainerID, id, ok := parseOwnedPokemonParams(c)
+	if !ok {
+		return
+	}
+
+	owned, err := h.serviceFor(c).GetPokemon(trainerID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, owned)
+}
+
+// @Summary Update a trainer's Pokemon
+// @Description Replace an owned Pokemon's details
+// @Tags trainers
+// @Accept json
+// @Produce json
+// @Param id path int true "Trainer ID"
+// @Param pokemonId path int true "Owned Pokemon ID"
+// @Param pokemon body domain.OwnedPokemonRequest true "Owned Pokemon data"
+// @Param If-Match header string false "ETag the change is based on"
+// @Success 200 {object} domain.OwnedPokemon
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokemon/{pokemonId} [put]
+func (h *trainerHandler) UpdatePokemon(c *gin.Context) {
+	trainerID, id, ok := parseOwnedPokemonParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.OwnedPokemonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, trainerID, id)
+	if !ok {
+		return
+	}
+
+	owned, err := h.serviceFor(c).UpdatePokemon(trainerID, id, &req, expected)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	respondWithETag(c, http.StatusOK, owned)
+}
+
+// @Summary Release a trainer's Pokemon
+// @Description Remove an owned Pokemon from its trainer
+// @Tags trainers
+// @Param id path int true "Trainer ID"
+// @Param pokemonId path int true "Owned Pokemon ID"
+// @Param If-Match header string false "ETag the change is based on"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trainers/{id}/pokemon/{pokemonId} [delete]
+func (h *trainerHandler) ReleasePokemon(c *gin.Context) {
+	trainerID, id, ok := parseOwnedPokemonParams(c)
+	if !ok {
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, trainerID, id)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceFor(c).ReleasePokemon(trainerID, id, expected); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// checkIfMatch compares If-Match, when sent, with the owned Pokemon's current ETag and returns the version the
+// change must still apply to, nil without If-Match
+func (h *trainerHandler) checkIfMatch(c *gin.Context, trainerID, id uint) (*time.Time, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return nil, true
+	}
+
+	owned, err := h.serviceFor(c).GetPokemon(trainerID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+	if !checkIfMatch(c, owned) {
+		return nil, false
+	}
+	return &owned.UpdatedAt, true
+}
+
+func (h *trainerHandler) handleError(c *gin.Context, err error) {
+	if preconditionFailed(c, err) {
+		return
+	}
+
+	switch err.Error() {
+	case "trainer not found", "owned pokemon not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "trainer with this name already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "species not found",
+		"level must be between 1 and 100",
+		"unknown nature",
+		"IVs must be between 0 and 31",
+		"EVs must be between 0 and 252",
+		"EVs cannot total more than 510":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func parseOwnedPokemonParams(c *gin.Context) (uint, uint, bool) {
+	trainerID, ok := parseIDParam(c, "id", "invalid trainer ID")
+	if !ok {
+		return 0, 0, false
+	}
+	id, ok := parseIDParam(c, "pokemonId", "invalid owned Pokemon ID")
+	if !ok {
+		return 0, 0, false
+	}
+	return trainerID, id, true
+}
+
+// parseIDParam parses a numeric path parameter, writing a 400 response with message if it is invalid
+func parseIDParam(c *gin.Context, name, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+	return uint(id), true
+}
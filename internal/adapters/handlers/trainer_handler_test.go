@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrainerService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockTrainerService) ForTenant(tenantID string) ports.TrainerService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockTrainerService) CreateTrainer(req *domain.TrainerRequest) (*domain.Trainer, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerService) GetTrainer(id uint) (*domain.Trainer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerService) ListTrainers() ([]*domain.Trainer, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerService) AddPokemon(trainerID uint, req *domain.OwnedPokemonRequest) (*domain.OwnedPokemon, error) {
+	args := m.Called(trainerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OwnedPokemon), args.Error(1)
+}
+
+func (m *MockTrainerService) GetPokemon(trainerID, id uint) (*domain.OwnedPokemon, error) {
+	args := m.Called(trainerID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OwnedPokemon), args.Error(1)
+}
+
+func (m *MockTrainerService) ListPokemon(trainerID uint) ([]*domain.OwnedPokemon, error) {
+	args := m.Called(trainerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OwnedPokemon), args.Error(1)
+}
+
+func (m *MockTrainerService) UpdatePokemon(trainerID, id uint, req *domain.OwnedPokemonRequest, expected *time.Time) (*domain.OwnedPokemon, error) {
+	args := m.Called(trainerID, id, req, expected)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OwnedPokemon), args.Error(1)
+}
+
+func (m *MockTrainerService) ReleasePokemon(trainerID, id uint, expected *time.Time) error {
+	args := m.Called(trainerID, id, expected)
+	return args.Error(0)
+}
+
+func setupTrainerRouter(service *MockTrainerService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewTrainerHandler(service)
+
+	trainers := router.Group("/api/v1/trainers")
+	{
+		trainers.POST("", handler.CreateTrainer)
+		trainers.GET("", handler.ListTrainers)
+		trainers.GET("/:id", handler.GetTrainer)
+		trainers.POST("/:id/pokemon", handler.AddPokemon)
+		trainers.GET("/:id/pokemon", handler.ListPokemon)
+		trainers.GET("/:id/pokemon/:pokemonId", handler.GetPokemon)
+		trainers.PUT("/:id/pokemon/:pokemonId", handler.UpdatePokemon)
+		trainers.DELETE("/:id/pokemon/:pokemonId", handler.ReleasePokemon)
+	}
+
+	return router
+}
+
+func TestTrainerHandler_CreateTrainer(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("CreateTrainer", &domain.TrainerRequest{Name: "misty"}).Return(&domain.Trainer{ID: 1, Name: "misty"}, nil)
+	router := setupTrainerRouter(mockService)
+
+	body, _ := json.Marshal(map[string]string{"name": "misty"})
+	req, _ := http.NewRequest("POST", "/api/v1/trainers", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTrainerHandler_CreateTrainer_Duplicate(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("CreateTrainer", &domain.TrainerRequest{Name: "misty"}).Return(nil, errors.New("trainer with this name already exists"))
+	router := setupTrainerRouter(mockService)
+
+	body, _ := json.Marshal(map[string]string{"name": "misty"})
+	req, _ := http.NewRequest("POST", "/api/v1/trainers", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTrainerHandler_GetTrainer_NotFound(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("GetTrainer", uint(5)).Return(nil, errors.New("trainer not found"))
+	router := setupTrainerRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/trainers/5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTrainerHandler_AddPokemon(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		requestBody    interface{}
+		setupMock      func(*MockTrainerService)
+		expectedStatus int
+	}{
+		{
+			name: "successful add",
+			path: "/api/v1/trainers/1/pokemon",
+			requestBody: map[string]interface{}{
+				"species_id": 25,
+				"nickname":   "sparky",
+				"level":      50,
+				"nature":     "timid",
+				"ivs":        map[string]int{"speed": 31},
+				"evs":        map[string]int{"special_attack": 252, "speed": 252},
+			},
+			setupMock: func(service *MockTrainerService) {
+				service.On("AddPokemon", uint(1), mock.MatchedBy(func(req *domain.OwnedPokemonRequest) bool {
+					return req.SpeciesID == 25 && req.IVs.Speed == 31 && req.EVs.SpAttack == 252
+				})).Return(&domain.OwnedPokemon{ID: 1, TrainerID: 1, SpeciesID: 25, Stats: &domain.StatSpread{Speed: 156}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing species",
+			path:           "/api/v1/trainers/1/pokemon",
+			requestBody:    map[string]interface{}{"level": 50},
+			setupMock:      func(service *MockTrainerService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid EVs",
+			path:        "/api/v1/trainers/1/pokemon",
+			requestBody: map[string]interface{}{"species_id": 25},
+			setupMock: func(service *MockTrainerService) {
+				service.On("AddPokemon", uint(1), mock.AnythingOfType("*domain.OwnedPokemonRequest")).Return(nil, errors.New("EVs cannot total more than 510"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "trainer not found",
+			path:        "/api/v1/trainers/9/pokemon",
+			requestBody: map[string]interface{}{"species_id": 25},
+			setupMock: func(service *MockTrainerService) {
+				service.On("AddPokemon", uint(9), mock.AnythingOfType("*domain.OwnedPokemonRequest")).Return(nil, errors.New("trainer not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid trainer ID",
+			path:           "/api/v1/trainers/ash/pokemon",
+			requestBody:    map[string]interface{}{"species_id": 25},
+			setupMock:      func(service *MockTrainerService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTrainerService)
+			tt.setupMock(mockService)
+			router := setupTrainerRouter(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrainerHandler_GetPokemon(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("GetPokemon", uint(1), uint(3)).Return(&domain.OwnedPokemon{
+		ID:       3,
+		Nickname: "sparky",
+		Stats:    &domain.StatSpread{HP: 110},
+	}, nil)
+	router := setupTrainerRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/trainers/1/pokemon/3", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "sparky", response["nickname"])
+	assert.Equal(t, float64(110), response["stats"].(map[string]interface{})["hp"])
+}
+
+func TestTrainerHandler_ListPokemon(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("ListPokemon", uint(1)).Return([]*domain.OwnedPokemon{{ID: 1}, {ID: 2}}, nil)
+	router := setupTrainerRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/trainers/1/pokemon", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
+
+func TestTrainerHandler_UpdatePokemon(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("UpdatePokemon", uint(1), uint(3), mock.AnythingOfType("*domain.OwnedPokemonRequest"), (*time.Time)(nil)).Return(nil, errors.New("owned pokemon not found"))
+	router := setupTrainerRouter(mockService)
+
+	body, _ := json.Marshal(map[string]interface{}{"species_id": 25})
+	req, _ := http.NewRequest("PUT", "/api/v1/trainers/1/pokemon/3", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTrainerHandler_ReleasePokemon(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("ReleasePokemon", uint(1), uint(3), (*time.Time)(nil)).Return(nil)
+	router := setupTrainerRouter(mockService)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/trainers/1/pokemon/3", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTrainerHandler_ReleasePokemon_IfMatch(t *testing.T) {
+	current := &domain.OwnedPokemon{ID: 3, TrainerID: 1, Level: 50}
+	currentBody, _ := json.Marshal(current)
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		expectedStatus int
+		expectRelease  bool
+	}{
+		{name: "matching ETag", ifMatch: etagFor(currentBody), expectedStatus: http.StatusNoContent, expectRelease: true},
+		{name: "stale ETag", ifMatch: `"stale"`, expectedStatus: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTrainerService)
+			mockService.On("GetPokemon", uint(1), uint(3)).Return(current, nil)
+			if tt.expectRelease {
+				mockService.On("ReleasePokemon", uint(1), uint(3), &current.UpdatedAt).Return(nil)
+			}
+			router := setupTrainerRouter(mockService)
+
+			req, _ := http.NewRequest("DELETE", "/api/v1/trainers/1/pokemon/3", nil)
+			req.Header.Set("If-Match", tt.ifMatch)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrainerHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockTrainerService)
+	mockService.On("ListTrainers").Return([]*domain.Trainer{}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/trainers", NewTrainerHandler(mockService).ListTrainers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/trainers", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+)
+
+type webhookHandler struct {
+	service ports.WebhookService
+}
+
+func NewWebhookHandler(service ports.WebhookService) *webhookHandler {
+	return &webhookHandler{
+		service: service,
+	}
+}
+
+// serviceFor scopes the service to the request's tenant
+func (h *webhookHandler) serviceFor(c *gin.Context) ports.WebhookService {
+	return h.service.ForTenant(currentTenant(c))
+}
+
+// @Summary Register a webhook
+// @Description Subscribe a URL to Pokemon change events. Deliveries are signed with the secret, which is only returned in this response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body domain.WebhookRequest true "Receiver URL, event types and optional secret"
+// @Success 201 {object} domain.CreatedWebhook
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/webhooks [post]
+func (h *webhookHandler) CreateWebhook(c *gin.Context) {
+	var req domain.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.serviceFor(c).CreateWebhook(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// @Summary List webhooks
+// @Description List registered webhooks without their secrets
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} domain.Webhook
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/webhooks [get]
+func (h *webhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.serviceFor(c).ListWebhooks()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary Get a webhook
+// @Description Get a registered webhook without its secret
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} domain.Webhook
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/webhooks/{id} [get]
+func (h *webhookHandler) GetWebhook(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	webhook, err := h.serviceFor(c).GetWebhook(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary Delete a webhook
+// @Description Unsubscribe a webhook and drop its queued deliveries
+// @Tags webhooks
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *webhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	if err := h.serviceFor(c).DeleteWebhook(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List webhook deliveries
+// @Description List a webhook's most recent deliveries, newest first, with their status and last error
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {array} domain.WebhookDelivery
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.serviceFor(c).ListDeliveries(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary Retry a webhook delivery
+// @Description Queue a delivery again with a fresh set of attempts, including one that was dead-lettered
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 202 {object} domain.WebhookDelivery
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/webhooks/{id}/deliveries/{deliveryId}/retry [post]
+func (h *webhookHandler) RetryDelivery(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseIDParam(c, "deliveryId", "invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.serviceFor(c).RetryDelivery(id, deliveryID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (h *webhookHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "webhook not found", "webhook delivery not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "webhook URL must be an absolute http or https URL",
+		"at least one event type is required",
+		"unknown event type":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockWebhookService) ForTenant(tenantID string) ports.WebhookService {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockWebhookService) Publish(message *domain.OutboxMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) CreateWebhook(req *domain.WebhookRequest) (*domain.CreatedWebhook, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreatedWebhook), args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhook(id uint) (*domain.Webhook, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) ListWebhooks() ([]*domain.Webhook, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhook(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(webhookID uint) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) RetryDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) DeliverDue() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func setupWebhookRouter(service *MockWebhookService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewWebhookHandler(service)
+
+	webhooks := router.Group("/api/v1/webhooks")
+	{
+		webhooks.POST("", handler.CreateWebhook)
+		webhooks.GET("", handler.ListWebhooks)
+		webhooks.GET("/:id", handler.GetWebhook)
+		webhooks.DELETE("/:id", handler.DeleteWebhook)
+		webhooks.GET("/:id/deliveries", handler.ListDeliveries)
+		webhooks.POST("/:id/deliveries/:deliveryId/retry", handler.RetryDelivery)
+	}
+
+	return router
+}
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*MockWebhookService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful creation returns the secret",
+			requestBody: map[string]interface{}{"url": "https://example.com/hooks", "event_types": []string{"pokemon.created"}},
+			setupMock: func(service *MockWebhookService) {
+				service.On("CreateWebhook", mock.AnythingOfType("*domain.WebhookRequest")).Return(&domain.CreatedWebhook{
+					Webhook: domain.Webhook{ID: 1, URL: "https://example.com/hooks", Secret: "s3cret"},
+					Secret:  "s3cret",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing URL",
+			requestBody:    map[string]interface{}{"event_types": []string{"pokemon.created"}},
+			setupMock:      func(service *MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid URL",
+			requestBody: map[string]interface{}{"url": "ftp://example.com", "event_types": []string{"pokemon.created"}},
+			setupMock: func(service *MockWebhookService) {
+				service.On("CreateWebhook", mock.AnythingOfType("*domain.WebhookRequest")).Return(nil, errors.New("webhook URL must be an absolute http or https URL"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown event type",
+			requestBody: map[string]interface{}{"url": "https://example.com", "event_types": []string{"pokemon.evolved"}},
+			setupMock: func(service *MockWebhookService) {
+				service.On("CreateWebhook", mock.AnythingOfType("*domain.WebhookRequest")).Return(nil, errors.New("unknown event type"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+			router := setupWebhookRouter(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest("POST", "/api/v1/webhooks", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "s3cret", response["secret"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookHandler_GetWebhook_HidesSecret(t *testing.T) {
+	mockService := new(MockWebhookService)
+	mockService.On("GetWebhook", uint(1)).Return(&domain.Webhook{ID: 1, URL: "https://example.com", Secret: "s3cret"}, nil)
+	router := setupWebhookRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "s3cret")
+}
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockWebhookService)
+		expectedStatus int
+	}{
+		{
+			name: "deleted",
+			path: "/api/v1/webhooks/1",
+			setupMock: func(service *MockWebhookService) {
+				service.On("DeleteWebhook", uint(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "not found",
+			path: "/api/v1/webhooks/9",
+			setupMock: func(service *MockWebhookService) {
+				service.On("DeleteWebhook", uint(9)).Return(errors.New("webhook not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid ID",
+			path:           "/api/v1/webhooks/abc",
+			setupMock:      func(service *MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+			router := setupWebhookRouter(mockService)
+
+			req, _ := http.NewRequest("DELETE", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookHandler_ListDeliveries(t *testing.T) {
+	mockService := new(MockWebhookService)
+	mockService.On("ListDeliveries", uint(1)).Return([]*domain.WebhookDelivery{
+		{ID: 2, WebhookID: 1, Status: domain.DeliveryDead, Attempts: 10, LastError: "connection refused"},
+		{ID: 1, WebhookID: 1, Status: domain.DeliverySucceeded, Attempts: 1, ResponseStatus: 200},
+	}, nil)
+	router := setupWebhookRouter(mockService)
+
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/1/deliveries", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+	assert.Equal(t, "dead", response[0]["status"])
+	assert.Equal(t, "connection refused", response[0]["last_error"])
+}
+
+func TestWebhookHandler_RetryDelivery(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockWebhookService)
+		expectedStatus int
+	}{
+		{
+			name: "queued again",
+			path: "/api/v1/webhooks/1/deliveries/7/retry",
+			setupMock: func(service *MockWebhookService) {
+				service.On("RetryDelivery", uint(1), uint(7)).Return(&domain.WebhookDelivery{ID: 7, WebhookID: 1, Status: domain.DeliveryPending}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "delivery not found",
+			path: "/api/v1/webhooks/1/deliveries/8/retry",
+			setupMock: func(service *MockWebhookService) {
+				service.On("RetryDelivery", uint(1), uint(8)).Return(nil, errors.New("webhook delivery not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid delivery ID",
+			path:           "/api/v1/webhooks/1/deliveries/x/retry",
+			setupMock:      func(service *MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+			router := setupWebhookRouter(mockService)
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookHandler_ScopesToTenant(t *testing.T) {
+	mockService := new(MockWebhookService)
+	mockService.On("ListWebhooks").Return([]*domain.Webhook{}, nil)
+	router := tenantRouter("kanto")
+	router.GET("/api/v1/webhooks", NewWebhookHandler(mockService).ListWebhooks)
+
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "kanto", mockService.tenantID)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,27 @@
+package publishers
+
+import (
+	"log"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+)
+
+type logPublisher struct {
+	logger *log.Logger
+}
+
+// NewLogPublisher writes one line per relayed event to logger, which helps when tracing changes in development
+func NewLogPublisher(logger *log.Logger) ports.EventPublisher {
+	return &logPublisher{
+		logger: logger,
+	}
+}
+
+func (p *logPublisher) Publish(message *domain.OutboxMessage) error {
+	name := ""
+	if message.Pokemon != nil {
+		name = message.Pokemon.Name
+	}
+	p.logger.Printf("event %s %s pokemon_id=%d name=%q", message.DedupID, message.Type, message.PokemonID, name)
+	return nil
+}
@@ -0,0 +1,25 @@
+package publishers
+
+import (
+	"bytes"
+	"log"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogPublisher_Publish(t *testing.T) {
+	var buf bytes.Buffer
+	publisher := NewLogPublisher(log.New(&buf, "", 0))
+
+	err := publisher.Publish(&domain.OutboxMessage{
+		DedupID:   "abc123",
+		Type:      domain.EventPokemonCreated,
+		PokemonID: 25,
+		Pokemon:   &domain.Pokemon{ID: 25, Name: "pikachu"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "event abc123 pokemon.created pokemon_id=25 name=\"pikachu\"\n", buf.String())
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"math"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that have refilled completely are dropped
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   domain.RateLimit
+}
+
+// MemoryStore keeps token buckets in process memory, so each instance enforces its own limits
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() ports.RateLimitStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	decision := domain.RateLimitDecision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = b.timeFor(1 - b.tokens)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.Reset = b.timeFor(float64(limit.Burst) - b.tokens)
+
+	return decision, nil
+}
+
+// sweep drops full buckets, which behave exactly like missing ones
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated)
+	b.updated = now
+	if elapsed <= 0 || b.limit.Period <= 0 {
+		return
+	}
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+float64(b.limit.Burst)*elapsed.Seconds()/b.limit.Period.Seconds())
+}
+
+// timeFor returns how long the bucket takes to gain the given number of tokens
+func (b *bucket) timeFor(tokens float64) time.Duration {
+	if tokens <= 0 || b.limit.Burst <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens * float64(b.limit.Period) / float64(b.limit.Burst)))
+}
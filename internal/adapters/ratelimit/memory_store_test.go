@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(start time.Time) (*MemoryStore, *time.Time) {
+	now := start
+	store := NewMemoryStore().(*MemoryStore)
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	store, now := newTestStore(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	limit := domain.PerMinute(3)
+
+	// The bucket starts full, so the whole burst is available at once
+	for remaining := 2; remaining >= 0; remaining-- {
+		decision, err := store.Take("client", limit)
+		assert.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 3, decision.Limit)
+		assert.Equal(t, remaining, decision.Remaining)
+	}
+
+	decision, _ := store.Take("client", limit)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, 20*time.Second, decision.RetryAfter)
+	assert.Equal(t, time.Minute, decision.Reset)
+
+	// One token refills every 20 seconds
+	*now = now.Add(20 * time.Second)
+	decision, _ = store.Take("client", limit)
+	assert.True(t, decision.Allowed)
+	assert.Zero(t, decision.RetryAfter)
+	decision, _ = store.Take("client", limit)
+	assert.False(t, decision.Allowed)
+
+	// Other keys have their own buckets
+	decision, _ = store.Take("other", limit)
+	assert.True(t, decision.Allowed)
+
+	// Refilling never exceeds the burst
+	*now = now.Add(time.Hour)
+	decision, _ = store.Take("client", limit)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Remaining)
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	store, now := newTestStore(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	store.Take("idle", domain.PerMinute(60))
+	store.Take("busy", domain.RateLimit{Burst: 1, Period: time.Hour})
+	assert.Len(t, store.buckets, 2)
+
+	*now = now.Add(2 * time.Minute)
+	store.Take("new", domain.PerMinute(60))
+
+	assert.NotContains(t, store.buckets, "idle")
+	assert.Contains(t, store.buckets, "busy")
+	assert.Contains(t, store.buckets, "new")
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnnotationRepository struct {
+	db       *gorm.DB
+	tenantID string
+}
+
+func NewAnnotationRepository(db *gorm.DB) ports.AnnotationRepository {
+	return &AnnotationRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *AnnotationRepository) ForTenant(tenantID string) ports.AnnotationRepository {
+	return &AnnotationRepository{db: r.db, tenantID: tenantID}
+}
+
+func (r *AnnotationRepository) AddTag(pokemonID uint, name string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tag := domain.Tag{TenantID: r.tenantID, Name: name}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tag).Error; err != nil {
+			return err
+		}
+		// The tag already existed when the insert did nothing
+		if tag.ID == 0 {
+			if err := tx.Scopes(tenantScope(r.tenantID)).Where("name = ?", name).First(&tag).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.PokemonTag{PokemonID: pokemonID, TagID: tag.ID}).Error
+	})
+}
+
+// RemoveTag detaches the tag; the tag itself is kept, and drops out of the tag cloud once nothing carries it
+func (r *AnnotationRepository) RemoveTag(pokemonID uint, name string) error {
+	return r.db.
+		Where("pokemon_id = ?", pokemonID).
+		Where("tag_id IN (?)", r.db.Model(&domain.Tag{}).Scopes(tenantScope(r.tenantID)).Where("name = ?", name).Select("id")).
+		Delete(&domain.PokemonTag{}).Error
+}
+
+func (r *AnnotationRepository) ListTags(pokemonID uint) ([]string, error) {
+	names := []string{}
+	err := r.db.Model(&domain.Tag{}).
+		Joins("JOIN pokemon_tags ON pokemon_tags.tag_id = tags.id").
+		Where("tags.tenant_id = ? AND pokemon_tags.pokemon_id = ?", r.tenantID, pokemonID).
+		Order("tags.name").
+		Pluck("tags.name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func (r *AnnotationRepository) CountTags() ([]domain.TagCount, error) {
+	counts := []domain.TagCount{}
+	err := r.db.Model(&domain.Tag{}).
+		Select("tags.name AS name, COUNT(*) AS count").
+		Joins("JOIN pokemon_tags ON pokemon_tags.tag_id = tags.id").
+		Joins("JOIN pokemons ON pokemons.id = pokemon_tags.pokemon_id AND pokemons.deleted_at IS NULL").
+		Where("tags.tenant_id = ?", r.tenantID).
+		Group("tags.name").
+		Order("count DESC, name").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// AddFavorite does nothing if the user already favorited the Pokemon
+func (r *AnnotationRepository) AddFavorite(favorite *domain.PokemonFavorite) error {
+	favorite.TenantID = r.tenantID
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error
+}
+
+func (r *AnnotationRepository) RemoveFavorite(pokemonID uint, subject string) error {
+	return r.db.Scopes(tenantScope(r.tenantID)).
+		Where("pokemon_id = ? AND subject = ?", pokemonID, subject).
+		Delete(&domain.PokemonFavorite{}).Error
+}
+
+func (r *AnnotationRepository) IsFavorite(pokemonID uint, subject string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.PokemonFavorite{}).Scopes(tenantScope(r.tenantID)).
+		Where("pokemon_id = ? AND subject = ?", pokemonID, subject).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *AnnotationRepository) GetNote(pokemonID uint) (*domain.PokemonNote, error) {
+	var note domain.PokemonNote
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("pokemon_id = ?", pokemonID).First(&note).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("note not found")
+		}
+		return nil, err
+	}
+	return &note, nil
+}
+
+// SaveNote inserts the note or overwrites the Pokemon's existing note
+func (r *AnnotationRepository) SaveNote(note *domain.PokemonNote) error {
+	note.TenantID = r.tenantID
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "pokemon_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"body", "updated_by", "updated_at"}),
+	}).Create(note).Error
+}
+
+func (r *AnnotationRepository) DeleteNote(pokemonID uint) error {
+	return r.db.Scopes(tenantScope(r.tenantID)).Where("pokemon_id = ?", pokemonID).Delete(&domain.PokemonNote{}).Error
+}
+
+// ListPokemon returns the live Pokemon carrying every tag in the filter, and favorited by its user when one is set,
+// in ID order. Filter tags must not repeat.
+func (r *AnnotationRepository) ListPokemon(filter *domain.PokemonFilter) ([]*domain.Pokemon, error) {
+	query := r.db.Scopes(tenantScope(r.tenantID))
+	if len(filter.Tags) > 0 {
+		query = query.Where("id IN (?)", r.db.Model(&domain.PokemonTag{}).
+			Select("pokemon_tags.pokemon_id").
+			Joins("JOIN tags ON tags.id = pokemon_tags.tag_id").
+			Where("tags.tenant_id = ? AND tags.name IN ?", r.tenantID, filter.Tags).
+			Group("pokemon_tags.pokemon_id").
+			Having("COUNT(*) = ?", len(filter.Tags)))
+	}
+	if filter.FavoriteOf != "" {
+		query = query.Where("id IN (?)", r.db.Model(&domain.PokemonFavorite{}).
+			Scopes(tenantScope(r.tenantID)).
+			Where("subject = ?", filter.FavoriteOf).
+			Select("pokemon_id"))
+	}
+
+	pokemon := []*domain.Pokemon{}
+	if err := query.Order("id").Find(&pokemon).Error; err != nil {
+		return nil, err
+	}
+	return pokemon, nil
+}
+
+func (r *AnnotationRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Tag{}, &domain.PokemonTag{}, &domain.PokemonFavorite{}, &domain.PokemonNote{})
+}
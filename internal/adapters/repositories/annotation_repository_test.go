@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// seedAnnotatedPokemon stores pikachu, charizard and mewtwo in the default tenant
+func seedAnnotatedPokemon(t *testing.T, db *gorm.DB) []*domain.Pokemon {
+	assert.NoError(t, (&AnnotationRepository{db: db}).Migrate())
+	pokemonRepo := NewPokemonRepository(db)
+	var pokemon []*domain.Pokemon
+	for _, name := range []string{"pikachu", "charizard", "mewtwo"} {
+		p := &domain.Pokemon{Name: name, Type1: "normal"}
+		assert.NoError(t, pokemonRepo.Create(p, testChange))
+		pokemon = append(pokemon, p)
+	}
+	return pokemon
+}
+
+func TestAnnotationRepository_Tags(t *testing.T) {
+	db := setupTestDB(t)
+	pokemon := seedAnnotatedPokemon(t, db)
+	repo := NewAnnotationRepository(db)
+
+	assert.NoError(t, repo.AddTag(pokemon[0].ID, "ou viable"))
+	assert.NoError(t, repo.AddTag(pokemon[0].ID, "fast"))
+	// Adding a tag twice keeps one
+	assert.NoError(t, repo.AddTag(pokemon[0].ID, "fast"))
+	assert.NoError(t, repo.AddTag(pokemon[1].ID, "ou viable"))
+	assert.NoError(t, repo.AddTag(pokemon[2].ID, "ou viable"))
+
+	tags, err := repo.ListTags(pokemon[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fast", "ou viable"}, tags)
+
+	var count int64
+	db.Model(&domain.Tag{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+
+	assert.NoError(t, repo.RemoveTag(pokemon[0].ID, "fast"))
+	assert.NoError(t, repo.RemoveTag(pokemon[0].ID, "unknown"))
+	tags, err = repo.ListTags(pokemon[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ou viable"}, tags)
+
+	tags, err = repo.ListTags(99)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestAnnotationRepository_CountTags(t *testing.T) {
+	db := setupTestDB(t)
+	pokemon := seedAnnotatedPokemon(t, db)
+	repo := NewAnnotationRepository(db)
+
+	assert.NoError(t, repo.AddTag(pokemon[0].ID, "ou viable"))
+	assert.NoError(t, repo.AddTag(pokemon[1].ID, "ou viable"))
+	assert.NoError(t, repo.AddTag(pokemon[1].ID, "needs ev spread"))
+	assert.NoError(t, repo.AddTag(pokemon[2].ID, "banned"))
+	assert.NoError(t, repo.AddTag(pokemon[0].ID, "fast"))
+	assert.NoError(t, repo.RemoveTag(pokemon[0].ID, "fast"))
+
+	// Tags of deleted Pokemon are not counted
+	assert.NoError(t, NewPokemonRepository(db).Delete(pokemon[2].ID, nil, testChange))
+
+	counts, err := repo.CountTags()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TagCount{{Name: "ou viable", Count: 2}, {Name: "needs ev spread", Count: 1}}, counts)
+}
+
+func TestAnnotationRepository_Favorites(t *testing.T) {
+	db := setupTestDB(t)
+	pokemon := seedAnnotatedPokemon(t, db)
+	repo := NewAnnotationRepository(db)
+
+	assert.NoError(t, repo.AddFavorite(&domain.PokemonFavorite{PokemonID: pokemon[0].ID, Subject: "ash"}))
+	assert.NoError(t, repo.AddFavorite(&domain.PokemonFavorite{PokemonID: pokemon[0].ID, Subject: "ash"}))
+
+	favorite, err := repo.IsFavorite(pokemon[0].ID, "ash")
+	assert.NoError(t, err)
+	assert.True(t, favorite)
+	favorite, err = repo.IsFavorite(pokemon[0].ID, "misty")
+	assert.NoError(t, err)
+	assert.False(t, favorite)
+
+	assert.NoError(t, repo.RemoveFavorite(pokemon[0].ID, "ash"))
+	favorite, err = repo.IsFavorite(pokemon[0].ID, "ash")
+	assert.NoError(t, err)
+	assert.False(t, favorite)
+}
+
+func TestAnnotationRepository_Notes(t *testing.T) {
+	db := setupTestDB(t)
+	pokemon := seedAnnotatedPokemon(t, db)
+	repo := NewAnnotationRepository(db)
+
+	_, err := repo.GetNote(pokemon[0].ID)
+	assert.EqualError(t, err, "note not found")
+
+	assert.NoError(t, repo.SaveNote(&domain.PokemonNote{PokemonID: pokemon[0].ID, Body: "needs *EV* spread", UpdatedBy: "ash"}))
+	assert.NoError(t, repo.SaveNote(&domain.PokemonNote{PokemonID: pokemon[0].ID, Body: "252 Spe", UpdatedBy: "misty"}))
+
+	note, err := repo.GetNote(pokemon[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "252 Spe", note.Body)
+	assert.Equal(t, "misty", note.UpdatedBy)
+
+	assert.NoError(t, repo.DeleteNote(pokemon[0].ID))
+	_, err = repo.GetNote(pokemon[0].ID)
+	assert.EqualError(t, err, "note not found")
+}
+
+func TestAnnotationRepository_ListPokemon(t *testing.T) {
+	db := setupTestDB(t)
+	pokemon := seedAnnotatedPokemon(t, db)
+	repo := NewAnnotationRepository(db)
+
+	assert.NoError(t, repo.AddTag(pokemon[0].ID, "ou viable"))
+	assert.NoError(t, repo.AddTag(pokemon[1].ID, "ou viable"))
+	assert.NoError(t, repo.AddTag(pokemon[1].ID, "fast"))
+	assert.NoError(t, repo.AddFavorite(&domain.PokemonFavorite{PokemonID: pokemon[0].ID, Subject: "ash"}))
+	assert.NoError(t, repo.AddFavorite(&domain.PokemonFavorite{PokemonID: pokemon[2].ID, Subject: "misty"}))
+
+	names := func(filter *domain.PokemonFilter) []string {
+		listed, err := repo.ListPokemon(filter)
+		assert.NoError(t, err)
+		result := []string{}
+		for _, p := range listed {
+			result = append(result, p.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"pikachu", "charizard"}, names(&domain.PokemonFilter{Tags: []string{"ou viable"}}))
+	assert.Equal(t, []string{"charizard"}, names(&domain.PokemonFilter{Tags: []string{"ou viable", "fast"}}))
+	assert.Equal(t, []string{}, names(&domain.PokemonFilter{Tags: []string{"unknown"}}))
+	assert.Equal(t, []string{"pikachu"}, names(&domain.PokemonFilter{FavoriteOf: "ash"}))
+	assert.Equal(t, []string{}, names(&domain.PokemonFilter{Tags: []string{"fast"}, FavoriteOf: "ash"}))
+}
+
+func TestAnnotationRepository_TenantIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&AnnotationRepository{db: db}).Migrate())
+	kantoPokemon := NewPokemonRepository(db).ForTenant("kanto")
+	johtoPokemon := NewPokemonRepository(db).ForTenant("johto")
+	pikachu := &domain.Pokemon{Name: "pikachu", Type1: "electric"}
+	assert.NoError(t, kantoPokemon.Create(pikachu, testChange))
+	pichu := &domain.Pokemon{Name: "pichu", Type1: "electric"}
+	assert.NoError(t, johtoPokemon.Create(pichu, testChange))
+
+	kanto := NewAnnotationRepository(db).ForTenant("kanto")
+	johto := NewAnnotationRepository(db).ForTenant("johto")
+	assert.NoError(t, kanto.AddTag(pikachu.ID, "ou viable"))
+	assert.NoError(t, johto.AddTag(pichu.ID, "ou viable"))
+
+	// Each tenant has its own tag by the same name
+	var count int64
+	db.Model(&domain.Tag{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+
+	counts, err := johto.CountTags()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TagCount{{Name: "ou viable", Count: 1}}, counts)
+
+	listed, err := johto.ListPokemon(&domain.PokemonFilter{Tags: []string{"ou viable"}})
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, "pichu", listed[0].Name)
+
+	tags, err := johto.ListTags(pikachu.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
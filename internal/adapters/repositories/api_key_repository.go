@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) ports.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(key *domain.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *APIKeyRepository) GetByHash(hash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.db.Where("key_hash = ?", hash).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("api key not found")
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) List() ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	err := r.db.Order("id").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.APIKey{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("api key not found")
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.APIKey{})
+}
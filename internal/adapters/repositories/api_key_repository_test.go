@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&APIKeyRepository{db: db}).Migrate())
+	repo := NewAPIKeyRepository(db)
+
+	key := &domain.APIKey{Name: "ci", Prefix: "pk_1234", KeyHash: "abc123", Scopes: []string{domain.RoleEditor}}
+	assert.NoError(t, repo.Create(key))
+	assert.NotZero(t, key.ID)
+
+	// Hashes are unique
+	assert.Error(t, repo.Create(&domain.APIKey{Name: "ci-copy", KeyHash: "abc123"}))
+
+	found, err := repo.GetByHash("abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", found.Name)
+	assert.Equal(t, []string{domain.RoleEditor}, found.Scopes)
+
+	_, err = repo.GetByHash("unknown")
+	assert.EqualError(t, err, "api key not found")
+
+	keys, err := repo.List()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	assert.NoError(t, repo.Delete(key.ID))
+	assert.EqualError(t, repo.Delete(key.ID), "api key not found")
+
+	_, err = repo.GetByHash("abc123")
+	assert.EqualError(t, err, "api key not found")
+}
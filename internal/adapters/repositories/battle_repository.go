@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"gorm.io/gorm"
+)
+
+type BattleRepository struct {
+	db       *gorm.DB
+	tenantID string
+}
+
+func NewBattleRepository(db *gorm.DB) ports.BattleRepository {
+	return &BattleRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *BattleRepository) ForTenant(tenantID string) ports.BattleRepository {
+	return &BattleRepository{db: r.db, tenantID: tenantID}
+}
+
+func (r *BattleRepository) Create(battle *domain.Battle) error {
+	battle.TenantID = r.tenantID
+	return r.db.Create(battle).Error
+}
+
+func (r *BattleRepository) GetByID(id uint) (*domain.Battle, error) {
+	var battle domain.Battle
+	err := r.db.Scopes(tenantScope(r.tenantID)).First(&battle, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("battle not found")
+		}
+		return nil, err
+	}
+	return &battle, nil
+}
+
+func (r *BattleRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Battle{})
+}
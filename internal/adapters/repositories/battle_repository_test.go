@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBattleRepository_CreateAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&BattleRepository{db: db}).Migrate())
+	repo := NewBattleRepository(db)
+
+	battle := &domain.Battle{
+		TeamAID: 1,
+		TeamBID: 2,
+		Format:  domain.BattleFormat1v1,
+		Level:   50,
+		Seed:    42,
+		Winner:  domain.BattleWinnerTeamA,
+		Turns:   1,
+		Log: []domain.BattleEvent{
+			{Type: domain.BattleEventSwitch, Side: domain.BattleWinnerTeamA, Pokemon: "pikachu", RemainingHP: 110},
+			{Turn: 1, Type: domain.BattleEventMove, Side: domain.BattleWinnerTeamA, Pokemon: "pikachu", Move: "thunderbolt", Target: "gyarados", Damage: 150, Effectiveness: 4},
+			{Turn: 1, Type: domain.BattleEventEnd, Winner: domain.BattleWinnerTeamA},
+		},
+		Moves: map[string]domain.Move{
+			"thunderbolt": {Name: "thunderbolt", Type: "electric", Power: 90, Category: domain.CategorySpecial, Accuracy: 100},
+		},
+	}
+	assert.NoError(t, repo.Create(battle))
+	assert.NotZero(t, battle.ID)
+
+	found, err := repo.GetByID(battle.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), found.Seed)
+	assert.Equal(t, domain.BattleWinnerTeamA, found.Winner)
+	assert.Equal(t, battle.Log, found.Log)
+	assert.Equal(t, battle.Moves, found.Moves)
+}
+
+func TestBattleRepository_GetByID_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&BattleRepository{db: db}).Migrate())
+	repo := NewBattleRepository(db)
+
+	battle, err := repo.GetByID(999)
+	assert.Error(t, err)
+	assert.Nil(t, battle)
+	assert.Equal(t, "battle not found", err.Error())
+}
+
+func TestBattleRepository_TenantIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&BattleRepository{db: db}).Migrate())
+	kanto := NewBattleRepository(db).ForTenant("kanto")
+
+	battle := &domain.Battle{TeamAID: 1, TeamBID: 2, Format: domain.BattleFormat1v1, Level: 50}
+	assert.NoError(t, kanto.Create(battle))
+
+	_, err := NewBattleRepository(db).ForTenant("johto").GetByID(battle.ID)
+	assert.EqualError(t, err, "battle not found")
+	_, err = kanto.GetByID(battle.ID)
+	assert.NoError(t, err)
+}
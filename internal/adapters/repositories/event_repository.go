@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventRepository struct {
+	db *gorm.DB
+}
+
+func NewEventRepository(db *gorm.DB) ports.EventRepository {
+	return &EventRepository{db: db}
+}
+
+func (r *EventRepository) Append(event *domain.Event) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("event already logged")
+	}
+	return nil
+}
+
+func (r *EventRepository) ListAfter(tenantID string, afterID uint, types []string, limit int) ([]*domain.Event, error) {
+	query := r.db.Scopes(tenantScope(tenantID)).Where("id > ?", afterID)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	var events []*domain.Event
+	if err := query.Order("id").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *EventRepository) DeleteThrough(id uint) error {
+	return r.db.Where("id <= ?", id).Delete(&domain.Event{}).Error
+}
+
+func (r *EventRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Event{})
+}
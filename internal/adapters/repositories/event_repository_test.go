@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventRepository_AppendAndListAfter(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&EventRepository{db: db}).Migrate())
+	repo := NewEventRepository(db)
+
+	pikachu := &domain.Pokemon{ID: 1, Name: "pikachu", Type1: "electric"}
+	for i, eventType := range []string{domain.EventPokemonCreated, domain.EventPokemonUpdated, domain.EventPokemonDeleted, domain.EventPokemonCreated} {
+		event := &domain.Event{DedupID: fmt.Sprintf("event-%d", i), Type: eventType, PokemonID: pikachu.ID, Pokemon: pikachu}
+		assert.NoError(t, repo.Append(event))
+		assert.NotZero(t, event.ID)
+	}
+	assert.EqualError(t, repo.Append(&domain.Event{DedupID: "event-0", Type: domain.EventPokemonCreated}), "event already logged")
+
+	events, err := repo.ListAfter(domain.DefaultTenantID, 1, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, uint(2), events[0].ID)
+	assert.Equal(t, "pikachu", events[0].Pokemon.Name)
+
+	events, err = repo.ListAfter(domain.DefaultTenantID, 0, []string{domain.EventPokemonCreated}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, []uint{1, 4}, []uint{events[0].ID, events[1].ID})
+
+	events, err = repo.ListAfter(domain.DefaultTenantID, 0, nil, 2)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestEventRepository_DeleteThrough(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&EventRepository{db: db}).Migrate())
+	repo := NewEventRepository(db)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, repo.Append(&domain.Event{DedupID: fmt.Sprintf("event-%d", i), Type: domain.EventPokemonCreated}))
+	}
+
+	assert.NoError(t, repo.DeleteThrough(3))
+
+	events, err := repo.ListAfter(domain.DefaultTenantID, 0, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, uint(4), events[0].ID)
+}
+
+func TestEventRepository_ListAfterIsScopedToTenant(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&EventRepository{db: db}).Migrate())
+	repo := NewEventRepository(db)
+
+	assert.NoError(t, repo.Append(&domain.Event{DedupID: "kanto-1", TenantID: "kanto", Type: domain.EventPokemonCreated}))
+	assert.NoError(t, repo.Append(&domain.Event{DedupID: "johto-1", TenantID: "johto", Type: domain.EventPokemonCreated}))
+
+	events, err := repo.ListAfter("kanto", 0, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "kanto-1", events[0].DedupID)
+
+	events, err = repo.ListAfter(domain.DefaultTenantID, 0, nil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) ports.IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) Create(record *domain.IdempotencyRecord) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("idempotency key already exists")
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Get(scope, key string) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	err := r.db.Where("scope = ? AND key = ?", scope, key).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("idempotency record not found")
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Update(record *domain.IdempotencyRecord) error {
+	return r.db.Model(record).Select("status_code", "content_type", "body").Updates(record).Error
+}
+
+func (r *IdempotencyRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.IdempotencyRecord{}, id).Error
+}
+
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&domain.IdempotencyRecord{}).Error
+}
+
+func (r *IdempotencyRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.IdempotencyRecord{})
+}
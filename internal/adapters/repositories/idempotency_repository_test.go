@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&IdempotencyRepository{db: db}).Migrate())
+	repo := NewIdempotencyRepository(db)
+	now := time.Now()
+
+	record := &domain.IdempotencyRecord{Scope: "api_key:ci", Key: "abc", Fingerprint: "f1", ExpiresAt: now.Add(time.Hour)}
+	assert.NoError(t, repo.Create(record))
+	assert.NotZero(t, record.ID)
+
+	// The same key in the same scope is rejected, but other scopes may use it
+	assert.EqualError(t, repo.Create(&domain.IdempotencyRecord{Scope: "api_key:ci", Key: "abc", Fingerprint: "f2"}), "idempotency key already exists")
+	assert.NoError(t, repo.Create(&domain.IdempotencyRecord{Scope: "jwt:ash", Key: "abc", Fingerprint: "f1", ExpiresAt: now.Add(-time.Minute)}))
+
+	record.StatusCode = 201
+	record.ContentType = "application/json"
+	record.Body = []byte(`{"id":1}`)
+	assert.NoError(t, repo.Update(record))
+
+	found, err := repo.Get("api_key:ci", "abc")
+	assert.NoError(t, err)
+	assert.True(t, found.Completed())
+	assert.Equal(t, []byte(`{"id":1}`), found.Body)
+	assert.Equal(t, "f1", found.Fingerprint)
+
+	_, err = repo.Get("api_key:ci", "unknown")
+	assert.EqualError(t, err, "idempotency record not found")
+
+	assert.NoError(t, repo.DeleteExpired(now))
+	_, err = repo.Get("jwt:ash", "abc")
+	assert.EqualError(t, err, "idempotency record not found")
+	_, err = repo.Get("api_key:ci", "abc")
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.Delete(record.ID))
+	_, err = repo.Get("api_key:ci", "abc")
+	assert.EqualError(t, err, "idempotency record not found")
+}
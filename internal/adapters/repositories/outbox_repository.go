@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) ports.OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) ListPending(limit int) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	if err := r.db.Where("published_at IS NULL").Order("id").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *OutboxRepository) MarkPublished(id uint, at time.Time) error {
+	return r.db.Model(&domain.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   "",
+		"published_at": at,
+	}).Error
+}
+
+func (r *OutboxRepository) MarkFailed(id uint, reason string) error {
+	return r.db.Model(&domain.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": reason,
+	}).Error
+}
+
+func (r *OutboxRepository) DeletePublishedBefore(before time.Time) error {
+	return r.db.Where("published_at < ?", before).Delete(&domain.OutboxMessage{}).Error
+}
+
+func (r *OutboxRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.OutboxMessage{})
+}
+
+// writeOutbox records a change event in tx, so it is committed or rolled back together with the change
+func writeOutbox(tx *gorm.DB, eventType string, pokemon *domain.Pokemon) error {
+	dedupID, err := newDedupID()
+	if err != nil {
+		return err
+	}
+
+	snapshot := *pokemon
+	return tx.Create(&domain.OutboxMessage{
+		DedupID:   dedupID,
+		TenantID:  pokemon.TenantID,
+		Type:      eventType,
+		PokemonID: pokemon.ID,
+		Pokemon:   &snapshot,
+	}).Error
+}
+
+func newDedupID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&OutboxRepository{db: db}).Migrate())
+	repo := NewOutboxRepository(db)
+	pokemonRepo := NewPokemonRepository(db)
+
+	for _, name := range []string{"pikachu", "eevee", "snorlax"} {
+		assert.NoError(t, pokemonRepo.Create(&domain.Pokemon{Name: name, Type1: "normal"}, testChange))
+	}
+
+	pending, err := repo.ListPending(2)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, "pikachu", pending[0].Pokemon.Name)
+
+	publishedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, repo.MarkFailed(pending[0].ID, "connection refused"))
+	assert.NoError(t, repo.MarkFailed(pending[0].ID, "connection refused"))
+	assert.NoError(t, repo.MarkPublished(pending[1].ID, publishedAt))
+
+	pending, err = repo.ListPending(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, 2, pending[0].Attempts)
+	assert.Equal(t, "connection refused", pending[0].LastError)
+	assert.Equal(t, "snorlax", pending[1].Pokemon.Name)
+
+	assert.NoError(t, repo.DeletePublishedBefore(publishedAt))
+	var count int64
+	db.Model(&domain.OutboxMessage{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+
+	assert.NoError(t, repo.DeletePublishedBefore(publishedAt.Add(time.Second)))
+	db.Model(&domain.OutboxMessage{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PokedexRepository struct {
+	db       *gorm.DB
+	tenantID string
+}
+
+func NewPokedexRepository(db *gorm.DB) ports.PokedexRepository {
+	return &PokedexRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *PokedexRepository) ForTenant(tenantID string) ports.PokedexRepository {
+	return &PokedexRepository{db: r.db, tenantID: tenantID}
+}
+
+func (r *PokedexRepository) ListFlags(trainerID uint) ([]*domain.PokedexFlag, error) {
+	var flags []*domain.PokedexFlag
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("trainer_id = ?", trainerID).Order("dex_number").Find(&flags).Error
+	if err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// SaveFlag inserts the flag or overwrites the trainer's existing flag for the same species
+func (r *PokedexRepository) SaveFlag(flag *domain.PokedexFlag) error {
+	flag.TenantID = r.tenantID
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "trainer_id"}, {Name: "dex_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"seen", "caught", "updated_at"}),
+	}).Create(flag).Error
+}
+
+func (r *PokedexRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.PokedexFlag{})
+}
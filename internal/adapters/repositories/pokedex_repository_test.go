@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPokedexRepository_SaveAndListFlags(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&PokedexRepository{db: db}).Migrate())
+	repo := NewPokedexRepository(db)
+
+	assert.NoError(t, repo.SaveFlag(&domain.PokedexFlag{TrainerID: 1, DexNumber: 25, Seen: true}))
+	assert.NoError(t, repo.SaveFlag(&domain.PokedexFlag{TrainerID: 1, DexNumber: 4, Seen: true, Caught: true}))
+	assert.NoError(t, repo.SaveFlag(&domain.PokedexFlag{TrainerID: 2, DexNumber: 25, Seen: true, Caught: true}))
+
+	// Saving the same species again overwrites the trainer's flag
+	assert.NoError(t, repo.SaveFlag(&domain.PokedexFlag{TrainerID: 1, DexNumber: 25, Seen: true, Caught: true}))
+
+	flags, err := repo.ListFlags(1)
+	assert.NoError(t, err)
+	assert.Len(t, flags, 2)
+	assert.Equal(t, 4, flags[0].DexNumber)
+	assert.Equal(t, 25, flags[1].DexNumber)
+	assert.True(t, flags[1].Caught)
+
+	flags, err = repo.ListFlags(3)
+	assert.NoError(t, err)
+	assert.Empty(t, flags)
+}
+
+func TestPokedexRepository_TenantIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&PokedexRepository{db: db}).Migrate())
+	kanto := NewPokedexRepository(db).ForTenant("kanto")
+
+	assert.NoError(t, kanto.SaveFlag(&domain.PokedexFlag{TrainerID: 1, DexNumber: 25, Seen: true}))
+
+	flags, err := NewPokedexRepository(db).ForTenant("johto").ListFlags(1)
+	assert.NoError(t, err)
+	assert.Empty(t, flags)
+	flags, err = kanto.ListFlags(1)
+	assert.NoError(t, err)
+	assert.Len(t, flags, 1)
+}
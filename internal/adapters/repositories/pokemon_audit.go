@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// writeAudit appends a history entry for a change in the change's transaction; before and after are copied
+func writeAudit(tx *gorm.DB, action string, before, after *domain.Pokemon, by domain.Attribution) error {
+	entry := &domain.PokemonAudit{
+		Action:    action,
+		Actor:     by.Actor,
+		RequestID: by.RequestID,
+		Source:    by.Source,
+	}
+	if before != nil {
+		snapshot := *before
+		entry.Before = &snapshot
+		entry.PokemonID = before.ID
+		entry.TenantID = before.TenantID
+	}
+	if after != nil {
+		snapshot := *after
+		entry.After = &snapshot
+		entry.PokemonID = after.ID
+		entry.TenantID = after.TenantID
+	}
+	return tx.Create(entry).Error
+}
+
+func (r *PokemonRepository) History(id uint) ([]*domain.PokemonAudit, error) {
+	var entries []*domain.PokemonAudit
+	if err := r.db.Scopes(tenantScope(r.tenantID)).Where("pokemon_id = ?", id).Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *PokemonRepository) AsOf(id uint, at time.Time) (*domain.Pokemon, error) {
+	var entry domain.PokemonAudit
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("pokemon_id = ? AND created_at <= ?", id, at).Order("created_at DESC, id DESC").First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("pokemon not found")
+		}
+		return nil, err
+	}
+	if entry.After == nil {
+		return nil, errors.New("pokemon not found")
+	}
+	return entry.After, nil
+}
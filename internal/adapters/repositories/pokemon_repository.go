@@ -4,25 +4,44 @@ import (
 	"errors"
 	"pokemon-api/internal/core/domain"
 	"pokemon-api/internal/core/ports"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// exportBatchSize is how many Pokemon Each loads per query
+const exportBatchSize = 500
+
 type PokemonRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID string
 }
 
 func NewPokemonRepository(db *gorm.DB) ports.PokemonRepository {
-	return &PokemonRepository{db: db}
+	return &PokemonRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *PokemonRepository) ForTenant(tenantID string) ports.PokemonRepository {
+	return &PokemonRepository{db: r.db, tenantID: tenantID}
 }
 
-func (r *PokemonRepository) Create(pokemon *domain.Pokemon) error {
-	return r.db.Create(pokemon).Error
+func (r *PokemonRepository) Create(pokemon *domain.Pokemon, by domain.Attribution) error {
+	pokemon.TenantID = r.tenantID
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(pokemon).Error; err != nil {
+			return err
+		}
+		if err := writeAudit(tx, domain.AuditActionCreated, nil, pokemon, by); err != nil {
+			return err
+		}
+		return writeOutbox(tx, domain.EventPokemonCreated, pokemon)
+	})
 }
 
 func (r *PokemonRepository) GetByID(id uint) (*domain.Pokemon, error) {
 	var pokemon domain.Pokemon
-	err := r.db.First(&pokemon, id).Error
+	err := r.db.Scopes(tenantScope(r.tenantID)).First(&pokemon, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("pokemon not found")
@@ -34,7 +53,19 @@ func (r *PokemonRepository) GetByID(id uint) (*domain.Pokemon, error) {
 
 func (r *PokemonRepository) GetByName(name string) (*domain.Pokemon, error) {
 	var pokemon domain.Pokemon
-	err := r.db.Where("name = ?", name).First(&pokemon).Error
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("name = ?", name).First(&pokemon).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("pokemon not found")
+		}
+		return nil, err
+	}
+	return &pokemon, nil
+}
+
+func (r *PokemonRepository) GetByDexNumber(number int) (*domain.Pokemon, error) {
+	var pokemon domain.Pokemon
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("dex_number = ?", number).Order("id").First(&pokemon).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("pokemon not found")
@@ -46,28 +77,278 @@ func (r *PokemonRepository) GetByName(name string) (*domain.Pokemon, error) {
 
 func (r *PokemonRepository) List() ([]*domain.Pokemon, error) {
 	var pokemon []*domain.Pokemon
-	err := r.db.Find(&pokemon).Error
+	err := r.db.Scopes(tenantScope(r.tenantID)).Find(&pokemon).Error
+	if err != nil {
+		return nil, err
+	}
+	return pokemon, nil
+}
+
+func (r *PokemonRepository) ListPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error) {
+	var total int64
+	if err := r.db.Model(&domain.Pokemon{}).Scopes(tenantScope(r.tenantID), pageFilter(query)).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	page := r.db.Scopes(tenantScope(r.tenantID), pageFilter(query)).Where("id > ?", query.After).Order("id")
+	if query.Limit > 0 {
+		// One row past the page tells whether there is another
+		page = page.Limit(query.Limit + 1)
+	}
+	var pokemon []*domain.Pokemon
+	if err := page.Find(&pokemon).Error; err != nil {
+		return nil, err
+	}
+
+	hasMore := query.Limit > 0 && len(pokemon) > query.Limit
+	if hasMore {
+		pokemon = pokemon[:query.Limit]
+	}
+	return &domain.PokemonPage{Pokemon: pokemon, Total: int(total), HasMore: hasMore}, nil
+}
+
+// pageFilter applies a PokemonPageQuery's filters, leaving its cursor and limit to the caller
+func pageFilter(query domain.PokemonPageQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(query.Types) > 0 {
+			db = db.Where("(type1 IN ? OR type2 IN ?)", query.Types, query.Types)
+		}
+		if query.NameContains != "" {
+			db = db.Where(`name LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(query.NameContains)+"%")
+		}
+		if query.CreatedBy != "" {
+			db = db.Where("created_by = ?", query.CreatedBy)
+		}
+		if query.MinBaseStatTotal > 0 {
+			db = db.Where("hp + attack + defense + sp_attack + sp_defense + speed >= ?", query.MinBaseStatTotal)
+		}
+		return db
+	}
+}
+
+// likeEscaper makes LIKE match its pattern's wildcards literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *PokemonRepository) Update(pokemon *domain.Pokemon, expected *time.Time, by domain.Attribution) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var before domain.Pokemon
+		if err := tx.Scopes(tenantScope(r.tenantID)).First(&before, pokemon.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("pokemon not found")
+			}
+			return err
+		}
+
+		pokemon.TenantID = r.tenantID
+		result := tx.Model(pokemon).Scopes(versionScope(expected)).Select("*").Omit("id", "tenant_id", "created_at", "deleted_at").Updates(pokemon)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return notWritten(r.row(tx, pokemon.ID), expected, "pokemon")
+		}
+		if err := writeAudit(tx, domain.AuditActionUpdated, &before, pokemon, by); err != nil {
+			return err
+		}
+		return writeOutbox(tx, domain.EventPokemonUpdated, pokemon)
+	})
+}
+
+func (r *PokemonRepository) Delete(id uint, expected *time.Time, by domain.Attribution) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var pokemon domain.Pokemon
+		if err := tx.Scopes(tenantScope(r.tenantID)).First(&pokemon, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("pokemon not found")
+			}
+			return err
+		}
+		result := tx.Scopes(versionScope(expected)).Delete(&pokemon)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return notWritten(r.row(tx, id), expected, "pokemon")
+		}
+		if err := writeAudit(tx, domain.AuditActionDeleted, &pokemon, nil, by); err != nil {
+			return err
+		}
+		return writeOutbox(tx, domain.EventPokemonDeleted, &pokemon)
+	})
+}
+
+// row finds the tenant's live Pokemon with the ID
+func (r *PokemonRepository) row(tx *gorm.DB, id uint) *gorm.DB {
+	return tx.Model(&domain.Pokemon{}).Scopes(tenantScope(r.tenantID)).Where("id = ?", id)
+}
+
+func (r *PokemonRepository) ListDeleted() ([]*domain.Pokemon, error) {
+	var pokemon []*domain.Pokemon
+	err := r.db.Unscoped().Scopes(tenantScope(r.tenantID)).Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Find(&pokemon).Error
 	if err != nil {
 		return nil, err
 	}
 	return pokemon, nil
 }
 
+func (r *PokemonRepository) Restore(id uint, by domain.Attribution) (*domain.Pokemon, error) {
+	var pokemon domain.Pokemon
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Scopes(tenantScope(r.tenantID)).Where("deleted_at IS NOT NULL").First(&pokemon, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("pokemon not found in trash")
+			}
+			return err
+		}
+
+		var live int64
+		if err := tx.Model(&domain.Pokemon{}).Scopes(tenantScope(r.tenantID)).Where("name = ?", pokemon.Name).Count(&live).Error; err != nil {
+			return err
+		}
+		if live > 0 {
+			return errors.New("pokemon with this name already exists")
+		}
+
+		deleted := pokemon
+		if err := tx.Unscoped().Model(&pokemon).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		pokemon.DeletedAt = gorm.DeletedAt{}
+		if err := writeAudit(tx, domain.AuditActionRestored, &deleted, &pokemon, by); err != nil {
+			return err
+		}
+		return writeOutbox(tx, domain.EventPokemonRestored, &pokemon)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pokemon, nil
+}
+
+// PurgeDeleted runs for every tenant at once; it keeps Pokemon that teams or trainers still refer to, so they can
+// be restored later. Each purged Pokemon gets a last history entry in the same transaction.
+func (r *PokemonRepository) PurgeDeleted(before time.Time, by domain.Attribution) (int, error) {
+	purged := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var expired []*domain.Pokemon
+		if err := tx.Unscoped().Scopes(purgeable(tx, before)).Order("id").Find(&expired).Error; err != nil {
+			return err
+		}
+
+		for _, pokemon := range expired {
+			// Checked again, so a Pokemon restored or added to a team since it was read is kept
+			result := tx.Unscoped().Scopes(purgeable(tx, before)).Where("id = ?", pokemon.ID).Delete(&domain.Pokemon{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			if err := writeAudit(tx, domain.AuditActionPurged, pokemon, nil, by); err != nil {
+				return err
+			}
+			purged++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// purgeable finds trashed Pokemon deleted before the given time that no team member or owned Pokemon refers to
+func purgeable(tx *gorm.DB, before time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at < ?", before).
+			Where("id NOT IN (?)", tx.Model(&domain.TeamMember{}).Select("pokemon_id")).
+			Where("id NOT IN (?)", tx.Model(&domain.OwnedPokemon{}).Select("species_id"))
+	}
+}
+
+func (r *PokemonRepository) Each(fn func(*domain.Pokemon) error) error {
+	var batch []*domain.Pokemon
+	return r.db.Scopes(tenantScope(r.tenantID)).Order("id").FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+		for _, pokemon := range batch {
+			if err := fn(pokemon); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
 func (r *PokemonRepository) Migrate() error {
+	if err := r.db.AutoMigrate(&domain.PokemonAudit{}); err != nil {
+		return err
+	}
+	// Names used to be unique across every tenant; idx_pokemons_tenant_name_live replaces this index
+	if err := r.db.Exec(`DROP INDEX IF EXISTS idx_pokemons_name_live`).Error; err != nil {
+		return err
+	}
+	if r.db.Dialector.Name() != "postgres" {
+		return r.migrateTable()
+	}
+
+	// Names used to be unique across every row; tables created by the fallback below have this constraint
+	if err := r.db.Exec(`ALTER TABLE IF EXISTS pokemons DROP CONSTRAINT IF EXISTS pokemons_name_key`).Error; err != nil {
+		return err
+	}
+	if err := r.migrateTable(); err != nil {
+		return err
+	}
+
+	// pg_trgm is a trusted extension, so the database owner can create it
+	for _, statement := range []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE INDEX IF NOT EXISTS idx_pokemons_search ON pokemons USING GIN ((` + searchDocument + `))`,
+		`CREATE INDEX IF NOT EXISTS idx_pokemons_name_trgm ON pokemons USING GIN (name gin_trgm_ops)`,
+	} {
+		if err := r.db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PokemonRepository) migrateTable() error {
 	if err := r.db.AutoMigrate(&domain.Pokemon{}); err != nil {
-		return r.db.Exec(`
+		for _, statement := range []string{`
 			CREATE TABLE IF NOT EXISTS pokemons (
 				id SERIAL PRIMARY KEY,
-				name VARCHAR(255) UNIQUE NOT NULL,
+				tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+				dex_number INTEGER DEFAULT 0,
+				name VARCHAR(255) NOT NULL,
 				type1 VARCHAR(255),
 				type2 VARCHAR(255),
 				height INTEGER DEFAULT 0,
 				weight INTEGER DEFAULT 0,
 				base_exp INTEGER DEFAULT 0,
+				hp INTEGER DEFAULT 0,
+				attack INTEGER DEFAULT 0,
+				defense INTEGER DEFAULT 0,
+				sp_attack INTEGER DEFAULT 0,
+				sp_defense INTEGER DEFAULT 0,
+				speed INTEGER DEFAULT 0,
+				abilities TEXT,
+				flavor_text TEXT,
+				generation INTEGER DEFAULT 0,
+				sprite_default TEXT,
+				sprite_shiny TEXT,
+				sprite_artwork TEXT,
+				translations TEXT,
+				created_by VARCHAR(255),
 				created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-				updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-			)
-		`).Error
+				updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+				deleted_at TIMESTAMP WITH TIME ZONE
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_pokemons_tenant_name_live ON pokemons (tenant_id, name) WHERE deleted_at IS NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_pokemons_deleted_at ON pokemons (deleted_at)`,
+		} {
+			if err := r.db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -1,8 +1,11 @@
 package repositories
 
 import (
+	"errors"
+	"fmt"
 	"pokemon-api/internal/core/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
@@ -13,12 +16,15 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	err = db.AutoMigrate(&domain.Pokemon{})
+	err = db.AutoMigrate(&domain.Pokemon{}, &domain.OutboxMessage{}, &domain.PokemonAudit{})
 	assert.NoError(t, err)
 
 	return db
 }
 
+// testChange attributes the changes tests make
+var testChange = domain.Attribution{Actor: "ash", RequestID: "req-1", Source: domain.AuditSourceAPI}
+
 func TestPokemonRepository_Create(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewPokemonRepository(db)
@@ -32,7 +38,7 @@ func TestPokemonRepository_Create(t *testing.T) {
 		BaseExp: 112,
 	}
 
-	err := repo.Create(pokemon)
+	err := repo.Create(pokemon, testChange)
 	assert.NoError(t, err)
 	assert.NotZero(t, pokemon.ID)
 
@@ -54,10 +60,10 @@ func TestPokemonRepository_Create_Duplicate(t *testing.T) {
 		Type1: "electric",
 	}
 
-	err := repo.Create(pokemon1)
+	err := repo.Create(pokemon1, testChange)
 	assert.NoError(t, err)
 
-	err = repo.Create(pokemon2)
+	err = repo.Create(pokemon2, testChange)
 	assert.Error(t, err)
 }
 
@@ -72,9 +78,10 @@ func TestPokemonRepository_GetByID(t *testing.T) {
 		Height:  17,
 		Weight:  905,
 		BaseExp: 267,
+		Sprites: domain.PokemonSprites{Default: "https://sprites.example/6.png", Shiny: "https://sprites.example/shiny/6.png"},
 	}
 
-	err := repo.Create(original)
+	err := repo.Create(original, testChange)
 	assert.NoError(t, err)
 
 	found, err := repo.GetByID(original.ID)
@@ -85,6 +92,7 @@ func TestPokemonRepository_GetByID(t *testing.T) {
 	assert.Equal(t, original.Height, found.Height)
 	assert.Equal(t, original.Weight, found.Weight)
 	assert.Equal(t, original.BaseExp, found.BaseExp)
+	assert.Equal(t, original.Sprites, found.Sprites)
 }
 
 func TestPokemonRepository_GetByID_NotFound(t *testing.T) {
@@ -109,7 +117,7 @@ func TestPokemonRepository_GetByName(t *testing.T) {
 		BaseExp: 63,
 	}
 
-	err := repo.Create(original)
+	err := repo.Create(original, testChange)
 	assert.NoError(t, err)
 
 	found, err := repo.GetByName("squirtle")
@@ -139,11 +147,11 @@ func TestPokemonRepository_List(t *testing.T) {
 	pokemon2 := &domain.Pokemon{Name: "charizard", Type1: "fire"}
 	pokemon3 := &domain.Pokemon{Name: "squirtle", Type1: "water"}
 
-	err := repo.Create(pokemon1)
+	err := repo.Create(pokemon1, testChange)
 	assert.NoError(t, err)
-	err = repo.Create(pokemon2)
+	err = repo.Create(pokemon2, testChange)
 	assert.NoError(t, err)
-	err = repo.Create(pokemon3)
+	err = repo.Create(pokemon3, testChange)
 	assert.NoError(t, err)
 
 	list, err := repo.List()
@@ -168,6 +176,360 @@ func TestPokemonRepository_List_Empty(t *testing.T) {
 	assert.Empty(t, list)
 }
 
+func TestPokemonRepository_ListPage(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+	for _, pokemon := range []*domain.Pokemon{
+		{Name: "charmander", Type1: "fire", HP: 39, Attack: 52, Defense: 43, SpAttack: 60, SpDefense: 50, Speed: 65},
+		{Name: "pikachu", Type1: "electric", HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90, CreatedBy: "ash"},
+		{Name: "charizard", Type1: "fire", Type2: "flying", HP: 78, Attack: 84, Defense: 78, SpAttack: 109, SpDefense: 85, Speed: 100},
+		{Name: "pidgey", Type1: "normal", Type2: "flying", HP: 40, Attack: 45, Defense: 40, SpAttack: 35, SpDefense: 35, Speed: 56},
+		{Name: "mr_mime", Type1: "psychic", Type2: "fairy"},
+		{Name: "rattata", Type1: "normal"},
+	} {
+		assert.NoError(t, repo.Create(pokemon, testChange))
+	}
+	other := &domain.Pokemon{Name: "charmeleon", Type1: "fire"}
+	assert.NoError(t, repo.ForTenant("kanto").Create(other, testChange))
+	assert.NoError(t, repo.Delete(6, nil, testChange))
+
+	tests := []struct {
+		name            string
+		query           domain.PokemonPageQuery
+		expectedNames   []string
+		expectedTotal   int
+		expectedHasMore bool
+	}{
+		{name: "first page", query: domain.PokemonPageQuery{Limit: 2}, expectedNames: []string{"charmander", "pikachu"}, expectedTotal: 5, expectedHasMore: true},
+		{name: "after a cursor", query: domain.PokemonPageQuery{After: 2, Limit: 2}, expectedNames: []string{"charizard", "pidgey"}, expectedTotal: 5, expectedHasMore: true},
+		{name: "without a limit", query: domain.PokemonPageQuery{After: 1}, expectedNames: []string{"pikachu", "charizard", "pidgey", "mr_mime"}, expectedTotal: 5},
+		{name: "either type", query: domain.PokemonPageQuery{Types: []string{"flying"}}, expectedNames: []string{"charizard", "pidgey"}, expectedTotal: 2},
+		{name: "any of several types", query: domain.PokemonPageQuery{Types: []string{"electric", "normal"}, Limit: 1}, expectedNames: []string{"pikachu"}, expectedTotal: 2, expectedHasMore: true},
+		{name: "name contains", query: domain.PokemonPageQuery{NameContains: "char"}, expectedNames: []string{"charmander", "charizard"}, expectedTotal: 2},
+		{name: "creator", query: domain.PokemonPageQuery{CreatedBy: "ash"}, expectedNames: []string{"pikachu"}, expectedTotal: 1},
+		{name: "base stat total", query: domain.PokemonPageQuery{Types: []string{"fire"}, MinBaseStatTotal: 500}, expectedNames: []string{"charizard"}, expectedTotal: 1},
+		{name: "like wildcards match literally", query: domain.PokemonPageQuery{NameContains: "_"}, expectedNames: []string{"mr_mime"}, expectedTotal: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.ListPage(tt.query)
+
+			assert.NoError(t, err)
+			names := []string{}
+			for _, pokemon := range page.Pokemon {
+				names = append(names, pokemon.Name)
+			}
+			assert.Equal(t, tt.expectedNames, names)
+			assert.Equal(t, tt.expectedTotal, page.Total)
+			assert.Equal(t, tt.expectedHasMore, page.HasMore)
+		})
+	}
+}
+
+func TestPokemonRepository_Update(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{Name: "rotom", Type1: "electric", Type2: "ghost", HP: 50}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+
+	pokemon.Type2 = ""
+	assert.NoError(t, repo.Update(pokemon, nil, testChange))
+
+	stored, err := repo.GetByID(pokemon.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", stored.Type2)
+	assert.Equal(t, 50, stored.HP)
+
+	err = repo.Update(&domain.Pokemon{ID: 999, Name: "missingno", Type1: "normal"}, nil, testChange)
+	assert.EqualError(t, err, "pokemon not found")
+}
+
+func TestPokemonRepository_Delete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{Name: "pikachu", Type1: "electric"}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+
+	assert.NoError(t, repo.Delete(pokemon.ID, nil, testChange))
+	_, err := repo.GetByID(pokemon.ID)
+	assert.EqualError(t, err, "pokemon not found")
+
+	assert.EqualError(t, repo.Delete(pokemon.ID, nil, testChange), "pokemon not found")
+}
+
+func TestPokemonRepository_ConditionalWrites(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{Name: "rotom", Type1: "electric", Type2: "ghost"}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+	read, err := repo.GetByID(pokemon.ID)
+	assert.NoError(t, err)
+	version := read.UpdatedAt
+
+	read.Type2 = "fire"
+	assert.NoError(t, repo.Update(read, &version, testChange))
+
+	// A second writer that read the same version loses
+	stale := &domain.Pokemon{ID: pokemon.ID, Name: "rotom", Type1: "electric", Type2: "water"}
+	var modified *domain.ModifiedError
+	assert.ErrorAs(t, repo.Update(stale, &version, testChange), &modified)
+	assert.ErrorAs(t, repo.Delete(pokemon.ID, &version, testChange), &modified)
+
+	stored, err := repo.GetByID(pokemon.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "fire", stored.Type2)
+	history, err := repo.History(pokemon.ID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	assert.NoError(t, repo.Delete(pokemon.ID, &stored.UpdatedAt, testChange))
+	assert.EqualError(t, repo.Delete(pokemon.ID, &stored.UpdatedAt, testChange), "pokemon not found")
+}
+
+func TestPokemonRepository_TrashAndRestore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	deleted := &domain.Pokemon{Name: "pikachu", Type1: "electric"}
+	assert.NoError(t, repo.Create(deleted, testChange))
+	assert.NoError(t, repo.Delete(deleted.ID, nil, testChange))
+
+	trash, err := repo.ListDeleted()
+	assert.NoError(t, err)
+	assert.Len(t, trash, 1)
+	assert.True(t, trash[0].DeletedAt.Valid)
+	live, err := repo.List()
+	assert.NoError(t, err)
+	assert.Empty(t, live)
+
+	// The name is free again, so restoring the deleted Pokemon conflicts with the new one
+	replacement := &domain.Pokemon{Name: "pikachu", Type1: "electric"}
+	assert.NoError(t, repo.Create(replacement, testChange))
+	_, err = repo.Restore(deleted.ID, testChange)
+	assert.EqualError(t, err, "pokemon with this name already exists")
+
+	assert.NoError(t, repo.Delete(replacement.ID, nil, testChange))
+	restored, err := repo.Restore(deleted.ID, testChange)
+	assert.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	stored, err := repo.GetByName("pikachu")
+	assert.NoError(t, err)
+	assert.Equal(t, deleted.ID, stored.ID)
+
+	_, err = repo.Restore(deleted.ID, testChange)
+	assert.EqualError(t, err, "pokemon not found in trash")
+
+	var message domain.OutboxMessage
+	assert.NoError(t, db.Order("id DESC").First(&message).Error)
+	assert.Equal(t, domain.EventPokemonRestored, message.Type)
+	assert.Equal(t, deleted.ID, message.PokemonID)
+}
+
+func TestPokemonRepository_PurgeDeleted(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.Team{}, &domain.TeamMember{}, &domain.OwnedPokemon{}))
+	repo := NewPokemonRepository(db)
+
+	var pokemon []*domain.Pokemon
+	for _, name := range []string{"pikachu", "raichu", "eevee", "snorlax"} {
+		p := &domain.Pokemon{Name: name, Type1: "normal"}
+		assert.NoError(t, repo.Create(p, testChange))
+		pokemon = append(pokemon, p)
+	}
+	assert.NoError(t, db.Create(&domain.TeamMember{TeamID: 1, Slot: 1, PokemonID: pokemon[2].ID}).Error)
+
+	// pikachu and eevee were deleted long ago, raichu recently; snorlax is live
+	for _, p := range pokemon[:3] {
+		assert.NoError(t, repo.Delete(p.ID, nil, testChange))
+	}
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	assert.NoError(t, db.Unscoped().Model(&domain.Pokemon{}).Where("id IN ?", []uint{pokemon[0].ID, pokemon[2].ID}).Update("deleted_at", old).Error)
+
+	purged, err := repo.PurgeDeleted(time.Now().Add(-30*24*time.Hour), domain.Attribution{Source: domain.AuditSourceJob})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	// pikachu's history ends with the purge, which holds the record as it was purged
+	history, err := repo.History(pokemon[0].ID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	last := history[2]
+	assert.Equal(t, domain.AuditActionPurged, last.Action)
+	assert.Equal(t, domain.AuditSourceJob, last.Source)
+	assert.Equal(t, "pikachu", last.Before.Name)
+	assert.Nil(t, last.After)
+	history, err = repo.History(pokemon[2].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AuditActionDeleted, history[len(history)-1].Action)
+
+	trash, err := repo.ListDeleted()
+	assert.NoError(t, err)
+	var names []string
+	for _, p := range trash {
+		names = append(names, p.Name)
+	}
+	// eevee stays in the trash because a team still uses it
+	assert.ElementsMatch(t, []string{"raichu", "eevee"}, names)
+	_, err = repo.GetByName("snorlax")
+	assert.NoError(t, err)
+}
+
+func TestPokemonRepository_History(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{Name: "rotom", Type1: "electric", Type2: "ghost"}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+	pokemon.Type2 = "fire"
+	assert.NoError(t, repo.Update(pokemon, nil, domain.Attribution{Actor: "misty", RequestID: "req-2", Source: domain.AuditSourceAPI}))
+	assert.NoError(t, repo.Delete(pokemon.ID, nil, testChange))
+	_, err := repo.Restore(pokemon.ID, testChange)
+	assert.NoError(t, err)
+	// A failed change writes no history
+	assert.Error(t, repo.Create(&domain.Pokemon{Name: "rotom", Type1: "electric"}, testChange))
+
+	entries, err := repo.History(pokemon.ID)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+	assert.Equal(t, domain.AuditActionCreated, entries[0].Action)
+	assert.Nil(t, entries[0].Before)
+	assert.Equal(t, "ghost", entries[0].After.Type2)
+	assert.Equal(t, domain.AuditActionUpdated, entries[1].Action)
+	assert.Equal(t, "ghost", entries[1].Before.Type2)
+	assert.Equal(t, "fire", entries[1].After.Type2)
+	assert.Equal(t, "misty", entries[1].Actor)
+	assert.Equal(t, "req-2", entries[1].RequestID)
+	assert.Equal(t, domain.AuditSourceAPI, entries[1].Source)
+	assert.Equal(t, domain.AuditActionDeleted, entries[2].Action)
+	assert.Nil(t, entries[2].After)
+	assert.Equal(t, domain.AuditActionRestored, entries[3].Action)
+	// Snapshots leave out deleted_at; the restored entry's action says the Pokemon was in the trash
+	assert.Equal(t, "fire", entries[3].Before.Type2)
+	assert.Equal(t, "fire", entries[3].After.Type2)
+	for _, entry := range entries {
+		assert.Equal(t, pokemon.ID, entry.PokemonID)
+	}
+
+	var count int64
+	db.Model(&domain.PokemonAudit{}).Count(&count)
+	assert.Equal(t, int64(4), count)
+}
+
+func TestPokemonRepository_AsOf(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{Name: "rotom", Type1: "electric", Type2: "ghost"}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+	pokemon.Type2 = "fire"
+	assert.NoError(t, repo.Update(pokemon, nil, testChange))
+	assert.NoError(t, repo.Delete(pokemon.ID, nil, testChange))
+
+	// Spread the entries a day apart so each time below falls between two of them
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries, err := repo.History(pokemon.ID)
+	assert.NoError(t, err)
+	for i, entry := range entries {
+		assert.NoError(t, db.Model(entry).Update("created_at", start.Add(time.Duration(i)*24*time.Hour)).Error)
+	}
+
+	_, err = repo.AsOf(pokemon.ID, start.Add(-time.Hour))
+	assert.EqualError(t, err, "pokemon not found")
+
+	created, err := repo.AsOf(pokemon.ID, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "ghost", created.Type2)
+
+	updated, err := repo.AsOf(pokemon.ID, start.Add(25*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "fire", updated.Type2)
+
+	_, err = repo.AsOf(pokemon.ID, start.Add(49*time.Hour))
+	assert.EqualError(t, err, "pokemon not found")
+}
+
+func TestPokemonRepository_WritesOutbox(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{Name: "rotom", Type1: "electric", Type2: "ghost"}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+	pokemon.Type2 = "fire"
+	assert.NoError(t, repo.Update(pokemon, nil, testChange))
+	assert.NoError(t, repo.Delete(pokemon.ID, nil, testChange))
+
+	var messages []*domain.OutboxMessage
+	assert.NoError(t, db.Order("id").Find(&messages).Error)
+	assert.Len(t, messages, 3)
+	assert.Equal(t, domain.EventPokemonCreated, messages[0].Type)
+	assert.Equal(t, "ghost", messages[0].Pokemon.Type2)
+	assert.Equal(t, domain.EventPokemonUpdated, messages[1].Type)
+	assert.Equal(t, domain.EventPokemonDeleted, messages[2].Type)
+	assert.Equal(t, "fire", messages[2].Pokemon.Type2)
+	for _, message := range messages {
+		assert.Equal(t, pokemon.ID, message.PokemonID)
+		assert.Len(t, message.DedupID, 32)
+	}
+	assert.NotEqual(t, messages[0].DedupID, messages[1].DedupID)
+
+	// A failed change writes no event
+	assert.NoError(t, repo.Create(&domain.Pokemon{Name: "pikachu", Type1: "electric"}, testChange))
+	assert.Error(t, repo.Create(&domain.Pokemon{Name: "pikachu", Type1: "electric"}, testChange))
+	assert.EqualError(t, repo.Delete(pokemon.ID, nil, testChange), "pokemon not found")
+	var count int64
+	db.Model(&domain.OutboxMessage{}).Count(&count)
+	assert.Equal(t, int64(4), count)
+}
+
+func TestPokemonRepository_Create_RolledBackWithOutbox(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+	assert.NoError(t, db.Migrator().DropTable(&domain.OutboxMessage{}))
+
+	assert.Error(t, repo.Create(&domain.Pokemon{Name: "pikachu", Type1: "electric"}, testChange))
+
+	var count int64
+	db.Model(&domain.Pokemon{}).Count(&count)
+	assert.Zero(t, count, "the Pokemon is not stored without its event")
+}
+
+func TestPokemonRepository_Each(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	for i := 0; i < exportBatchSize+2; i++ {
+		assert.NoError(t, repo.Create(&domain.Pokemon{Name: fmt.Sprintf("pokemon-%d", i), Type1: "normal"}, testChange))
+	}
+
+	var ids []uint
+	err := repo.Each(func(pokemon *domain.Pokemon) error {
+		ids = append(ids, pokemon.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ids, exportBatchSize+2)
+	assert.IsIncreasing(t, ids)
+}
+
+func TestPokemonRepository_Each_StopsOnError(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+	assert.NoError(t, repo.Create(&domain.Pokemon{Name: "pikachu", Type1: "electric"}, testChange))
+	assert.NoError(t, repo.Create(&domain.Pokemon{Name: "eevee", Type1: "normal"}, testChange))
+
+	calls := 0
+	err := repo.Each(func(pokemon *domain.Pokemon) error {
+		calls++
+		return errors.New("client went away")
+	})
+	assert.EqualError(t, err, "client went away")
+	assert.Equal(t, 1, calls)
+}
+
 func TestPokemonRepository_Migrate(t *testing.T) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
@@ -178,3 +540,155 @@ func TestPokemonRepository_Migrate(t *testing.T) {
 
 	assert.True(t, db.Migrator().HasTable(&domain.Pokemon{}))
 }
+
+func TestPokemonRepository_Search(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	for _, pokemon := range []*domain.Pokemon{
+		{Name: "pikachu", Type1: "electric", Abilities: []string{"static", "lightning-rod"}, FlavorText: "It stores electricity in its cheeks."},
+		{Name: "raichu", Type1: "electric", Abilities: []string{"static"}},
+		{Name: "charizard", Type1: "fire", Type2: "flying", Abilities: []string{"blaze"}, FlavorText: "Breathes fire hot enough to melt boulders."},
+		{Name: "mr-mime", Type1: "psychic", Type2: "fairy", Abilities: []string{"soundproof"}},
+	} {
+		assert.NoError(t, repo.Create(pokemon, testChange))
+	}
+
+	names := func(hits []domain.PokemonSearchHit) []string {
+		result := []string{}
+		for _, hit := range hits {
+			result = append(result, hit.Pokemon.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "name outranks other fields", text: "Pikachu", expected: []string{"pikachu"}},
+		{name: "type", text: "electric", expected: []string{"pikachu", "raichu"}},
+		{name: "ability", text: "lightning rod", expected: []string{"pikachu"}},
+		{name: "every word must match", text: "static cheeks", expected: []string{"pikachu"}},
+		{name: "word in several fields counts once", text: "fire", expected: []string{"charizard"}},
+		{name: "misspelt name", text: "charzard", expected: []string{"charizard"}},
+		{name: "display name", text: "Mr. Mime", expected: []string{"mr-mime"}},
+		{name: "no match", text: "dragon", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := repo.Search(tt.text)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, names(hits))
+		})
+	}
+
+	hits, err := repo.Search("static")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pikachu", "raichu"}, names(hits))
+	assert.Equal(t, []string{"static", "lightning-rod"}, hits[0].Pokemon.Abilities)
+	assert.Greater(t, hits[0].Score, 0.0)
+}
+
+func TestPokemonRepository_GetByDexNumber(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	assert.NoError(t, repo.Create(&domain.Pokemon{Name: "charizard-mega-x", DexNumber: 6, Type1: "fire", Type2: "dragon"}, testChange))
+	assert.NoError(t, repo.Create(&domain.Pokemon{Name: "charizard", DexNumber: 6, Type1: "fire", Type2: "flying"}, testChange))
+
+	pokemon, err := repo.GetByDexNumber(6)
+	assert.NoError(t, err)
+	assert.Equal(t, "charizard-mega-x", pokemon.Name)
+
+	_, err = repo.GetByDexNumber(25)
+	assert.EqualError(t, err, "pokemon not found")
+}
+
+func TestPokemonRepository_TenantIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	kanto := NewPokemonRepository(db).ForTenant("kanto")
+	johto := NewPokemonRepository(db).ForTenant("johto")
+
+	// Each tenant can use the same name
+	kantoPikachu := &domain.Pokemon{Name: "pikachu", Type1: "electric", DexNumber: 25}
+	johtoPikachu := &domain.Pokemon{Name: "pikachu", Type1: "electric", DexNumber: 25}
+	assert.NoError(t, kanto.Create(kantoPikachu, testChange))
+	assert.NoError(t, johto.Create(johtoPikachu, testChange))
+	assert.Error(t, kanto.Create(&domain.Pokemon{Name: "pikachu", Type1: "electric"}, testChange))
+	assert.Equal(t, "kanto", kantoPikachu.TenantID)
+
+	_, err := johto.GetByID(kantoPikachu.ID)
+	assert.EqualError(t, err, "pokemon not found")
+	found, err := johto.GetByName("pikachu")
+	assert.NoError(t, err)
+	assert.Equal(t, johtoPikachu.ID, found.ID)
+	found, err = johto.GetByDexNumber(25)
+	assert.NoError(t, err)
+	assert.Equal(t, johtoPikachu.ID, found.ID)
+
+	list, err := kanto.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, kantoPikachu.ID, list[0].ID)
+
+	hits, err := johto.Search("pikachu")
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, johtoPikachu.ID, hits[0].Pokemon.ID)
+
+	var exported []uint
+	assert.NoError(t, kanto.Each(func(pokemon *domain.Pokemon) error {
+		exported = append(exported, pokemon.ID)
+		return nil
+	}))
+	assert.Equal(t, []uint{kantoPikachu.ID}, exported)
+
+	// Another tenant can neither change nor see the history of a Pokemon
+	assert.EqualError(t, johto.Update(&domain.Pokemon{ID: kantoPikachu.ID, Name: "raichu", Type1: "electric"}, nil, testChange), "pokemon not found")
+	assert.EqualError(t, johto.Delete(kantoPikachu.ID, nil, testChange), "pokemon not found")
+	history, err := johto.History(kantoPikachu.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+	_, err = johto.AsOf(kantoPikachu.ID, time.Now())
+	assert.EqualError(t, err, "pokemon not found")
+
+	// Nor restore it from the trash
+	assert.NoError(t, kanto.Delete(kantoPikachu.ID, nil, testChange))
+	trash, err := johto.ListDeleted()
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+	_, err = johto.Restore(kantoPikachu.ID, testChange)
+	assert.EqualError(t, err, "pokemon not found in trash")
+
+	// A live Pokemon of another tenant does not block the restore
+	restored, err := kanto.Restore(kantoPikachu.ID, testChange)
+	assert.NoError(t, err)
+	assert.Equal(t, "kanto", restored.TenantID)
+
+	var message domain.OutboxMessage
+	assert.NoError(t, db.Order("id DESC").First(&message).Error)
+	assert.Equal(t, "kanto", message.TenantID)
+}
+
+func TestPokemonRepository_Translations(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPokemonRepository(db)
+
+	pokemon := &domain.Pokemon{
+		Name:  "pikachu",
+		Type1: "electric",
+		Translations: map[string]domain.PokemonTranslation{
+			"en": {Name: "Pikachu", FlavorText: "It stores electricity."},
+			"ja": {Name: "ピカチュウ", FlavorText: "でんきを ためこむ。"},
+		},
+	}
+	assert.NoError(t, repo.Create(pokemon, testChange))
+
+	stored, err := repo.GetByID(pokemon.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, pokemon.Translations, stored.Translations)
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"database/sql"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/names"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// minNameSimilarity is pg_trgm's default threshold, so a misspelt name still finds the Pokemon
+const minNameSimilarity = 0.3
+
+// Field weights match ts_rank's defaults for the A, B and D labels used in searchDocument
+const (
+	nameWeight    = 1.0
+	typeWeight    = 0.4
+	abilityWeight = 0.4
+	flavorWeight  = 0.1
+)
+
+// searchDocument is the weighted tsvector of a Pokemon row; Migrate indexes the same expression
+const searchDocument = `setweight(to_tsvector('simple', coalesce(name, '')), 'A') || ` +
+	`setweight(to_tsvector('simple', coalesce(type1, '') || ' ' || coalesce(type2, '')), 'B') || ` +
+	`setweight(to_tsvector('simple', coalesce(abilities, '')), 'B') || ` +
+	`setweight(to_tsvector('simple', coalesce(flavor_text, '')), 'D')`
+
+// Search uses Postgres full-text and trigram indexes, and an in-process index on other databases such as SQLite
+func (r *PokemonRepository) Search(text string) ([]domain.PokemonSearchHit, error) {
+	if r.db.Dialector.Name() == "postgres" {
+		return r.searchPostgres(text)
+	}
+	return r.searchInProcess(text)
+}
+
+// searchPostgres matches every word of text in the document, or the whole of it as a similar name
+func (r *PokemonRepository) searchPostgres(text string) ([]domain.PokemonSearchHit, error) {
+	var rows []struct {
+		domain.Pokemon
+		Score float64
+	}
+	err := r.db.Raw(`
+		SELECT *, ts_rank(`+searchDocument+`, plainto_tsquery('simple', @text)) + similarity(name, @slug) AS score
+		FROM pokemons
+		WHERE tenant_id = @tenant AND deleted_at IS NULL AND (`+searchDocument+` @@ plainto_tsquery('simple', @text) OR name % @slug)
+		ORDER BY score DESC, id`,
+		sql.Named("text", text), sql.Named("slug", names.Slug(text)), sql.Named("tenant", r.tenantID),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]domain.PokemonSearchHit, 0, len(rows))
+	for i := range rows {
+		hits = append(hits, domain.PokemonSearchHit{Pokemon: &rows[i].Pokemon, Score: rows[i].Score})
+	}
+	return hits, nil
+}
+
+func (r *PokemonRepository) searchInProcess(text string) ([]domain.PokemonSearchHit, error) {
+	index := newSearchIndex()
+	if err := r.Each(func(pokemon *domain.Pokemon) error {
+		index.add(pokemon)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return index.search(text), nil
+}
+
+// searchIndex is an inverted index from words to the Pokemon containing them, scored like searchPostgres
+type searchIndex struct {
+	pokemon []*domain.Pokemon
+	// postings maps each word to the positions in pokemon that contain it, with the word's field weight
+	postings map[string]map[int]float64
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{postings: make(map[string]map[int]float64)}
+}
+
+func (idx *searchIndex) add(pokemon *domain.Pokemon) {
+	position := len(idx.pokemon)
+	idx.pokemon = append(idx.pokemon, pokemon)
+
+	idx.addWords(position, pokemon.Name, nameWeight)
+	idx.addWords(position, pokemon.Type1+" "+pokemon.Type2, typeWeight)
+	idx.addWords(position, strings.Join(pokemon.Abilities, " "), abilityWeight)
+	idx.addWords(position, pokemon.FlavorText, flavorWeight)
+}
+
+func (idx *searchIndex) addWords(position int, text string, weight float64) {
+	for _, word := range searchWords(text) {
+		if idx.postings[word] == nil {
+			idx.postings[word] = make(map[int]float64)
+		}
+		// A word in several fields counts at its heaviest
+		idx.postings[word][position] = max(idx.postings[word][position], weight)
+	}
+}
+
+func (idx *searchIndex) search(text string) []domain.PokemonSearchHit {
+	words := searchWords(text)
+	scores := make(map[int]float64)
+	matched := make(map[int]int)
+	for _, word := range words {
+		for position, weight := range idx.postings[word] {
+			scores[position] += weight
+			matched[position]++
+		}
+	}
+
+	slug := names.Slug(text)
+	hits := []domain.PokemonSearchHit{}
+	for position, pokemon := range idx.pokemon {
+		score := 0.0
+		if len(words) > 0 && matched[position] == len(words) {
+			score = scores[position]
+		}
+		similarity := names.Similarity(slug, pokemon.Name)
+		if score == 0 && similarity < minNameSimilarity {
+			continue
+		}
+		hits = append(hits, domain.PokemonSearchHit{Pokemon: pokemon, Score: score + similarity})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	return hits
+}
+
+// searchWords splits text into lowercase words of letters and digits
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
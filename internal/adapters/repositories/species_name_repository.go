@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SpeciesNameRepository struct {
+	db *gorm.DB
+}
+
+func NewSpeciesNameRepository(db *gorm.DB) ports.SpeciesNameRepository {
+	return &SpeciesNameRepository{db: db}
+}
+
+// Save also marks the species as synced, so one with no names is not fetched again
+func (r *SpeciesNameRepository) Save(dexNumber int, names []*domain.SpeciesName) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("dex_number = ?", dexNumber).Delete(&domain.SpeciesName{}).Error; err != nil {
+			return err
+		}
+		synced := &domain.SyncedSpecies{DexNumber: dexNumber, SyncedAt: time.Now()}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(synced).Error; err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		// Two species can share a name in a language; the first one saved keeps it
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(names).Error
+	})
+}
+
+// FindPokemon prefers the name saved first when several species have the key in different languages
+func (r *SpeciesNameRepository) FindPokemon(key string) (string, error) {
+	var name domain.SpeciesName
+	if err := r.db.Where("normalized_name = ?", key).Order("id").First(&name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("species name not found")
+		}
+		return "", err
+	}
+	return name.Pokemon, nil
+}
+
+// DexNumbers returns the species marked as synced, and those with names saved before species were marked
+func (r *SpeciesNameRepository) DexNumbers() (map[int]bool, error) {
+	var marked, named []int
+	if err := r.db.Model(&domain.SyncedSpecies{}).Pluck("dex_number", &marked).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&domain.SpeciesName{}).Distinct("dex_number").Pluck("dex_number", &named).Error; err != nil {
+		return nil, err
+	}
+
+	synced := make(map[int]bool, len(marked)+len(named))
+	for _, number := range append(marked, named...) {
+		synced[number] = true
+	}
+	return synced, nil
+}
+
+func (r *SpeciesNameRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.SpeciesName{}, &domain.SyncedSpecies{})
+}
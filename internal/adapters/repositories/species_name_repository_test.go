@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func speciesName(dexNumber int, language, name, pokemon string) *domain.SpeciesName {
+	return &domain.SpeciesName{
+		DexNumber:      dexNumber,
+		Language:       language,
+		Name:           name,
+		NormalizedName: domain.NormalizeLocalizedName(name),
+		Pokemon:        pokemon,
+	}
+}
+
+func TestSpeciesNameRepository(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&SpeciesNameRepository{db: db}).Migrate())
+	repo := NewSpeciesNameRepository(db)
+
+	assert.NoError(t, repo.Save(25, []*domain.SpeciesName{
+		speciesName(25, "en", "Pikachu", "pikachu"),
+		speciesName(25, "ja-hrkt", "ピカチュウ", "pikachu"),
+	}))
+	assert.NoError(t, repo.Save(386, []*domain.SpeciesName{
+		speciesName(386, "ja-hrkt", "デオキシス", "deoxys-normal"),
+	}))
+
+	pokemon, err := repo.FindPokemon(domain.NormalizeLocalizedName("ﾋﾟｶﾁｭｳ"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pikachu", pokemon)
+
+	pokemon, err = repo.FindPokemon("デオキシス")
+	assert.NoError(t, err)
+	assert.Equal(t, "deoxys-normal", pokemon)
+
+	_, err = repo.FindPokemon("pikachuu")
+	assert.EqualError(t, err, "species name not found")
+
+	// A species PokeAPI names in no language is still marked as synced
+	assert.NoError(t, repo.Save(10001, nil))
+	// Names saved before species were marked count as synced
+	assert.NoError(t, db.Create(speciesName(151, "en", "Mew", "mew")).Error)
+
+	numbers, err := repo.DexNumbers()
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{25: true, 151: true, 386: true, 10001: true}, numbers)
+
+	// Saving a species again replaces its names
+	assert.NoError(t, repo.Save(25, []*domain.SpeciesName{speciesName(25, "es", "Pikachu", "pikachu")}))
+	_, err = repo.FindPokemon("ピカチュウ")
+	assert.EqualError(t, err, "species name not found")
+	pokemon, err = repo.FindPokemon("pikachu")
+	assert.NoError(t, err)
+	assert.Equal(t, "pikachu", pokemon)
+}
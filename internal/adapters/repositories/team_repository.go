@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TeamRepository struct {
+	db       *gorm.DB
+	tenantID string
+}
+
+func NewTeamRepository(db *gorm.DB) ports.TeamRepository {
+	return &TeamRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *TeamRepository) ForTenant(tenantID string) ports.TeamRepository {
+	return &TeamRepository{db: r.db, tenantID: tenantID}
+}
+
+func (r *TeamRepository) Create(team *domain.Team) error {
+	team.TenantID = r.tenantID
+	return r.db.Omit("Members.Pokemon").Create(team).Error
+}
+
+func (r *TeamRepository) GetByID(id uint) (*domain.Team, error) {
+	var team domain.Team
+	err := r.withMembers().First(&team, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("team not found")
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *TeamRepository) List() ([]*domain.Team, error) {
+	var teams []*domain.Team
+	err := r.withMembers().Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// Update saves the team's own fields and replaces its member list
+func (r *TeamRepository) Update(team *domain.Team, expected *time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(team).Scopes(tenantScope(r.tenantID), versionScope(expected)).Select("name", "owner", "updated_at").Updates(team)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return notWritten(r.row(tx, team.ID), expected, "team")
+		}
+
+		if err := tx.Where("team_id = ?", team.ID).Delete(&domain.TeamMember{}).Error; err != nil {
+			return err
+		}
+		for i := range team.Members {
+			team.Members[i].ID = 0
+			team.Members[i].TeamID = team.ID
+		}
+		if len(team.Members) == 0 {
+			return nil
+		}
+		return tx.Omit("Pokemon").Create(&team.Members).Error
+	})
+}
+
+func (r *TeamRepository) Delete(id uint, expected *time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(tenantScope(r.tenantID), versionScope(expected)).Delete(&domain.Team{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return notWritten(r.row(tx, id), expected, "team")
+		}
+		return tx.Where("team_id = ?", id).Delete(&domain.TeamMember{}).Error
+	})
+}
+
+func (r *TeamRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Team{}, &domain.TeamMember{})
+}
+
+// row finds the tenant's team with the ID
+func (r *TeamRepository) row(tx *gorm.DB, id uint) *gorm.DB {
+	return tx.Model(&domain.Team{}).Scopes(tenantScope(r.tenantID)).Where("id = ?", id)
+}
+
+func (r *TeamRepository) withMembers() *gorm.DB {
+	return r.db.Scopes(tenantScope(r.tenantID)).Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("slot")
+	}).Preload("Members.Pokemon")
+}
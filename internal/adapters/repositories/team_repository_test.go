@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupTeamTestDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	err := (&TeamRepository{db: db}).Migrate()
+	assert.NoError(t, err)
+	return db
+}
+
+func createTestPokemon(t *testing.T, db *gorm.DB, names ...string) []*domain.Pokemon {
+	repo := NewPokemonRepository(db)
+	created := make([]*domain.Pokemon, 0, len(names))
+	for _, name := range names {
+		pokemon := &domain.Pokemon{Name: name, Type1: "normal"}
+		assert.NoError(t, repo.Create(pokemon, testChange))
+		created = append(created, pokemon)
+	}
+	return created
+}
+
+func TestTeamRepository_CreateAndGet(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+	pokemon := createTestPokemon(t, db, "snorlax", "chansey")
+
+	team := &domain.Team{
+		Name:  "walls",
+		Owner: "red",
+		Members: []domain.TeamMember{
+			{Slot: 1, PokemonID: pokemon[0].ID, Pokemon: pokemon[0], Moves: []string{"rest", "body-slam"}},
+			{Slot: 2, PokemonID: pokemon[1].ID, Moves: []string{"soft-boiled"}},
+		},
+	}
+	assert.NoError(t, repo.Create(team))
+	assert.NotZero(t, team.ID)
+
+	found, err := repo.GetByID(team.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "walls", found.Name)
+	assert.Len(t, found.Members, 2)
+	assert.Equal(t, []string{"rest", "body-slam"}, found.Members[0].Moves)
+	assert.Equal(t, "snorlax", found.Members[0].Pokemon.Name)
+	assert.Equal(t, "chansey", found.Members[1].Pokemon.Name)
+
+	var count int64
+	db.Model(&domain.Pokemon{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestTeamRepository_GetByID_NotFound(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+
+	found, err := repo.GetByID(42)
+	assert.EqualError(t, err, "team not found")
+	assert.Nil(t, found)
+}
+
+func TestTeamRepository_List(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+	pokemon := createTestPokemon(t, db, "pikachu")
+
+	assert.NoError(t, repo.Create(&domain.Team{Name: "one", Members: []domain.TeamMember{{Slot: 1, PokemonID: pokemon[0].ID}}}))
+	assert.NoError(t, repo.Create(&domain.Team{Name: "two"}))
+
+	teams, err := repo.List()
+	assert.NoError(t, err)
+	assert.Len(t, teams, 2)
+	assert.Len(t, teams[0].Members, 1)
+	assert.Empty(t, teams[1].Members)
+}
+
+func TestTeamRepository_Update_ReplacesMembers(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+	pokemon := createTestPokemon(t, db, "pikachu", "raichu", "pichu")
+
+	team := &domain.Team{Name: "mice", Members: []domain.TeamMember{
+		{Slot: 1, PokemonID: pokemon[0].ID},
+		{Slot: 2, PokemonID: pokemon[1].ID},
+	}}
+	assert.NoError(t, repo.Create(team))
+
+	team.Name = "baby mice"
+	team.Members = []domain.TeamMember{{Slot: 1, PokemonID: pokemon[2].ID, Moves: []string{"thunder-shock"}}}
+	assert.NoError(t, repo.Update(team, nil))
+
+	found, err := repo.GetByID(team.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "baby mice", found.Name)
+	assert.Len(t, found.Members, 1)
+	assert.Equal(t, "pichu", found.Members[0].Pokemon.Name)
+
+	var memberCount int64
+	db.Model(&domain.TeamMember{}).Count(&memberCount)
+	assert.Equal(t, int64(1), memberCount)
+}
+
+func TestTeamRepository_Update_NotFound(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+
+	err := repo.Update(&domain.Team{ID: 7, Name: "missing"}, nil)
+	assert.EqualError(t, err, "team not found")
+}
+
+func TestTeamRepository_Delete(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+	pokemon := createTestPokemon(t, db, "eevee")
+
+	team := &domain.Team{Name: "eeveelutions", Members: []domain.TeamMember{{Slot: 1, PokemonID: pokemon[0].ID}}}
+	assert.NoError(t, repo.Create(team))
+
+	assert.NoError(t, repo.Delete(team.ID, nil))
+	_, err := repo.GetByID(team.ID)
+	assert.EqualError(t, err, "team not found")
+
+	var memberCount int64
+	db.Model(&domain.TeamMember{}).Count(&memberCount)
+	assert.Zero(t, memberCount)
+
+	assert.EqualError(t, repo.Delete(team.ID, nil), "team not found")
+}
+
+func TestTeamRepository_ConditionalWrites(t *testing.T) {
+	db := setupTeamTestDB(t)
+	repo := NewTeamRepository(db)
+
+	team := &domain.Team{Name: "rain"}
+	assert.NoError(t, repo.Create(team))
+	read, err := repo.GetByID(team.ID)
+	assert.NoError(t, err)
+	version := read.UpdatedAt
+
+	read.Name = "sun"
+	assert.NoError(t, repo.Update(read, &version))
+
+	var modified *domain.ModifiedError
+	assert.ErrorAs(t, repo.Update(&domain.Team{ID: team.ID, Name: "sand"}, &version), &modified)
+	assert.ErrorAs(t, repo.Delete(team.ID, &version), &modified)
+
+	stored, err := repo.GetByID(team.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "sun", stored.Name)
+
+	assert.NoError(t, repo.Delete(team.ID, &stored.UpdatedAt))
+	assert.EqualError(t, repo.Delete(team.ID, &stored.UpdatedAt), "team not found")
+}
+
+func TestTeamRepository_TenantIsolation(t *testing.T) {
+	db := setupTeamTestDB(t)
+	kanto := NewTeamRepository(db).ForTenant("kanto")
+	johto := NewTeamRepository(db).ForTenant("johto")
+
+	team := &domain.Team{Name: "walls"}
+	assert.NoError(t, kanto.Create(team))
+	assert.Equal(t, "kanto", team.TenantID)
+
+	_, err := johto.GetByID(team.ID)
+	assert.EqualError(t, err, "team not found")
+	list, err := johto.List()
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	assert.EqualError(t, johto.Update(&domain.Team{ID: team.ID, Name: "stolen"}, nil), "team not found")
+	assert.EqualError(t, johto.Delete(team.ID, nil), "team not found")
+
+	found, err := kanto.GetByID(team.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "walls", found.Name)
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+
+	"gorm.io/gorm"
+)
+
+type TenantRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) ports.TenantRepository {
+	return &TenantRepository{db: db}
+}
+
+func (r *TenantRepository) Create(tenant *domain.Tenant) error {
+	return r.db.Create(tenant).Error
+}
+
+func (r *TenantRepository) GetByID(id string) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	err := r.db.Where("id = ?", id).First(&tenant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tenant not found")
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+func (r *TenantRepository) List() ([]*domain.Tenant, error) {
+	var tenants []*domain.Tenant
+	if err := r.db.Order("id").Find(&tenants).Error; err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (r *TenantRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Tenant{})
+}
+
+// tenantScope limits a query to the rows of one tenant
+func tenantScope(tenantID string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("tenant_id = ?", tenantID)
+	}
+}
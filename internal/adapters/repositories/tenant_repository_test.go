@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantRepository(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&TenantRepository{db: db}).Migrate())
+	repo := NewTenantRepository(db)
+
+	assert.NoError(t, repo.Create(&domain.Tenant{ID: "kanto", Name: "Kanto League"}))
+	assert.NoError(t, repo.Create(&domain.Tenant{ID: "johto", Name: "Johto League"}))
+	assert.Error(t, repo.Create(&domain.Tenant{ID: "kanto", Name: "Again"}))
+
+	found, err := repo.GetByID("kanto")
+	assert.NoError(t, err)
+	assert.Equal(t, "Kanto League", found.Name)
+
+	_, err = repo.GetByID("hoenn")
+	assert.EqualError(t, err, "tenant not found")
+
+	tenants, err := repo.List()
+	assert.NoError(t, err)
+	assert.Len(t, tenants, 2)
+	assert.Equal(t, "johto", tenants[0].ID)
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TrainerRepository struct {
+	db       *gorm.DB
+	tenantID string
+}
+
+func NewTrainerRepository(db *gorm.DB) ports.TrainerRepository {
+	return &TrainerRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *TrainerRepository) ForTenant(tenantID string) ports.TrainerRepository {
+	return &TrainerRepository{db: r.db, tenantID: tenantID}
+}
+
+func (r *TrainerRepository) Create(trainer *domain.Trainer) error {
+	trainer.TenantID = r.tenantID
+	return r.db.Create(trainer).Error
+}
+
+func (r *TrainerRepository) GetByID(id uint) (*domain.Trainer, error) {
+	var trainer domain.Trainer
+	err := r.db.Scopes(tenantScope(r.tenantID)).First(&trainer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("trainer not found")
+		}
+		return nil, err
+	}
+	return &trainer, nil
+}
+
+func (r *TrainerRepository) GetByName(name string) (*domain.Trainer, error) {
+	var trainer domain.Trainer
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("name = ?", name).First(&trainer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("trainer not found")
+		}
+		return nil, err
+	}
+	return &trainer, nil
+}
+
+func (r *TrainerRepository) List() ([]*domain.Trainer, error) {
+	var trainers []*domain.Trainer
+	err := r.db.Scopes(tenantScope(r.tenantID)).Find(&trainers).Error
+	if err != nil {
+		return nil, err
+	}
+	return trainers, nil
+}
+
+func (r *TrainerRepository) AddPokemon(owned *domain.OwnedPokemon) error {
+	owned.TenantID = r.tenantID
+	return r.db.Omit("Species").Create(owned).Error
+}
+
+func (r *TrainerRepository) GetPokemon(trainerID, id uint) (*domain.OwnedPokemon, error) {
+	var owned domain.OwnedPokemon
+	err := r.db.Scopes(tenantScope(r.tenantID)).Preload("Species").Where("trainer_id = ?", trainerID).First(&owned, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("owned pokemon not found")
+		}
+		return nil, err
+	}
+	return &owned, nil
+}
+
+func (r *TrainerRepository) ListPokemon(trainerID uint) ([]*domain.OwnedPokemon, error) {
+	var owned []*domain.OwnedPokemon
+	err := r.db.Scopes(tenantScope(r.tenantID)).Preload("Species").Where("trainer_id = ?", trainerID).Order("id").Find(&owned).Error
+	if err != nil {
+		return nil, err
+	}
+	return owned, nil
+}
+
+func (r *TrainerRepository) UpdatePokemon(owned *domain.OwnedPokemon, expected *time.Time) error {
+	owned.TenantID = r.tenantID
+	// Save would insert the row when the update matches nothing, so the tenant's row is updated in place instead
+	result := r.db.Model(owned).Scopes(tenantScope(r.tenantID), versionScope(expected)).Select("*").Omit("Species", "created_at").Updates(owned)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return notWritten(r.ownedRow(owned.TrainerID, owned.ID), expected, "owned pokemon")
+	}
+	return nil
+}
+
+func (r *TrainerRepository) DeletePokemon(trainerID, id uint, expected *time.Time) error {
+	result := r.db.Scopes(tenantScope(r.tenantID), versionScope(expected)).Where("trainer_id = ?", trainerID).Delete(&domain.OwnedPokemon{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return notWritten(r.ownedRow(trainerID, id), expected, "owned pokemon")
+	}
+	return nil
+}
+
+// ownedRow finds the tenant's owned Pokemon with the ID, if the trainer owns it
+func (r *TrainerRepository) ownedRow(trainerID, id uint) *gorm.DB {
+	return r.db.Model(&domain.OwnedPokemon{}).Scopes(tenantScope(r.tenantID)).Where("trainer_id = ? AND id = ?", trainerID, id)
+}
+
+func (r *TrainerRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Trainer{}, &domain.OwnedPokemon{})
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupTrainerTestDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	err := (&TrainerRepository{db: db}).Migrate()
+	assert.NoError(t, err)
+	return db
+}
+
+func TestTrainerRepository_CreateAndGet(t *testing.T) {
+	db := setupTrainerTestDB(t)
+	repo := NewTrainerRepository(db)
+
+	trainer := &domain.Trainer{Name: "brock"}
+	assert.NoError(t, repo.Create(trainer))
+
+	found, err := repo.GetByID(trainer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "brock", found.Name)
+
+	_, err = repo.GetByID(999)
+	assert.EqualError(t, err, "trainer not found")
+
+	found, err = repo.GetByName("brock")
+	assert.NoError(t, err)
+	assert.Equal(t, trainer.ID, found.ID)
+	_, err = repo.GetByName("misty")
+	assert.EqualError(t, err, "trainer not found")
+
+	assert.Error(t, repo.Create(&domain.Trainer{Name: "brock"}))
+}
+
+func TestTrainerRepository_OwnedPokemon(t *testing.T) {
+	db := setupTrainerTestDB(t)
+	repo := NewTrainerRepository(db)
+	species := createTestPokemon(t, db, "pikachu")[0]
+
+	ash := &domain.Trainer{Name: "ash"}
+	gary := &domain.Trainer{Name: "gary"}
+	assert.NoError(t, repo.Create(ash))
+	assert.NoError(t, repo.Create(gary))
+
+	// Two trainers can each own a Pikachu, and one trainer can own several
+	first := &domain.OwnedPokemon{TrainerID: ash.ID, SpeciesID: species.ID, Species: species, Nickname: "sparky", Level: 50, Nature: "timid",
+		IVs: domain.StatSpread{Speed: 31}, EVs: domain.StatSpread{SpAttack: 252, Speed: 252}, Shiny: true}
+	second := &domain.OwnedPokemon{TrainerID: ash.ID, SpeciesID: species.ID, Level: 5, Nature: "hardy"}
+	third := &domain.OwnedPokemon{TrainerID: gary.ID, SpeciesID: species.ID, Level: 30, Nature: "jolly"}
+	assert.NoError(t, repo.AddPokemon(first))
+	assert.NoError(t, repo.AddPokemon(second))
+	assert.NoError(t, repo.AddPokemon(third))
+
+	found, err := repo.GetPokemon(ash.ID, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "sparky", found.Nickname)
+	assert.Equal(t, 31, found.IVs.Speed)
+	assert.Equal(t, 252, found.EVs.SpAttack)
+	assert.True(t, found.Shiny)
+	assert.Equal(t, "pikachu", found.Species.Name)
+
+	_, err = repo.GetPokemon(gary.ID, first.ID)
+	assert.EqualError(t, err, "owned pokemon not found")
+
+	list, err := repo.ListPokemon(ash.ID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	found.Level = 51
+	assert.NoError(t, repo.UpdatePokemon(found, nil))
+	updated, err := repo.GetPokemon(ash.ID, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 51, updated.Level)
+
+	assert.EqualError(t, repo.DeletePokemon(gary.ID, first.ID, nil), "owned pokemon not found")
+	assert.NoError(t, repo.DeletePokemon(ash.ID, first.ID, nil))
+	list, err = repo.ListPokemon(ash.ID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestTrainerRepository_ConditionalWrites(t *testing.T) {
+	db := setupTrainerTestDB(t)
+	repo := NewTrainerRepository(db)
+	species := createTestPokemon(t, db, "pikachu")[0]
+
+	ash := &domain.Trainer{Name: "ash"}
+	assert.NoError(t, repo.Create(ash))
+	owned := &domain.OwnedPokemon{TrainerID: ash.ID, SpeciesID: species.ID, Level: 5, Nature: "hardy"}
+	assert.NoError(t, repo.AddPokemon(owned))
+	read, err := repo.GetPokemon(ash.ID, owned.ID)
+	assert.NoError(t, err)
+	version := read.UpdatedAt
+
+	read.Level = 10
+	assert.NoError(t, repo.UpdatePokemon(read, &version))
+
+	var modified *domain.ModifiedError
+	assert.ErrorAs(t, repo.UpdatePokemon(&domain.OwnedPokemon{ID: owned.ID, TrainerID: ash.ID, SpeciesID: species.ID, Level: 20, Nature: "hardy"}, &version), &modified)
+	assert.ErrorAs(t, repo.DeletePokemon(ash.ID, owned.ID, &version), &modified)
+
+	stored, err := repo.GetPokemon(ash.ID, owned.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, stored.Level)
+
+	assert.NoError(t, repo.DeletePokemon(ash.ID, owned.ID, &stored.UpdatedAt))
+	assert.EqualError(t, repo.DeletePokemon(ash.ID, owned.ID, &stored.UpdatedAt), "owned pokemon not found")
+}
+
+func TestTrainerRepository_TenantIsolation(t *testing.T) {
+	db := setupTrainerTestDB(t)
+	kanto := NewTrainerRepository(db).ForTenant("kanto")
+	johto := NewTrainerRepository(db).ForTenant("johto")
+	species := createTestPokemon(t, db, "pikachu")[0]
+
+	// Trainer names only have to be unique within a tenant
+	ash := &domain.Trainer{Name: "ash"}
+	assert.NoError(t, kanto.Create(ash))
+	assert.NoError(t, johto.Create(&domain.Trainer{Name: "ash"}))
+	assert.Error(t, kanto.Create(&domain.Trainer{Name: "ash"}))
+
+	_, err := johto.GetByID(ash.ID)
+	assert.EqualError(t, err, "trainer not found")
+	found, err := johto.GetByName("ash")
+	assert.NoError(t, err)
+	assert.NotEqual(t, ash.ID, found.ID)
+	list, err := johto.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.NotEqual(t, ash.ID, list[0].ID)
+
+	owned := &domain.OwnedPokemon{TrainerID: ash.ID, SpeciesID: species.ID, Level: 5, Nature: "hardy"}
+	assert.NoError(t, kanto.AddPokemon(owned))
+
+	_, err = johto.GetPokemon(ash.ID, owned.ID)
+	assert.EqualError(t, err, "owned pokemon not found")
+	ownedList, err := johto.ListPokemon(ash.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, ownedList)
+	assert.EqualError(t, johto.UpdatePokemon(&domain.OwnedPokemon{ID: owned.ID, TrainerID: ash.ID, SpeciesID: species.ID, Level: 100}, nil), "owned pokemon not found")
+	assert.EqualError(t, johto.DeletePokemon(ash.ID, owned.ID, nil), "owned pokemon not found")
+
+	foundOwned, err := kanto.GetPokemon(ash.ID, owned.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, foundOwned.Level)
+}
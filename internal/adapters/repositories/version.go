@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// versionScope limits an update or delete to a row still at the expected version, its updated_at, so a change
+// based on a stale read matches nothing; without an expected version the write is unconditional
+func versionScope(expected *time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if expected == nil {
+			return db
+		}
+		return db.Where("updated_at = ?", *expected)
+	}
+}
+
+// notWritten explains a versionScope write that matched no row: the row query finds still exists at another
+// version, or it is gone
+func notWritten(row *gorm.DB, expected *time.Time, resource string) error {
+	if expected != nil {
+		var count int64
+		if err := row.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return &domain.ModifiedError{Resource: resource}
+		}
+	}
+	return errors.New(resource + " not found")
+}
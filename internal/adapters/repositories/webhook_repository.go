@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db       *gorm.DB
+	tenantID string
+}
+
+func NewWebhookRepository(db *gorm.DB) ports.WebhookRepository {
+	return &WebhookRepository{db: db, tenantID: domain.DefaultTenantID}
+}
+
+func (r *WebhookRepository) ForTenant(tenantID string) ports.WebhookRepository {
+	return &WebhookRepository{db: r.db, tenantID: tenantID}
+}
+
+func (r *WebhookRepository) Create(webhook *domain.Webhook) error {
+	webhook.TenantID = r.tenantID
+	return r.db.Create(webhook).Error
+}
+
+func (r *WebhookRepository) GetByID(id uint) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := r.db.Scopes(tenantScope(r.tenantID)).First(&webhook, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) List() ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+	if err := r.db.Scopes(tenantScope(r.tenantID)).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(tenantScope(r.tenantID)).Delete(&domain.Webhook{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("webhook not found")
+		}
+		return tx.Where("webhook_id = ?", id).Delete(&domain.WebhookDelivery{}).Error
+	})
+}
+
+func (r *WebhookRepository) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+func (r *WebhookRepository) GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("webhook_id = ?", webhookID).First(&delivery, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) ListDueDeliveries(now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) ListDeliveries(webhookID uint, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := r.db.Scopes(tenantScope(r.tenantID)).Where("webhook_id = ?", webhookID).Order("id DESC").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery stores an attempt's outcome. It never inserts, so a delivery deleted with its webhook while it was
+// being sent stays deleted.
+func (r *WebhookRepository) UpdateDelivery(delivery *domain.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+	result := r.db.Model(&domain.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"response_status": delivery.ResponseStatus,
+		"last_error":      delivery.LastError,
+		"delivered_at":    delivery.DeliveredAt,
+		"updated_at":      delivery.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("webhook delivery not found")
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Migrate() error {
+	return r.db.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{})
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRepository_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&WebhookRepository{db: db}).Migrate())
+	repo := NewWebhookRepository(db)
+
+	webhook := &domain.Webhook{URL: "https://example.com/hook", EventTypes: []string{domain.EventPokemonCreated}, Secret: "s3cret"}
+	assert.NoError(t, repo.Create(webhook))
+	assert.NotZero(t, webhook.ID)
+
+	stored, err := repo.GetByID(webhook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.EventPokemonCreated}, stored.EventTypes)
+	assert.Equal(t, "s3cret", stored.Secret)
+
+	list, err := repo.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	assert.NoError(t, repo.CreateDeliveries([]*domain.WebhookDelivery{{WebhookID: webhook.ID, DedupID: "event-1", Status: domain.DeliveryPending}}))
+	assert.NoError(t, repo.Delete(webhook.ID))
+
+	_, err = repo.GetByID(webhook.ID)
+	assert.EqualError(t, err, "webhook not found")
+	assert.EqualError(t, repo.Delete(webhook.ID), "webhook not found")
+
+	var deliveries int64
+	db.Model(&domain.WebhookDelivery{}).Count(&deliveries)
+	assert.Zero(t, deliveries)
+}
+
+func TestWebhookRepository_Deliveries(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&WebhookRepository{db: db}).Migrate())
+	repo := NewWebhookRepository(db)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	deliveries := []*domain.WebhookDelivery{
+		{WebhookID: 1, DedupID: "event-1", Status: domain.DeliveryPending, NextAttemptAt: now.Add(-time.Minute), Payload: []byte(`{"type":"pokemon.created"}`)},
+		{WebhookID: 1, DedupID: "event-2", Status: domain.DeliveryPending, NextAttemptAt: now.Add(time.Minute)},
+		{WebhookID: 2, DedupID: "event-1", Status: domain.DeliveryPending, NextAttemptAt: now.Add(-time.Hour)},
+		{WebhookID: 1, DedupID: "event-3", Status: domain.DeliveryDead, NextAttemptAt: now.Add(-time.Hour)},
+	}
+	assert.NoError(t, repo.CreateDeliveries(deliveries))
+	assert.NoError(t, repo.CreateDeliveries(nil))
+
+	// An event relayed again is not queued twice for the same webhook
+	assert.NoError(t, repo.CreateDeliveries([]*domain.WebhookDelivery{{WebhookID: 1, DedupID: "event-1", Status: domain.DeliveryPending, NextAttemptAt: now.Add(-time.Hour)}}))
+	var count int64
+	db.Model(&domain.WebhookDelivery{}).Count(&count)
+	assert.Equal(t, int64(4), count)
+
+	due, err := repo.ListDueDeliveries(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 2)
+	assert.Equal(t, deliveries[2].ID, due[0].ID)
+	assert.Equal(t, `{"type":"pokemon.created"}`, string(due[1].Payload))
+
+	due[1].Status = domain.DeliverySucceeded
+	due[1].Attempts = 1
+	assert.NoError(t, repo.UpdateDelivery(due[1]))
+
+	delivery, err := repo.GetDelivery(1, deliveries[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+
+	_, err = repo.GetDelivery(2, deliveries[0].ID)
+	assert.EqualError(t, err, "webhook delivery not found")
+
+	log, err := repo.ListDeliveries(1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, log, 2)
+	assert.Equal(t, deliveries[3].ID, log[0].ID)
+}
+
+func TestWebhookRepository_UpdateDelivery_DeletedWebhook(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&WebhookRepository{db: db}).Migrate())
+	repo := NewWebhookRepository(db)
+	now := time.Now()
+
+	webhook := &domain.Webhook{URL: "https://example.com", EventTypes: []string{domain.EventPokemonCreated}, Secret: "s"}
+	assert.NoError(t, repo.Create(webhook))
+	assert.NoError(t, repo.CreateDeliveries([]*domain.WebhookDelivery{
+		{WebhookID: webhook.ID, TenantID: domain.DefaultTenantID, DedupID: "event-1", Status: domain.DeliveryPending, NextAttemptAt: now.Add(-time.Minute)},
+	}))
+	due, err := repo.ListDueDeliveries(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	// The webhook is deleted while its delivery is being sent
+	assert.NoError(t, repo.Delete(webhook.ID))
+	due[0].Status = domain.DeliverySucceeded
+	due[0].Attempts = 1
+	assert.EqualError(t, repo.UpdateDelivery(due[0]), "webhook delivery not found")
+
+	var count int64
+	db.Model(&domain.WebhookDelivery{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestWebhookRepository_TenantIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, (&WebhookRepository{db: db}).Migrate())
+	kanto := NewWebhookRepository(db).ForTenant("kanto")
+	johto := NewWebhookRepository(db).ForTenant("johto")
+
+	webhook := &domain.Webhook{URL: "https://example.com/hook", EventTypes: []string{domain.EventPokemonCreated}, Secret: "s3cret"}
+	assert.NoError(t, kanto.Create(webhook))
+	delivery := &domain.WebhookDelivery{WebhookID: webhook.ID, TenantID: "kanto", DedupID: "event-1", Status: domain.DeliveryPending}
+	assert.NoError(t, kanto.CreateDeliveries([]*domain.WebhookDelivery{delivery}))
+
+	_, err := johto.GetByID(webhook.ID)
+	assert.EqualError(t, err, "webhook not found")
+	list, err := johto.List()
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+	_, err = johto.GetDelivery(webhook.ID, delivery.ID)
+	assert.EqualError(t, err, "webhook delivery not found")
+	deliveries, err := johto.ListDeliveries(webhook.ID, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+	assert.EqualError(t, johto.Delete(webhook.ID), "webhook not found")
+
+	deliveries, err = kanto.ListDeliveries(webhook.ID, 10)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
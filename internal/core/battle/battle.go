@@ -0,0 +1,200 @@
+// Package battle simulates singles battles between two sides with a seeded random source,
+// so the same sides and seed always produce the same log.
+package battle
+
+import (
+	"math/rand"
+	"pokemon-api/internal/core/damage"
+	"pokemon-api/internal/core/domain"
+)
+
+// MaxTurns ends a battle in a draw if neither side has won by then
+const MaxTurns = 500
+
+// criticalChance is the one-in-N chance of an unboosted critical hit
+const criticalChance = 24
+
+// Struggle is used by fighters without any damaging move
+var Struggle = domain.Move{Name: "struggle", Power: 50, Category: domain.CategoryPhysical}
+
+// Fighter is one Pokemon taking part in a battle
+type Fighter struct {
+	Name      string
+	Combatant damage.Combatant
+	Moves     []domain.Move
+	HP        int
+}
+
+// Side is a team in battle order; the first fighter leads
+type Side struct {
+	Key      string
+	Fighters []*Fighter
+}
+
+type state struct {
+	side   *Side
+	active int
+}
+
+func (s *state) current() *Fighter {
+	return s.side.Fighters[s.active]
+}
+
+// Result holds the outcome and full log of a simulated battle
+type Result struct {
+	Winner string
+	Turns  int
+	Log    []domain.BattleEvent
+}
+
+// Simulate runs a battle to completion; every fighter picks the move with the highest expected damage
+func Simulate(a, b *Side, seed int64) Result {
+	rng := rand.New(rand.NewSource(seed))
+	sides := [2]*state{{side: a}, {side: b}}
+	result := Result{}
+
+	for _, s := range sides {
+		for _, f := range s.side.Fighters {
+			f.HP = f.Combatant.Stats.HP
+		}
+		if len(s.side.Fighters) == 0 {
+			continue
+		}
+		result.Log = append(result.Log, domain.BattleEvent{Type: domain.BattleEventSwitch, Side: s.side.Key, Pokemon: s.current().Name, RemainingHP: s.current().HP})
+	}
+
+	for turn := 1; turn <= MaxTurns; turn++ {
+		if winner := winnerOf(sides); winner != "" {
+			return finish(result, winner, turn-1)
+		}
+		result.Turns = turn
+
+		order := turnOrder(sides, rng)
+		for _, i := range order {
+			attacker, defender := sides[i], sides[1-i]
+			if attacker.current().HP <= 0 || defender.current().HP <= 0 {
+				continue
+			}
+			result.Log = append(result.Log, attack(turn, attacker, defender, rng)...)
+		}
+
+		for _, s := range sides {
+			if s.current().HP > 0 {
+				continue
+			}
+			if next := nextAlive(s); next >= 0 {
+				s.active = next
+				result.Log = append(result.Log, domain.BattleEvent{Turn: turn, Type: domain.BattleEventSwitch, Side: s.side.Key, Pokemon: s.current().Name, RemainingHP: s.current().HP})
+			}
+		}
+	}
+
+	if winner := winnerOf(sides); winner != "" {
+		return finish(result, winner, MaxTurns)
+	}
+	return finish(result, domain.BattleDraw, MaxTurns)
+}
+
+// BestMove returns the move with the highest expected damage against the defender, accounting for accuracy
+func BestMove(attacker, defender damage.Combatant, moves []domain.Move) domain.Move {
+	best, bestScore := Struggle, -1.0
+	for _, move := range moves {
+		if move.Power <= 0 || move.Category == domain.CategoryStatus {
+			continue
+		}
+		score := expectedDamage(attacker, defender, move)
+		if score > bestScore {
+			best, bestScore = move, score
+		}
+	}
+	return best
+}
+
+func expectedDamage(attacker, defender damage.Combatant, move domain.Move) float64 {
+	result := damage.Calculate(attacker, defender, move, domain.DamageModifiers{})
+	total := 0
+	for _, roll := range result.Rolls {
+		total += roll
+	}
+	expected := float64(total) / float64(len(result.Rolls))
+	if move.Accuracy > 0 {
+		expected *= float64(move.Accuracy) / 100
+	}
+	return expected
+}
+
+func attack(turn int, attacker, defender *state, rng *rand.Rand) []domain.BattleEvent {
+	user, target := attacker.current(), defender.current()
+	move := BestMove(user.Combatant, target.Combatant, user.Moves)
+
+	event := domain.BattleEvent{Turn: turn, Side: attacker.side.Key, Pokemon: user.Name, Move: move.Name, Target: target.Name}
+	if move.Accuracy > 0 && rng.Intn(100) >= move.Accuracy {
+		event.Type = domain.BattleEventMiss
+		return []domain.BattleEvent{event}
+	}
+
+	critical := rng.Intn(criticalChance) == 0
+	result := damage.Calculate(user.Combatant, target.Combatant, move, domain.DamageModifiers{Critical: critical})
+	dealt := result.Rolls[rng.Intn(len(result.Rolls))]
+	if dealt > target.HP {
+		dealt = target.HP
+	}
+	target.HP -= dealt
+
+	event.Type = domain.BattleEventMove
+	event.Damage = dealt
+	event.Critical = critical
+	event.Effectiveness = result.Effectiveness
+	event.RemainingHP = target.HP
+	events := []domain.BattleEvent{event}
+
+	if target.HP <= 0 {
+		events = append(events, domain.BattleEvent{Turn: turn, Type: domain.BattleEventFaint, Side: defender.side.Key, Pokemon: target.Name})
+	}
+	return events
+}
+
+// turnOrder returns the side indexes in acting order: faster first, speed ties broken at random
+func turnOrder(sides [2]*state, rng *rand.Rand) []int {
+	speedA, speedB := sides[0].current().Combatant.Stats.Speed, sides[1].current().Combatant.Stats.Speed
+	switch {
+	case speedA > speedB:
+		return []int{0, 1}
+	case speedB > speedA:
+		return []int{1, 0}
+	case rng.Intn(2) == 0:
+		return []int{0, 1}
+	default:
+		return []int{1, 0}
+	}
+}
+
+func nextAlive(s *state) int {
+	for i, f := range s.side.Fighters {
+		if f.HP > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func winnerOf(sides [2]*state) string {
+	aliveA, aliveB := nextAlive(sides[0]) >= 0, nextAlive(sides[1]) >= 0
+	switch {
+	case aliveA && aliveB:
+		return ""
+	case aliveA:
+		return sides[0].side.Key
+	case aliveB:
+		return sides[1].side.Key
+	default:
+		return domain.BattleDraw
+	}
+}
+
+func finish(result Result, winner string, turns int) Result {
+	result.Winner = winner
+	result.Turns = turns
+	result.Log = append(result.Log, domain.BattleEvent{Turn: turns, Type: domain.BattleEventEnd, Winner: winner})
+	return result
+}
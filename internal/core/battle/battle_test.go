@@ -0,0 +1,145 @@
+package battle
+
+import (
+	"pokemon-api/internal/core/damage"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	thunderbolt = domain.Move{Name: "thunderbolt", Type: "electric", Power: 90, Category: domain.CategorySpecial, Accuracy: 100}
+	surf        = domain.Move{Name: "surf", Type: "water", Power: 90, Category: domain.CategorySpecial, Accuracy: 100}
+	tackle      = domain.Move{Name: "tackle", Type: "normal", Power: 40, Category: domain.CategoryPhysical, Accuracy: 100}
+	hydroPump   = domain.Move{Name: "hydro-pump", Type: "water", Power: 110, Category: domain.CategorySpecial, Accuracy: 80}
+)
+
+func fighter(name string, types []string, stats domain.StatSpread, moves ...domain.Move) *Fighter {
+	return &Fighter{
+		Name:      name,
+		Combatant: damage.Combatant{Level: 50, Types: types, Stats: stats},
+		Moves:     moves,
+	}
+}
+
+func balanced(hp, speed int) domain.StatSpread {
+	return domain.StatSpread{HP: hp, Attack: 100, Defense: 100, SpAttack: 100, SpDefense: 100, Speed: speed}
+}
+
+func TestSimulate_SameSeedReplaysExactly(t *testing.T) {
+	build := func() (*Side, *Side) {
+		a := &Side{Key: domain.BattleWinnerTeamA, Fighters: []*Fighter{
+			fighter("pikachu", []string{"electric"}, balanced(150, 120), thunderbolt, tackle),
+			fighter("lapras", []string{"water", "ice"}, balanced(200, 60), surf, hydroPump),
+		}}
+		b := &Side{Key: domain.BattleWinnerTeamB, Fighters: []*Fighter{
+			fighter("vaporeon", []string{"water"}, balanced(220, 65), hydroPump, tackle),
+			fighter("jolteon", []string{"electric"}, balanced(140, 130), thunderbolt),
+		}}
+		return a, b
+	}
+
+	a1, b1 := build()
+	a2, b2 := build()
+	first := Simulate(a1, b1, 42)
+	second := Simulate(a2, b2, 42)
+
+	assert.Equal(t, first, second)
+	assert.NotEmpty(t, first.Log)
+	assert.Equal(t, domain.BattleEventEnd, first.Log[len(first.Log)-1].Type)
+	assert.Contains(t, []string{domain.BattleWinnerTeamA, domain.BattleWinnerTeamB}, first.Winner)
+}
+
+func TestSimulate_OneVersusOne(t *testing.T) {
+	a := &Side{Key: domain.BattleWinnerTeamA, Fighters: []*Fighter{
+		fighter("pikachu", []string{"electric"}, balanced(150, 120), tackle, thunderbolt),
+	}}
+	b := &Side{Key: domain.BattleWinnerTeamB, Fighters: []*Fighter{
+		fighter("magikarp", []string{"water"}, domain.StatSpread{HP: 60, Attack: 10, Defense: 55, SpAttack: 15, SpDefense: 20, Speed: 80}, tackle),
+	}}
+
+	result := Simulate(a, b, 7)
+
+	assert.Equal(t, domain.BattleWinnerTeamA, result.Winner)
+	assert.Equal(t, 1, result.Turns)
+
+	// Lead switches, then Pikachu outspeeds and knocks Magikarp out with a super-effective Thunderbolt
+	assert.Equal(t, domain.BattleEventSwitch, result.Log[0].Type)
+	assert.Equal(t, domain.BattleEventSwitch, result.Log[1].Type)
+	assert.Equal(t, domain.BattleEventMove, result.Log[2].Type)
+	assert.Equal(t, "pikachu", result.Log[2].Pokemon)
+	assert.Equal(t, "thunderbolt", result.Log[2].Move)
+	assert.Equal(t, 2.0, result.Log[2].Effectiveness)
+	assert.Equal(t, 0, result.Log[2].RemainingHP)
+	assert.Equal(t, domain.BattleEventFaint, result.Log[3].Type)
+	assert.Equal(t, "magikarp", result.Log[3].Pokemon)
+	assert.Equal(t, domain.BattleEventEnd, result.Log[4].Type)
+	assert.Len(t, result.Log, 5)
+}
+
+func TestSimulate_SwitchesInNextMember(t *testing.T) {
+	a := &Side{Key: domain.BattleWinnerTeamA, Fighters: []*Fighter{
+		fighter("raichu", []string{"electric"}, balanced(200, 150), thunderbolt),
+	}}
+	b := &Side{Key: domain.BattleWinnerTeamB, Fighters: []*Fighter{
+		fighter("goldeen", []string{"water"}, balanced(20, 10), tackle),
+		fighter("seaking", []string{"water"}, balanced(20, 10), tackle),
+	}}
+
+	result := Simulate(a, b, 1)
+
+	assert.Equal(t, domain.BattleWinnerTeamA, result.Winner)
+	var switches []string
+	for _, event := range result.Log {
+		if event.Type == domain.BattleEventSwitch && event.Side == domain.BattleWinnerTeamB {
+			switches = append(switches, event.Pokemon)
+		}
+	}
+	assert.Equal(t, []string{"goldeen", "seaking"}, switches)
+}
+
+func TestSimulate_DrawWhenNoOneCanDealDamage(t *testing.T) {
+	a := &Side{Key: domain.BattleWinnerTeamA, Fighters: []*Fighter{fighter("gengar", []string{"ghost"}, balanced(100, 110), tackle)}}
+	b := &Side{Key: domain.BattleWinnerTeamB, Fighters: []*Fighter{fighter("haunter", []string{"ghost"}, balanced(100, 95), tackle)}}
+
+	result := Simulate(a, b, 3)
+
+	assert.Equal(t, domain.BattleDraw, result.Winner)
+	assert.Equal(t, MaxTurns, result.Turns)
+}
+
+func TestSimulate_EmptySideLoses(t *testing.T) {
+	a := &Side{Key: domain.BattleWinnerTeamA, Fighters: []*Fighter{fighter("eevee", []string{"normal"}, balanced(100, 55), tackle)}}
+	b := &Side{Key: domain.BattleWinnerTeamB}
+
+	result := Simulate(a, b, 3)
+
+	assert.Equal(t, domain.BattleWinnerTeamA, result.Winner)
+	assert.Equal(t, 0, result.Turns)
+}
+
+func TestBestMove(t *testing.T) {
+	attacker := damage.Combatant{Level: 50, Types: []string{"water"}, Stats: balanced(100, 100)}
+	fire := damage.Combatant{Level: 50, Types: []string{"fire"}, Stats: balanced(100, 100)}
+	ground := damage.Combatant{Level: 50, Types: []string{"ground"}, Stats: balanced(100, 100)}
+
+	tests := []struct {
+		name     string
+		defender damage.Combatant
+		moves    []domain.Move
+		expected string
+	}{
+		{name: "prefers super effective STAB", defender: fire, moves: []domain.Move{tackle, surf, thunderbolt}, expected: "surf"},
+		{name: "weighs accuracy", defender: fire, moves: []domain.Move{hydroPump, surf}, expected: "surf"},
+		{name: "avoids immunity", defender: ground, moves: []domain.Move{thunderbolt, tackle}, expected: "tackle"},
+		{name: "falls back to struggle", defender: fire, moves: []domain.Move{{Name: "growl", Category: domain.CategoryStatus}}, expected: "struggle"},
+		{name: "no moves", defender: fire, moves: nil, expected: "struggle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BestMove(attacker, tt.defender, tt.moves).Name)
+		})
+	}
+}
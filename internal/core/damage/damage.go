@@ -0,0 +1,193 @@
+// Package damage implements the main-series damage formula as pure functions.
+package damage
+
+import (
+	"fmt"
+	"math"
+	"pokemon-api/internal/core/domain"
+	"strings"
+)
+
+// RollCount is the number of random rolls, from 85% to 100% of the modified damage
+const RollCount = 16
+
+// Combatant is a Pokemon as it stands in battle: its level, types and actual (not base) stats
+type Combatant struct {
+	Level int
+	Types []string
+	Stats domain.StatSpread
+}
+
+// NewCombatant computes a combatant's actual stats from its base stats and investment
+func NewCombatant(types []string, base domain.StatSpread, level int, nature domain.Nature, ivs, evs domain.StatSpread) Combatant {
+	return Combatant{
+		Level: level,
+		Types: types,
+		Stats: domain.CalculateStats(base, ivs, evs, level, nature),
+	}
+}
+
+// HasType reports whether the combatant has the given type
+func (c Combatant) HasType(name string) bool {
+	for _, t := range c.Types {
+		if strings.EqualFold(t, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Calculate returns every damage roll of move from attacker against defender
+func Calculate(attacker, defender Combatant, move domain.Move, mods domain.DamageModifiers) domain.DamageResult {
+	result := domain.DamageResult{
+		Move:       move,
+		DefenderHP: defender.Stats.HP,
+		Rolls:      make([]int, 0, RollCount),
+	}
+
+	result.Effectiveness = domain.TypeEffectiveness(move.Type, defender.Types...)
+	result.STAB = attacker.HasType(move.Type)
+	if mods.STAB != nil {
+		result.STAB = *mods.STAB
+	}
+
+	base := baseDamage(attacker, defender, move)
+	for roll := 85; roll <= 100; roll++ {
+		result.Rolls = append(result.Rolls, applyModifiers(base, roll, move, mods, result.STAB, result.Effectiveness))
+	}
+
+	result.Min = result.Rolls[0]
+	result.Max = result.Rolls[len(result.Rolls)-1]
+	if defender.Stats.HP > 0 {
+		result.MinPercent = percent(result.Min, defender.Stats.HP)
+		result.MaxPercent = percent(result.Max, defender.Stats.HP)
+	}
+	result.KOChance = KOChance(result.Rolls, defender.Stats.HP)
+	result.Description = describe(result.Rolls, defender.Stats.HP)
+	return result
+}
+
+// KOChance returns the fraction of rolls that deal at least hp damage
+func KOChance(rolls []int, hp int) float64 {
+	if len(rolls) == 0 {
+		return 0
+	}
+	kos := 0
+	for _, roll := range rolls {
+		if roll >= hp {
+			kos++
+		}
+	}
+	return float64(kos) / float64(len(rolls))
+}
+
+// baseDamage computes the level, power and attack/defense part of the formula
+func baseDamage(attacker, defender Combatant, move domain.Move) int {
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.Category == domain.CategorySpecial {
+		attack, defense = attacker.Stats.SpAttack, defender.Stats.SpDefense
+	}
+	if defense < 1 {
+		defense = 1
+	}
+
+	levelFactor := 2*attacker.Level/5 + 2
+	return levelFactor*move.Power*attack/defense/50 + 2
+}
+
+// applyModifiers applies the modifiers in the order the games do, truncating after each step
+func applyModifiers(base, roll int, move domain.Move, mods domain.DamageModifiers, stab bool, effectiveness float64) int {
+	damage := base
+
+	switch weatherMultiplier(mods.Weather, move.Type) {
+	case 1.5:
+		damage = pokeRound(float64(damage) * 1.5)
+	case 0.5:
+		damage = pokeRound(float64(damage) * 0.5)
+	}
+
+	if mods.Critical {
+		damage = damage * 3 / 2
+	}
+
+	damage = damage * roll / 100
+
+	if stab {
+		damage = pokeRound(float64(damage) * 1.5)
+	}
+
+	damage = int(float64(damage) * effectiveness)
+	if effectiveness == 0 {
+		return 0
+	}
+
+	if mods.Burned && move.Category == domain.CategoryPhysical {
+		damage = damage / 2
+	}
+
+	// Critical hits bypass Reflect and Light Screen
+	if mods.Screen && !mods.Critical {
+		damage = pokeRound(float64(damage) * 0.5)
+	}
+
+	if damage < 1 {
+		damage = 1
+	}
+	return damage
+}
+
+func weatherMultiplier(weather, moveType string) float64 {
+	switch strings.ToLower(weather) {
+	case "sun", "harsh-sunlight":
+		switch moveType {
+		case "fire":
+			return 1.5
+		case "water":
+			return 0.5
+		}
+	case "rain":
+		switch moveType {
+		case "water":
+			return 1.5
+		case "fire":
+			return 0.5
+		}
+	}
+	return 1
+}
+
+// pokeRound rounds to the nearest integer, rounding halves down as the games do
+func pokeRound(value float64) int {
+	if value-math.Floor(value) > 0.5 {
+		return int(math.Ceil(value))
+	}
+	return int(math.Floor(value))
+}
+
+func percent(damage, hp int) float64 {
+	return math.Round(float64(damage)*1000/float64(hp)) / 10
+}
+
+// describe summarises the rolls the way damage calculators usually do, e.g. "guaranteed 2HKO"
+func describe(rolls []int, hp int) string {
+	min, max := rolls[0], rolls[len(rolls)-1]
+	if max == 0 {
+		return "no damage"
+	}
+	if hp <= 0 {
+		return "guaranteed OHKO"
+	}
+
+	if chance := KOChance(rolls, hp); chance == 1 {
+		return "guaranteed OHKO"
+	} else if chance > 0 {
+		return fmt.Sprintf("%.1f%% chance to OHKO", chance*100)
+	}
+
+	best := (hp + max - 1) / max
+	worst := (hp + min - 1) / min
+	if best == worst {
+		return fmt.Sprintf("guaranteed %dHKO", best)
+	}
+	return fmt.Sprintf("possible %dHKO", best)
+}
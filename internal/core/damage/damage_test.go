@@ -0,0 +1,268 @@
+package damage
+
+import (
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculate(t *testing.T) {
+	noStab := false
+	attacker := func(types ...string) Combatant {
+		return Combatant{Level: 100, Types: types, Stats: domain.StatSpread{HP: 300, Attack: 200, SpAttack: 100}}
+	}
+	defender := func(hp int, types ...string) Combatant {
+		return Combatant{Level: 100, Types: types, Stats: domain.StatSpread{HP: hp, Defense: 200, SpDefense: 200}}
+	}
+	surf := domain.Move{Name: "surf", Type: "water", Power: 100, Category: domain.CategoryPhysical}
+
+	tests := []struct {
+		name          string
+		attacker      Combatant
+		defender      Combatant
+		move          domain.Move
+		mods          domain.DamageModifiers
+		expectedMin   int
+		expectedMax   int
+		expectedKO    float64
+		expectedDesc  string
+		expectedEff   float64
+		expectedSTAB  bool
+		expectedRolls []int
+	}{
+		{
+			name:         "neutral hit without STAB",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			expectedMin:  73,
+			expectedMax:  86,
+			expectedDesc: "guaranteed 2HKO",
+			expectedEff:  1,
+			expectedRolls: []int{
+				73, 73, 74, 75, 76, 77, 78, 79, 79, 80, 81, 82, 83, 84, 85, 86,
+			},
+		},
+		{
+			name:         "partial OHKO chance",
+			attacker:     attacker("fire"),
+			defender:     defender(80, "normal"),
+			move:         surf,
+			expectedMin:  73,
+			expectedMax:  86,
+			expectedKO:   7.0 / 16,
+			expectedDesc: "43.8% chance to OHKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "STAB derived from attacker types",
+			attacker:     attacker("water"),
+			defender:     defender(300, "normal"),
+			move:         surf,
+			expectedMin:  109,
+			expectedMax:  129,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  1,
+			expectedSTAB: true,
+		},
+		{
+			name:         "STAB forced off",
+			attacker:     attacker("water"),
+			defender:     defender(300, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{STAB: &noStab},
+			expectedMin:  73,
+			expectedMax:  86,
+			expectedDesc: "possible 4HKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "STAB and super effective",
+			attacker:     attacker("water"),
+			defender:     defender(250, "fire"),
+			move:         surf,
+			expectedMin:  218,
+			expectedMax:  258,
+			expectedKO:   3.0 / 16,
+			expectedDesc: "18.8% chance to OHKO",
+			expectedEff:  2,
+			expectedSTAB: true,
+		},
+		{
+			name:         "immune",
+			attacker:     attacker("electric"),
+			defender:     defender(100, "ground"),
+			move:         domain.Move{Name: "thunderbolt", Type: "electric", Power: 90, Category: domain.CategorySpecial},
+			expectedDesc: "no damage",
+			expectedEff:  0,
+			expectedSTAB: true,
+		},
+		{
+			name:         "special move uses special stats",
+			attacker:     attacker("normal"),
+			defender:     defender(100, "normal"),
+			move:         domain.Move{Name: "scald", Type: "water", Power: 100, Category: domain.CategorySpecial},
+			expectedMin:  37,
+			expectedMax:  44,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "critical hit",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{Critical: true},
+			expectedMin:  109,
+			expectedMax:  129,
+			expectedKO:   1,
+			expectedDesc: "guaranteed OHKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "rain boosts water",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{Weather: "rain"},
+			expectedMin:  109,
+			expectedMax:  129,
+			expectedKO:   1,
+			expectedDesc: "guaranteed OHKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "sun weakens water",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{Weather: "sun"},
+			expectedMin:  36,
+			expectedMax:  43,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "burn halves physical damage",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{Burned: true},
+			expectedMin:  36,
+			expectedMax:  43,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "burn does not affect special moves",
+			attacker:     attacker("normal"),
+			defender:     defender(100, "normal"),
+			move:         domain.Move{Name: "scald", Type: "water", Power: 100, Category: domain.CategorySpecial},
+			mods:         domain.DamageModifiers{Burned: true},
+			expectedMin:  37,
+			expectedMax:  44,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "screen halves damage",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{Screen: true},
+			expectedMin:  36,
+			expectedMax:  43,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "critical hit ignores screen",
+			attacker:     attacker("fire"),
+			defender:     defender(100, "normal"),
+			move:         surf,
+			mods:         domain.DamageModifiers{Critical: true, Screen: true},
+			expectedMin:  109,
+			expectedMax:  129,
+			expectedKO:   1,
+			expectedDesc: "guaranteed OHKO",
+			expectedEff:  1,
+		},
+		{
+			name:         "resisted hit never drops below one",
+			attacker:     Combatant{Level: 1, Stats: domain.StatSpread{Attack: 5}},
+			defender:     Combatant{Level: 100, Types: []string{"rock", "steel"}, Stats: domain.StatSpread{HP: 3, Defense: 500}},
+			move:         domain.Move{Name: "tackle", Type: "normal", Power: 40, Category: domain.CategoryPhysical},
+			expectedMin:  1,
+			expectedMax:  1,
+			expectedDesc: "guaranteed 3HKO",
+			expectedEff:  0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Calculate(tt.attacker, tt.defender, tt.move, tt.mods)
+
+			assert.Len(t, result.Rolls, RollCount)
+			assert.Equal(t, tt.expectedMin, result.Min)
+			assert.Equal(t, tt.expectedMax, result.Max)
+			assert.InDelta(t, tt.expectedKO, result.KOChance, 0.0001)
+			assert.Equal(t, tt.expectedDesc, result.Description)
+			assert.Equal(t, tt.expectedEff, result.Effectiveness)
+			assert.Equal(t, tt.expectedSTAB, result.STAB)
+			assert.Equal(t, tt.defender.Stats.HP, result.DefenderHP)
+			if tt.expectedRolls != nil {
+				assert.Equal(t, tt.expectedRolls, result.Rolls)
+			}
+		})
+	}
+}
+
+func TestCalculate_Percentages(t *testing.T) {
+	attacker := Combatant{Level: 100, Stats: domain.StatSpread{Attack: 200}}
+	defender := Combatant{Level: 100, Types: []string{"normal"}, Stats: domain.StatSpread{HP: 300, Defense: 200}}
+	move := domain.Move{Name: "surf", Type: "water", Power: 100, Category: domain.CategoryPhysical}
+
+	result := Calculate(attacker, defender, move, domain.DamageModifiers{})
+
+	assert.Equal(t, 24.3, result.MinPercent)
+	assert.Equal(t, 28.7, result.MaxPercent)
+}
+
+func TestKOChance(t *testing.T) {
+	tests := []struct {
+		name     string
+		rolls    []int
+		hp       int
+		expected float64
+	}{
+		{name: "no rolls", rolls: nil, hp: 10, expected: 0},
+		{name: "all rolls", rolls: []int{10, 11}, hp: 10, expected: 1},
+		{name: "half rolls", rolls: []int{9, 10}, hp: 10, expected: 0.5},
+		{name: "no KO", rolls: []int{1, 2}, hp: 10, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, KOChance(tt.rolls, tt.hp))
+		})
+	}
+}
+
+func TestPokeRound(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected int
+	}{
+		{109.5, 109},
+		{109.51, 110},
+		{36.5, 36},
+		{129.0, 129},
+		{0.4, 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, pokeRound(tt.input))
+	}
+}
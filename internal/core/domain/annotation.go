@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxTagLength and MaxNoteLength bound user annotations, in characters
+const (
+	MaxTagLength  = 50
+	MaxNoteLength = 10000
+)
+
+// NormalizeTag lowercases a tag and collapses its whitespace, so "OU  Viable" and "ou viable" are the same tag
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Tag is a label such as "ou viable" that users attach to Pokemon; a tenant's users share its tags
+type Tag struct {
+	ID       uint   `json:"-" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;uniqueIndex:idx_tags_tenant_name,priority:1"`
+
+	Name string `json:"name" gorm:"size:50;uniqueIndex:idx_tags_tenant_name,priority:2;not null"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PokemonTag attaches a tag to a Pokemon; a Pokemon has many tags and a tag many Pokemon
+type PokemonTag struct {
+	PokemonID uint `gorm:"primaryKey;autoIncrement:false"`
+	TagID     uint `gorm:"primaryKey;autoIncrement:false;index"`
+
+	CreatedAt time.Time
+}
+
+// PokemonFavorite marks a Pokemon as one of a user's favorites; favorites are personal, unlike tags and notes
+type PokemonFavorite struct {
+	ID       uint   `json:"-" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	PokemonID uint `json:"pokemon_id" gorm:"uniqueIndex:idx_pokemon_favorites_pokemon_subject;not null"`
+	// Subject is the principal subject of the user who favorited the Pokemon
+	Subject string `json:"subject" gorm:"uniqueIndex:idx_pokemon_favorites_pokemon_subject;not null"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PokemonNote is a free-text markdown note on a Pokemon, shared by the tenant's users
+type PokemonNote struct {
+	PokemonID uint   `json:"pokemon_id" gorm:"primaryKey;autoIncrement:false"`
+	TenantID  string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	Body string `json:"body" gorm:"type:text;not null"`
+	// UpdatedBy is the subject of the principal that last edited the note
+	UpdatedBy string `json:"updated_by,omitempty"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PokemonNoteRequest struct {
+	// Body is markdown; an empty body removes the note
+	Body string `json:"body"`
+}
+
+// PokemonAnnotations gathers a Pokemon's tags and note, and whether the caller favorited it
+type PokemonAnnotations struct {
+	PokemonID uint         `json:"pokemon_id"`
+	Tags      []string     `json:"tags"`
+	Favorite  bool         `json:"favorite"`
+	Note      *PokemonNote `json:"note,omitempty"`
+}
+
+// TagCount is a tag in the tag cloud with the number of live Pokemon carrying it
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// PokemonFilter narrows a Pokemon listing to annotated Pokemon
+type PokemonFilter struct {
+	// Tags lists tags a Pokemon must all carry
+	Tags []string
+	// FavoriteOf, when set, keeps only the favorites of the user with this subject
+	FavoriteOf string
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// Actions recorded in a Pokemon's history
+const (
+	AuditActionCreated  = "created"
+	AuditActionUpdated  = "updated"
+	AuditActionDeleted  = "deleted"
+	AuditActionRestored = "restored"
+	// AuditActionPurged is the last entry of a Pokemon removed from the trash for good
+	AuditActionPurged = "purged"
+)
+
+// Sources of a change: a client request, a background job such as the trash purge, or a bulk import
+const (
+	AuditSourceAPI    = "api"
+	AuditSourceJob    = "job"
+	AuditSourceImport = "import"
+)
+
+// Attribution says who made a change, in which request and through which source
+type Attribution struct {
+	// Actor is the subject of the principal that made the change, empty for anonymous callers
+	Actor     string
+	RequestID string
+	Source    string
+}
+
+// PokemonAudit is one entry of a Pokemon's append-only history, written in the same transaction as the change
+type PokemonAudit struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	TenantID  string `json:"-" gorm:"size:64;not null;default:default"`
+	PokemonID uint   `json:"pokemon_id" gorm:"index:idx_pokemon_audit_pokemon_time;not null"`
+	Action    string `json:"action" gorm:"not null"`
+	// Before is nil for creations and After is nil for deletions
+	Before *Pokemon `json:"before" gorm:"serializer:json"`
+	After  *Pokemon `json:"after" gorm:"serializer:json"`
+
+	Actor     string    `json:"actor,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_pokemon_audit_pokemon_time"`
+}
+
+// TableName keeps the table name singular, as a log of changes
+func (PokemonAudit) TableName() string {
+	return "pokemon_audit"
+}
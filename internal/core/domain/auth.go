@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+// Roles, from least to most privileged; each role includes the permissions of the roles before it
+const (
+	RoleReader = "reader"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// Authentication methods
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+)
+
+var roleRanks = map[string]int{
+	RoleReader: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// IsValidRole reports whether name is one of the known roles
+func IsValidRole(name string) bool {
+	_, ok := roleRanks[name]
+	return ok
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string   `json:"subject"`
+	Method  string   `json:"method"`
+	Roles   []string `json:"roles"`
+	// TenantID is the workspace the credential is bound to; credentials without one act in the default tenant
+	TenantID string `json:"tenant_id,omitempty"`
+	// Operator credentials run the deployment and may act in any tenant
+	Operator bool `json:"operator,omitempty"`
+}
+
+// HasRole reports whether any of the principal's roles grants the required role
+func (p *Principal) HasRole(required string) bool {
+	for _, role := range p.Roles {
+		if rank, ok := roleRanks[role]; ok && rank >= roleRanks[required] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOperator reports whether the principal runs the deployment rather than belonging to one tenant
+func (p *Principal) IsOperator() bool {
+	return p.Operator
+}
+
+// ClientKey identifies the principal's rate limit buckets, which are shared by REST, GraphQL and gRPC
+func (p *Principal) ClientKey() string {
+	return p.Method + ":" + p.Subject
+}
+
+// HomeTenant returns the tenant a non-operator principal acts in: its bound tenant, or the default tenant
+func (p *Principal) HomeTenant() string {
+	if p.TenantID == "" {
+		return DefaultTenantID
+	}
+	return p.TenantID
+}
+
+// APIKey is a static credential; only the SHA-256 hash of the key is stored
+type APIKey struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	Name    string `json:"name" gorm:"unique;not null"`
+	Prefix  string `json:"prefix"`
+	KeyHash string `json:"-" gorm:"uniqueIndex;not null"`
+	// Scopes are the roles the key grants
+	Scopes []string `json:"scopes" gorm:"serializer:json"`
+	// TenantID binds the key to one workspace; keys without one act in the default tenant unless they are operator keys
+	TenantID string `json:"tenant_id,omitempty" gorm:"size:64"`
+	// Operator keys may act in any tenant and manage the deployment; they are never bound to a tenant
+	Operator bool `json:"operator" gorm:"not null;default:false"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type APIKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+	// TenantID is optional; leaving it out binds the key to the default tenant
+	TenantID string `json:"tenant_id,omitempty"`
+	// Operator issues a key that may act in any tenant; it cannot be combined with TenantID
+	Operator bool `json:"operator,omitempty"`
+}
+
+// CreatedAPIKey is returned once when a key is issued; the plaintext key cannot be retrieved again
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+const (
+	BattleFormatSingles = "6v6"
+	BattleFormat1v1     = "1v1"
+
+	BattleWinnerTeamA = "team_a"
+	BattleWinnerTeamB = "team_b"
+	BattleDraw        = "draw"
+)
+
+// Battle event types
+const (
+	BattleEventSwitch = "switch"
+	BattleEventMove   = "move"
+	BattleEventMiss   = "miss"
+	BattleEventFaint  = "faint"
+	BattleEventEnd    = "end"
+)
+
+type Battle struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	TeamAID uint   `json:"team_a_id" gorm:"index;not null"`
+	TeamBID uint   `json:"team_b_id" gorm:"index;not null"`
+	Format  string `json:"format"`
+	Level   int    `json:"level"`
+	Seed    int64  `json:"seed"`
+
+	Winner string        `json:"winner"`
+	Turns  int           `json:"turns"`
+	Log    []BattleEvent `json:"log" gorm:"serializer:json"`
+	// Moves is the move data the battle was simulated with, by the name on the team, so the record does not depend
+	// on PokeAPI later
+	Moves map[string]Move `json:"moves" gorm:"serializer:json"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BattleEvent is one entry of the turn-by-turn battle log
+type BattleEvent struct {
+	Turn    int    `json:"turn"`
+	Type    string `json:"type"`
+	Side    string `json:"side,omitempty"`
+	Pokemon string `json:"pokemon,omitempty"`
+
+	Move          string  `json:"move,omitempty"`
+	Target        string  `json:"target,omitempty"`
+	Damage        int     `json:"damage,omitempty"`
+	Critical      bool    `json:"critical,omitempty"`
+	Effectiveness float64 `json:"effectiveness,omitempty"`
+	RemainingHP   int     `json:"remaining_hp,omitempty"`
+
+	Winner string `json:"winner,omitempty"`
+}
+
+type BattleRequest struct {
+	TeamAID uint   `json:"team_a_id" binding:"required"`
+	TeamBID uint   `json:"team_b_id" binding:"required"`
+	Format  string `json:"format,omitempty"`
+	Level   int    `json:"level,omitempty"`
+	// Seed makes the battle reproducible; a random seed is chosen and returned when omitted
+	Seed *int64 `json:"seed,omitempty"`
+}
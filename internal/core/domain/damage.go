@@ -0,0 +1,75 @@
+package domain
+
+const (
+	CategoryPhysical = "physical"
+	CategorySpecial  = "special"
+	CategoryStatus   = "status"
+)
+
+type Move struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Power    int    `json:"power"`
+	Category string `json:"category"`
+	// Accuracy is a percentage; zero means the move never misses
+	Accuracy int `json:"accuracy,omitempty"`
+}
+
+// DamageModifiers are the battle conditions applied on top of the base damage
+type DamageModifiers struct {
+	// STAB forces same-type attack bonus on or off; when omitted it is derived from the attacker's types
+	STAB     *bool  `json:"stab,omitempty"`
+	Weather  string `json:"weather,omitempty"`
+	Critical bool   `json:"critical"`
+	Burned   bool   `json:"burned"`
+	Screen   bool   `json:"screen"`
+}
+
+// CombatantSpec describes one side of a calculation, either a stored Pokemon or inline types and base stats
+type CombatantSpec struct {
+	PokemonID uint        `json:"pokemon_id,omitempty"`
+	Types     []string    `json:"types,omitempty"`
+	BaseStats *StatSpread `json:"base_stats,omitempty"`
+
+	Level  int         `json:"level,omitempty"`
+	Nature string      `json:"nature,omitempty"`
+	IVs    *StatSpread `json:"ivs,omitempty"`
+	EVs    StatSpread  `json:"evs"`
+}
+
+type DamageCalcRequest struct {
+	Attacker  CombatantSpec   `json:"attacker"`
+	Defender  CombatantSpec   `json:"defender"`
+	Move      Move            `json:"move"`
+	Modifiers DamageModifiers `json:"modifiers"`
+}
+
+type DamageResult struct {
+	Move       Move    `json:"move"`
+	Rolls      []int   `json:"rolls"`
+	Min        int     `json:"min"`
+	Max        int     `json:"max"`
+	DefenderHP int     `json:"defender_hp"`
+	MinPercent float64 `json:"min_percent"`
+	MaxPercent float64 `json:"max_percent"`
+
+	Effectiveness float64 `json:"effectiveness"`
+	STAB          bool    `json:"stab"`
+
+	// KOChance is the probability that a single hit knocks out the defender from full HP
+	KOChance    float64 `json:"ko_chance"`
+	Description string  `json:"description"`
+}
+
+type ExternalMoveResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Power    *int   `json:"power"`
+	Accuracy *int   `json:"accuracy"`
+	Type     struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	DamageClass struct {
+		Name string `json:"name"`
+	} `json:"damage_class"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Event types emitted when the Pokemon collection changes
+const (
+	EventPokemonCreated  = "pokemon.created"
+	EventPokemonUpdated  = "pokemon.updated"
+	EventPokemonDeleted  = "pokemon.deleted"
+	EventPokemonRestored = "pokemon.restored"
+)
+
+// EventTypes lists every event type clients can filter on
+var EventTypes = []string{EventPokemonCreated, EventPokemonUpdated, EventPokemonDeleted, EventPokemonRestored}
+
+// IsValidEventType reports whether name is one of EventTypes
+func IsValidEventType(name string) bool {
+	for _, eventType := range EventTypes {
+		if eventType == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a change to the collection; IDs increase, so clients resume a stream from the last ID they saw
+type Event struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+	// DedupID is the outbox message's, so a message relayed twice is only logged once
+	DedupID string `json:"dedup_id" gorm:"uniqueIndex;not null"`
+	// TenantID limits the event to subscribers in the same workspace
+	TenantID  string `json:"-" gorm:"size:64;not null;default:default;index"`
+	Type      string `json:"type" gorm:"index;not null"`
+	PokemonID uint   `json:"pokemon_id"`
+	// Pokemon is the record after the change, or as it was before deletion
+	Pokemon   *Pokemon  `json:"pokemon" gorm:"serializer:json"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EventSubscription delivers the stored events a client missed, then new events as they are published.
+// Live is closed if the subscriber falls too far behind; it should reconnect from the last ID it saw.
+type EventSubscription struct {
+	Backlog []*Event
+	Live    <-chan *Event
+	Close   func()
+}
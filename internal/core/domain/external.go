@@ -0,0 +1,32 @@
+package domain
+
+import "fmt"
+
+// ExternalNotFoundError reports a PokeAPI resource that does not exist, such as a misspelt move
+type ExternalNotFoundError struct {
+	// Resource is PokeAPI's resource name, such as "move" or "pokemon-species"
+	Resource string
+	// Identifier is empty when the resource's list was requested
+	Identifier string
+}
+
+func (e *ExternalNotFoundError) Error() string {
+	if e.Identifier == "" {
+		return e.Resource + " list not found"
+	}
+	return fmt.Sprintf("%s '%s' not found", e.Resource, e.Identifier)
+}
+
+// ExternalServiceError reports PokeAPI failing to answer: it could not be reached, returned an error status or
+// an unreadable body, or its rate limit ran out
+type ExternalServiceError struct {
+	Err error
+}
+
+func (e *ExternalServiceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExternalServiceError) Unwrap() error {
+	return e.Err
+}
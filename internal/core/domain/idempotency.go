@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord stores the response to a request sent with an Idempotency-Key header so retries can replay it.
+// A record without a status code belongs to a request that is still being processed.
+type IdempotencyRecord struct {
+	ID uint `gorm:"primaryKey"`
+
+	// Scope identifies the client, so different clients may use the same key
+	Scope       string `gorm:"uniqueIndex:idx_idempotency_scope_key;not null"`
+	Key         string `gorm:"uniqueIndex:idx_idempotency_scope_key;not null"`
+	Fingerprint string `gorm:"not null"`
+
+	StatusCode  int
+	ContentType string
+	Body        []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// Completed reports whether the original request has finished and its response was stored
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultLanguage is shown when a Pokemon has no translation in any of the requested languages
+const DefaultLanguage = "en"
+
+// PokemonTranslation is a Pokemon's species name and most recent Pokedex entry in one language
+type PokemonTranslation struct {
+	Name       string `json:"name"`
+	FlavorText string `json:"flavor_text,omitempty"`
+}
+
+// SpeciesName maps a species' name in one language to the PokeAPI name of its default form, so Pokemon can be
+// created and looked up by names such as "ピカチュウ"
+type SpeciesName struct {
+	ID uint `json:"-" gorm:"primaryKey"`
+	// DexNumber is the species' national dex number
+	DexNumber int    `json:"dex_number" gorm:"index;not null"`
+	Language  string `json:"language" gorm:"size:16;uniqueIndex:idx_species_names_language_name,priority:1;not null"`
+	Name      string `json:"name" gorm:"not null"`
+	// NormalizedName is Name normalized with NormalizeLocalizedName, which is what lookups match
+	NormalizedName string `json:"-" gorm:"uniqueIndex:idx_species_names_language_name,priority:2;not null"`
+	// Pokemon is the PokeAPI name of the species' default form, such as "deoxys-normal"
+	Pokemon string `json:"pokemon" gorm:"not null"`
+}
+
+// SyncedSpecies marks a species whose names have been fetched, so a species PokeAPI names in no language is not
+// fetched again on every sync
+type SyncedSpecies struct {
+	DexNumber int `gorm:"primaryKey;autoIncrement:false"`
+	SyncedAt  time.Time
+}
+
+// NormalizeLocalizedName folds width and case and collapses whitespace, so "ﾋﾟｶﾁｭｳ" matches "ピカチュウ" and
+// "PIKACHU" matches "Pikachu"
+func NormalizeLocalizedName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(norm.NFKC.String(name)), " "))
+}
+
+// Localize shows the Pokemon's name and flavor text in the first of languages it has a translation for, falling
+// back to English, and returns the language shown. Pokemon stored before translations were kept are left as they
+// are, and "" is returned.
+func (p *Pokemon) Localize(languages []string) string {
+	language := p.translationLanguage(languages)
+	if language == "" {
+		return ""
+	}
+
+	translation := p.Translations[language]
+	p.LocalizedName = translation.Name
+	// A language can have a name for the species without a Pokedex entry; the English entry is kept then
+	if translation.FlavorText != "" {
+		p.FlavorText = translation.FlavorText
+	}
+	p.Language = language
+	return language
+}
+
+// translationLanguage picks the translation for the first of languages that matches one exactly, by its primary
+// subtag ("es-mx" matches "es"), or by a translation's primary subtag ("zh" matches "zh-hant")
+func (p *Pokemon) translationLanguage(languages []string) string {
+	available := make([]string, 0, len(p.Translations))
+	for language := range p.Translations {
+		available = append(available, language)
+	}
+	sort.Strings(available)
+
+	for _, requested := range languages {
+		requested = strings.ToLower(requested)
+		primary, _, _ := strings.Cut(requested, "-")
+		if _, ok := p.Translations[requested]; ok {
+			return requested
+		}
+		if _, ok := p.Translations[primary]; ok {
+			return primary
+		}
+		for _, language := range available {
+			if strings.HasPrefix(language, primary+"-") {
+				return language
+			}
+		}
+	}
+
+	if _, ok := p.Translations[DefaultLanguage]; ok {
+		return DefaultLanguage
+	}
+	return ""
+}
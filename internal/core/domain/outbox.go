@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// OutboxMessage is a change event written in the same transaction as the change, so it exists exactly
+// when the change was committed. The outbox relay publishes it afterwards, at least once.
+type OutboxMessage struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+	// DedupID is the same every time the message is published, so consumers can drop repeats
+	DedupID   string `json:"dedup_id" gorm:"uniqueIndex;not null"`
+	TenantID  string `json:"-" gorm:"size:64;not null;default:default"`
+	Type      string `json:"type" gorm:"not null"`
+	PokemonID uint   `json:"pokemon_id"`
+	// Pokemon is the record after the change, or as it was before deletion
+	Pokemon *Pokemon `json:"pokemon" gorm:"serializer:json"`
+
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NationalDexSize is the number of species in the national Pokedex, up to Generation IX
+const NationalDexSize = 1025
+
+// generationEnds holds the last national dex number introduced by each generation
+var generationEnds = []int{151, 251, 386, 493, 649, 721, 809, 905, 1025}
+
+// Generation returns the generation that introduced a national dex number, or 0 if it is out of range
+func Generation(dexNumber int) int {
+	if dexNumber < 1 {
+		return 0
+	}
+	for i, end := range generationEnds {
+		if dexNumber <= end {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// DexEntry is a species in the national Pokedex
+type DexEntry struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+}
+
+// PokedexFlag records whether a trainer has seen or caught a species
+type PokedexFlag struct {
+	ID       uint   `json:"-" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	TrainerID uint `json:"trainer_id" gorm:"uniqueIndex:idx_pokedex_flags_trainer_dex;not null"`
+	DexNumber int  `json:"dex_number" gorm:"uniqueIndex:idx_pokedex_flags_trainer_dex;not null"`
+	Seen      bool `json:"seen"`
+	Caught    bool `json:"caught"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PokedexFlagRequest struct {
+	Seen   *bool `json:"seen,omitempty"`
+	Caught *bool `json:"caught,omitempty"`
+}
+
+// PokedexCompletion compares the national dex against the catalog, or against a trainer's caught flags
+type PokedexCompletion struct {
+	TrainerID uint `json:"trainer_id,omitempty"`
+
+	Total int `json:"total"`
+	// Registered counts species stored in the catalog, or caught by the trainer
+	Registered int     `json:"registered"`
+	Seen       int     `json:"seen,omitempty"`
+	Percent    float64 `json:"percent"`
+
+	Generations []GenerationCompletion `json:"generations"`
+}
+
+type GenerationCompletion struct {
+	Generation int        `json:"generation"`
+	Total      int        `json:"total"`
+	Registered int        `json:"registered"`
+	Seen       int        `json:"seen,omitempty"`
+	Percent    float64    `json:"percent"`
+	Missing    []DexEntry `json:"missing"`
+}
+
+type ExternalPokemonListResponse struct {
+	Count   int `json:"count"`
+	Results []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+// DexEntries returns the listed species with their dex numbers, read from each resource URL
+func (r *ExternalPokemonListResponse) DexEntries() []DexEntry {
+	entries := make([]DexEntry, 0, len(r.Results))
+	for _, result := range r.Results {
+		url := strings.TrimSuffix(result.URL, "/")
+		number, err := strconv.Atoi(url[strings.LastIndex(url, "/")+1:])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, DexEntry{Number: number, Name: result.Name})
+	}
+	return entries
+}
@@ -1,11 +1,23 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// Pokemon is a species record in the catalog; individual Pokemon owned by trainers are OwnedPokemon
 type Pokemon struct {
 	ID uint `json:"id" gorm:"primaryKey"`
+	// TenantID is the workspace the Pokemon belongs to; it is never part of the API
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;uniqueIndex:idx_pokemons_tenant_name_live,priority:1,where:deleted_at IS NULL"`
+	// DexNumber is the species' national Pokedex number; alternate forms share their species' number
+	DexNumber int `json:"dex_number,omitempty" gorm:"index"`
 
-	Name  string `json:"name" gorm:"unique;not null"`
+	// Name is unique among the tenant's live Pokemon; a deleted Pokemon's name can be reused, which blocks restoring it
+	Name  string `json:"name" gorm:"uniqueIndex:idx_pokemons_tenant_name_live,priority:2;not null"`
 	Type1 string `json:"type1" binding:"required"`
 	Type2 string `json:"type2,omitempty"`
 
@@ -13,14 +25,48 @@ type Pokemon struct {
 	Weight  int `json:"weight"`
 	BaseExp int `json:"base_experience"`
 
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"special_attack"`
+	SpDefense int `json:"special_defense"`
+	Speed     int `json:"speed"`
+
+	// Abilities, FlavorText and Generation come from PokeAPI's Pokemon and species data and are searchable
+	Abilities  []string `json:"abilities,omitempty" gorm:"serializer:json;type:text"`
+	FlavorText string   `json:"flavor_text,omitempty"`
+	Generation int      `json:"generation,omitempty"`
+	// Sprites are PokeAPI's image URLs; GET /pokemon/{id}/sprite serves the images themselves
+	Sprites PokemonSprites `json:"sprites" gorm:"embedded;embeddedPrefix:sprite_"`
+	// Translations holds the species name and flavor text by PokeAPI language, such as "ja-hrkt"
+	Translations map[string]PokemonTranslation `json:"-" gorm:"serializer:json;type:text"`
+	// LocalizedName and Language are set by Localize for a response and never stored
+	LocalizedName string `json:"localized_name,omitempty" gorm:"-"`
+	Language      string `json:"language,omitempty" gorm:"-"`
+
+	// CreatedBy is the subject of the principal that added the Pokemon
+	CreatedBy string `json:"created_by,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is set while the Pokemon is in the trash; deleted Pokemon are left out of every query but Trash.
+	// Only the trash listing shows it, as DeletedPokemon.
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// DeletedPokemon is a Pokemon in the trash as the API lists it, with when it was deleted
+type DeletedPokemon struct {
+	*Pokemon
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
 type CreatePokemonRequest struct {
 	Name  string `json:"name" binding:"required"`
 	Type1 string `json:"type1" binding:"required"`
 	Type2 string `json:"type2,omitempty"`
+	// CreatedBy and RequestID are set from the authenticated principal and request, never from the request body
+	CreatedBy string `json:"-"`
+	RequestID string `json:"-"`
 }
 
 type FlexiblePokemonRequest struct {
@@ -28,6 +74,50 @@ type FlexiblePokemonRequest struct {
 	Type1   string                 `json:"type1" binding:"required"`
 	Type2   string                 `json:"type2,omitempty"`
 	Pokemon map[string]interface{} `json:"pokemon,omitempty"`
+	// CreatedBy and RequestID are set from the authenticated principal and request, never from the request body
+	CreatedBy string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// UnknownPokemonError reports a name that is not a PokeAPI Pokemon, with the closest known names first
+type UnknownPokemonError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *UnknownPokemonError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown pokemon %q", e.Name)
+	}
+	return fmt.Sprintf("unknown pokemon %q, did you mean %s?", e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+// UpdatePokemonRequest changes a stored Pokemon's types; everything else comes from PokeAPI
+type UpdatePokemonRequest struct {
+	Type1 string `json:"type1" binding:"required"`
+	Type2 string `json:"type2,omitempty"`
+}
+
+// PokemonPageQuery selects stored Pokemon in ID order; zero-valued fields do not filter
+type PokemonPageQuery struct {
+	// Types keeps Pokemon whose first or second type is any of these
+	Types            []string
+	NameContains     string
+	CreatedBy        string
+	MinBaseStatTotal int
+	// After is the ID of the last Pokemon on the previous page
+	After uint
+	// Limit caps the page size; zero returns every match after the cursor
+	Limit int
+}
+
+// PokemonPage is one page of a PokemonPageQuery
+type PokemonPage struct {
+	Pokemon []*Pokemon
+	// Total counts every Pokemon matching the filter, on any page
+	Total int
+	// HasMore reports matches after the last Pokemon on the page
+	HasMore bool
 }
 
 type ExternalPokemonResponse struct {
@@ -41,4 +131,166 @@ type ExternalPokemonResponse struct {
 			Name string `json:"name"`
 		} `json:"type"`
 	} `json:"types"`
+	Stats []struct {
+		BaseStat int `json:"base_stat"`
+		Stat     struct {
+			Name string `json:"name"`
+		} `json:"stat"`
+	} `json:"stats"`
+	Abilities []struct {
+		Ability struct {
+			Name string `json:"name"`
+		} `json:"ability"`
+	} `json:"abilities"`
+	Species struct {
+		Name string `json:"name"`
+	} `json:"species"`
+	Sprites struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+		Other        struct {
+			OfficialArtwork struct {
+				FrontDefault string `json:"front_default"`
+			} `json:"official-artwork"`
+		} `json:"other"`
+	} `json:"sprites"`
+}
+
+// ExternalSpeciesResponse is the part of a PokeAPI species used by the catalog
+type ExternalSpeciesResponse struct {
+	// ID is the national dex number
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []struct {
+		Name     string `json:"name"`
+		Language struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"names"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"flavor_text_entries"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// Types returns the Pokemon's types in slot order, skipping the empty second slot
+func (p *Pokemon) Types() []string {
+	if p.Type2 == "" {
+		return []string{p.Type1}
+	}
+	return []string{p.Type1, p.Type2}
+}
+
+// BaseStats returns the six base stats as a StatSpread
+func (p *Pokemon) BaseStats() StatSpread {
+	return StatSpread{
+		HP:        p.HP,
+		Attack:    p.Attack,
+		Defense:   p.Defense,
+		SpAttack:  p.SpAttack,
+		SpDefense: p.SpDefense,
+		Speed:     p.Speed,
+	}
+}
+
+// BaseStatTotal returns the sum of the six base stats
+func (p *Pokemon) BaseStatTotal() int {
+	return p.HP + p.Attack + p.Defense + p.SpAttack + p.SpDefense + p.Speed
+}
+
+// SpriteURLs returns the front sprites and official artwork URLs
+func (r *ExternalPokemonResponse) SpriteURLs() PokemonSprites {
+	return PokemonSprites{
+		Default: r.Sprites.FrontDefault,
+		Shiny:   r.Sprites.FrontShiny,
+		Artwork: r.Sprites.Other.OfficialArtwork.FrontDefault,
+	}
+}
+
+// AbilityNames returns the Pokemon's abilities in slot order
+func (r *ExternalPokemonResponse) AbilityNames() []string {
+	abilities := make([]string, 0, len(r.Abilities))
+	for _, a := range r.Abilities {
+		abilities = append(abilities, a.Ability.Name)
+	}
+	return abilities
+}
+
+// SpeciesName returns the species a Pokemon or form belongs to, such as "deoxys" for "deoxys-normal"
+func (r *ExternalPokemonResponse) SpeciesName() string {
+	if r.Species.Name != "" {
+		return r.Species.Name
+	}
+	return r.Name
+}
+
+// EnglishFlavorText returns the most recent English Pokedex entry on one line, or "" if there is none
+func (r *ExternalSpeciesResponse) EnglishFlavorText() string {
+	return r.Translations()[DefaultLanguage].FlavorText
+}
+
+// Translations returns the species name and most recent Pokedex entry in every language PokeAPI names it in
+func (r *ExternalSpeciesResponse) Translations() map[string]PokemonTranslation {
+	// PokeAPI capitalizes scripts, as in "ja-Hrkt"; languages are kept lowercase so requests match them directly
+	translations := make(map[string]PokemonTranslation, len(r.Names))
+	for _, name := range r.Names {
+		translations[strings.ToLower(name.Language.Name)] = PokemonTranslation{Name: name.Name}
+	}
+	// Entries are oldest first, so later ones overwrite earlier ones
+	for _, entry := range r.FlavorTextEntries {
+		language := strings.ToLower(entry.Language.Name)
+		translation := translations[language]
+		// Entries keep the game's line and page breaks
+		translation.FlavorText = strings.Join(strings.Fields(entry.FlavorText), " ")
+		translations[language] = translation
+	}
+	return translations
+}
+
+// DefaultPokemonName returns the PokeAPI name of the species' default form, such as "deoxys-normal"
+func (r *ExternalSpeciesResponse) DefaultPokemonName() string {
+	for _, variety := range r.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.Name
+		}
+	}
+	return r.Name
+}
+
+// SpeciesNames returns the species' names for the localized name catalog
+func (r *ExternalSpeciesResponse) SpeciesNames() []*SpeciesName {
+	pokemon := r.DefaultPokemonName()
+	speciesNames := make([]*SpeciesName, 0, len(r.Names))
+	for _, name := range r.Names {
+		key := NormalizeLocalizedName(name.Name)
+		if key == "" {
+			continue
+		}
+		speciesNames = append(speciesNames, &SpeciesName{
+			DexNumber:      r.ID,
+			Language:       strings.ToLower(name.Language.Name),
+			Name:           name.Name,
+			NormalizedName: key,
+			Pokemon:        pokemon,
+		})
+	}
+	return speciesNames
+}
+
+// BaseStat returns the value of a PokeAPI stat (e.g. "special-attack") or 0 if absent
+func (r *ExternalPokemonResponse) BaseStat(name string) int {
+	for _, s := range r.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
 }
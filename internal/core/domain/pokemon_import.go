@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxImportRows bounds a single import so a file cannot hold the request open indefinitely
+const MaxImportRows = 5000
+
+// PokemonImportRow is one decoded record of an import file; Error is set when the record could not be parsed
+type PokemonImportRow struct {
+	Row     int
+	Pokemon *Pokemon
+	Error   string
+}
+
+// PokemonImportError reports why a row was rejected
+type PokemonImportError struct {
+	Row   int    `json:"row"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// PokemonImportReport summarizes an import; in a dry run Valid counts the rows that would have been imported
+type PokemonImportReport struct {
+	DryRun   bool                 `json:"dry_run"`
+	Total    int                  `json:"total"`
+	Valid    int                  `json:"valid"`
+	Imported int                  `json:"imported"`
+	Failed   int                  `json:"failed"`
+	Errors   []PokemonImportError `json:"errors"`
+}
+
+// Normalize lowercases the name and types and trims surrounding whitespace, as PokeAPI names are lowercase
+func (p *Pokemon) Normalize() {
+	p.Name = strings.ToLower(strings.TrimSpace(p.Name))
+	p.Type1 = strings.ToLower(strings.TrimSpace(p.Type1))
+	p.Type2 = strings.ToLower(strings.TrimSpace(p.Type2))
+}
+
+// Validate checks the name, types and that no measurement or base stat is negative
+func (p *Pokemon) Validate() error {
+	if p.Name == "" {
+		return errors.New("pokemon name is required")
+	}
+	if !IsValidType(p.Type1) {
+		return errors.New("type1 must be a known type")
+	}
+	if p.Type2 != "" && (!IsValidType(p.Type2) || p.Type2 == p.Type1) {
+		return errors.New("type2 must be a known type different from type1")
+	}
+	for _, value := range []int{p.Height, p.Weight, p.BaseExp, p.HP, p.Attack, p.Defense, p.SpAttack, p.SpDefense, p.Speed} {
+		if value < 0 {
+			return errors.New("height, weight, base experience and stats cannot be negative")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package domain
+
+// ModifiedError reports a conditional update or delete that was refused because the record changed after the
+// version, its UpdatedAt, the change was based on
+type ModifiedError struct {
+	Resource string
+}
+
+func (e *ModifiedError) Error() string {
+	return e.Resource + " has been modified"
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// RateLimit is a token bucket holding up to Burst tokens that refills completely over Period
+type RateLimit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// PerMinute allows n requests per minute, all of which may be used at once
+func PerMinute(n int) RateLimit {
+	return RateLimit{Burst: n, Period: time.Minute}
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available; zero when the request was allowed
+	RetryAfter time.Duration
+}
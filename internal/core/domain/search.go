@@ -0,0 +1,37 @@
+package domain
+
+// PokemonSearchQuery is a full-text search over stored Pokemon, optionally narrowed by facet values
+type PokemonSearchQuery struct {
+	Text       string
+	Type       string
+	Generation int
+	Limit      int
+	Offset     int
+}
+
+// PokemonSearchHit is a Pokemon matching a search; a higher score is a better match
+type PokemonSearchHit struct {
+	Pokemon *Pokemon `json:"pokemon"`
+	Score   float64  `json:"score"`
+}
+
+// FacetCount is how many hits have one value of a facet
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PokemonSearchFacets count every hit of a search, not just the returned page, most common value first
+type PokemonSearchFacets struct {
+	Type       []FacetCount `json:"type"`
+	Generation []FacetCount `json:"generation"`
+}
+
+type PokemonSearchResult struct {
+	Query  string              `json:"query"`
+	Total  int                 `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+	Hits   []PokemonSearchHit  `json:"hits"`
+	Facets PokemonSearchFacets `json:"facets"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+// Sprite variants served by the sprite proxy
+const (
+	SpriteDefault = "default"
+	SpriteShiny   = "shiny"
+	// SpriteArtwork is the official artwork, larger than the in-game sprites
+	SpriteArtwork = "artwork"
+)
+
+// PokemonSprites holds PokeAPI's image URLs for a Pokemon; a variant PokeAPI has no image for is empty
+type PokemonSprites struct {
+	Default string `json:"default,omitempty"`
+	Shiny   string `json:"shiny,omitempty"`
+	Artwork string `json:"artwork,omitempty"`
+}
+
+// URL returns the image URL of a variant, and false if the variant is unknown
+func (s PokemonSprites) URL(variant string) (string, bool) {
+	switch variant {
+	case SpriteDefault:
+		return s.Default, true
+	case SpriteShiny:
+		return s.Shiny, true
+	case SpriteArtwork:
+		return s.Artwork, true
+	default:
+		return "", false
+	}
+}
+
+// Blob is a cached file with what is needed to serve it
+type Blob struct {
+	ContentType string `json:"content_type"`
+	// ETag is a strong, quoted entity tag of Data
+	ETag string `json:"etag"`
+	Data []byte `json:"-"`
+}
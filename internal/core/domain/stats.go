@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	MinLevel    = 1
+	MaxLevel    = 100
+	MaxIV       = 31
+	MaxEV       = 252
+	MaxTotalEVs = 510
+)
+
+// StatSpread holds one value per stat, used for base stats, IVs, EVs and computed stats alike
+type StatSpread struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"special_attack"`
+	SpDefense int `json:"special_defense"`
+	Speed     int `json:"speed"`
+}
+
+func (s StatSpread) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpAttack + s.SpDefense + s.Speed
+}
+
+func (s StatSpread) values() []int {
+	return []int{s.HP, s.Attack, s.Defense, s.SpAttack, s.SpDefense, s.Speed}
+}
+
+// Nature raises one stat by 10% and lowers another by 10%; neutral natures have empty stat names
+type Nature struct {
+	Name      string `json:"name"`
+	Increased string `json:"increased,omitempty"`
+	Decreased string `json:"decreased,omitempty"`
+}
+
+var natures = map[string]Nature{
+	"hardy":   {Name: "hardy"},
+	"docile":  {Name: "docile"},
+	"serious": {Name: "serious"},
+	"bashful": {Name: "bashful"},
+	"quirky":  {Name: "quirky"},
+	"lonely":  {Name: "lonely", Increased: "attack", Decreased: "defense"},
+	"brave":   {Name: "brave", Increased: "attack", Decreased: "speed"},
+	"adamant": {Name: "adamant", Increased: "attack", Decreased: "special-attack"},
+	"naughty": {Name: "naughty", Increased: "attack", Decreased: "special-defense"},
+	"bold":    {Name: "bold", Increased: "defense", Decreased: "attack"},
+	"relaxed": {Name: "relaxed", Increased: "defense", Decreased: "speed"},
+	"impish":  {Name: "impish", Increased: "defense", Decreased: "special-attack"},
+	"lax":     {Name: "lax", Increased: "defense", Decreased: "special-defense"},
+	"timid":   {Name: "timid", Increased: "speed", Decreased: "attack"},
+	"hasty":   {Name: "hasty", Increased: "speed", Decreased: "defense"},
+	"jolly":   {Name: "jolly", Increased: "speed", Decreased: "special-attack"},
+	"naive":   {Name: "naive", Increased: "speed", Decreased: "special-defense"},
+	"modest":  {Name: "modest", Increased: "special-attack", Decreased: "attack"},
+	"mild":    {Name: "mild", Increased: "special-attack", Decreased: "defense"},
+	"quiet":   {Name: "quiet", Increased: "special-attack", Decreased: "speed"},
+	"rash":    {Name: "rash", Increased: "special-attack", Decreased: "special-defense"},
+	"calm":    {Name: "calm", Increased: "special-defense", Decreased: "attack"},
+	"gentle":  {Name: "gentle", Increased: "special-defense", Decreased: "defense"},
+	"sassy":   {Name: "sassy", Increased: "special-defense", Decreased: "speed"},
+	"careful": {Name: "careful", Increased: "special-defense", Decreased: "special-attack"},
+}
+
+// LookupNature finds a nature by name, case-insensitively
+func LookupNature(name string) (Nature, bool) {
+	nature, ok := natures[strings.ToLower(strings.TrimSpace(name))]
+	return nature, ok
+}
+
+// modifier returns the nature's multiplier for a stat, expressed in percent
+func (n Nature) modifier(stat string) int {
+	switch stat {
+	case n.Increased:
+		return 110
+	case n.Decreased:
+		return 90
+	default:
+		return 100
+	}
+}
+
+// ValidateIVs checks that every IV is within 0-31
+func ValidateIVs(ivs StatSpread) error {
+	for _, iv := range ivs.values() {
+		if iv < 0 || iv > MaxIV {
+			return errors.New("IVs must be between 0 and 31")
+		}
+	}
+	return nil
+}
+
+// ValidateEVs checks that every EV is within 0-252 and that they total at most 510
+func ValidateEVs(evs StatSpread) error {
+	for _, ev := range evs.values() {
+		if ev < 0 || ev > MaxEV {
+			return errors.New("EVs must be between 0 and 252")
+		}
+	}
+	if evs.Total() > MaxTotalEVs {
+		return errors.New("EVs cannot total more than 510")
+	}
+	return nil
+}
+
+// CalculateStats applies the standard (generation III onwards) stat formulas
+func CalculateStats(base, ivs, evs StatSpread, level int, nature Nature) StatSpread {
+	core := func(b, iv, ev int) int {
+		return (2*b + iv + ev/4) * level / 100
+	}
+	other := func(stat string, b, iv, ev int) int {
+		return (core(b, iv, ev) + 5) * nature.modifier(stat) / 100
+	}
+
+	return StatSpread{
+		HP:        core(base.HP, ivs.HP, evs.HP) + level + 10,
+		Attack:    other("attack", base.Attack, ivs.Attack, evs.Attack),
+		Defense:   other("defense", base.Defense, ivs.Defense, evs.Defense),
+		SpAttack:  other("special-attack", base.SpAttack, ivs.SpAttack, evs.SpAttack),
+		SpDefense: other("special-defense", base.SpDefense, ivs.SpDefense, evs.SpDefense),
+		Speed:     other("speed", base.Speed, ivs.Speed, evs.Speed),
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+const (
+	// MaxTeamSize is the number of Pokemon a competitive team can hold
+	MaxTeamSize = 6
+	// MaxMovesPerMember is the number of moves a team member can know
+	MaxMovesPerMember = 4
+)
+
+type Team struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	Name    string       `json:"name" gorm:"not null"`
+	Owner   string       `json:"owner"`
+	Members []TeamMember `json:"members" gorm:"constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TeamMember struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	TeamID    uint     `json:"team_id" gorm:"index;not null"`
+	Slot      int      `json:"slot"`
+	PokemonID uint     `json:"pokemon_id" gorm:"not null"`
+	Pokemon   *Pokemon `json:"pokemon,omitempty" gorm:"foreignKey:PokemonID"`
+	Moves     []string `json:"moves" gorm:"serializer:json"`
+}
+
+type TeamRequest struct {
+	Name    string              `json:"name" binding:"required"`
+	Owner   string              `json:"owner,omitempty"`
+	Members []TeamMemberRequest `json:"members"`
+}
+
+type TeamMemberRequest struct {
+	PokemonID uint     `json:"pokemon_id" binding:"required"`
+	Moves     []string `json:"moves,omitempty"`
+}
+
+type TeamAnalysis struct {
+	TeamID  uint                `json:"team_id"`
+	Members []TeamMemberSummary `json:"members"`
+
+	Matchups         []TypeMatchup `json:"matchups"`
+	SharedWeaknesses []string      `json:"shared_weaknesses"`
+	CoverageGaps     []string      `json:"coverage_gaps"`
+
+	SpeedTiers []SpeedTier `json:"speed_tiers"`
+	StatTotals StatTotals  `json:"stat_totals"`
+
+	// Warnings name members left out of the analysis, such as those whose Pokemon is in the trash
+	Warnings []string `json:"warnings"`
+}
+
+type TeamMemberSummary struct {
+	PokemonID uint     `json:"pokemon_id"`
+	Name      string   `json:"name"`
+	Types     []string `json:"types"`
+	// AttackTypes are the types of the member's damaging moves, or its own types if it knows none; coverage
+	// gaps are measured from them
+	AttackTypes   []string `json:"attack_types"`
+	Speed         int      `json:"speed"`
+	BaseStatTotal int      `json:"base_stat_total"`
+}
+
+// TypeMatchup counts how the team's members take hits from a single attacking type
+type TypeMatchup struct {
+	Type   string `json:"type"`
+	Weak   int    `json:"weak"`
+	Resist int    `json:"resist"`
+	Immune int    `json:"immune"`
+}
+
+type SpeedTier struct {
+	Tier    string   `json:"tier"`
+	Members []string `json:"members"`
+}
+
+type StatTotals struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"special_attack"`
+	SpDefense int `json:"special_defense"`
+	Speed     int `json:"speed"`
+	Total     int `json:"total"`
+
+	AverageBaseStatTotal float64 `json:"average_base_stat_total"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// DefaultTenantID is the workspace of callers that name none, and of every row stored before tenants existed
+const DefaultTenantID = "default"
+
+// validTenantID keeps workspace IDs to short slugs, since they are sent in headers and stored on every row
+var validTenantID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// IsValidTenantID reports whether id is a lowercase slug of at most 63 characters
+func IsValidTenantID(id string) bool {
+	return validTenantID.MatchString(id)
+}
+
+// Tenant is a workspace sharing the deployment; Pokemon, teams, trainers, battles, webhooks and events all belong
+// to one, and names only have to be unique within it
+type Tenant struct {
+	ID        string    `json:"id" gorm:"primaryKey;size:64"`
+	Name      string    `json:"name" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TenantRequest struct {
+	ID   string `json:"id" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
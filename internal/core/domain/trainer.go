@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Trainer struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;uniqueIndex:idx_trainers_tenant_name,priority:1"`
+
+	// Name is unique within the tenant
+	Name string `json:"name" gorm:"uniqueIndex:idx_trainers_tenant_name,priority:2;not null"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TrainerRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// OwnedPokemon is an individual Pokemon caught by a trainer, with its own level, nature and stat investment
+type OwnedPokemon struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	TrainerID uint     `json:"trainer_id" gorm:"index;not null"`
+	SpeciesID uint     `json:"species_id" gorm:"index;not null"`
+	Species   *Pokemon `json:"species,omitempty" gorm:"foreignKey:SpeciesID"`
+
+	Nickname string     `json:"nickname,omitempty"`
+	Level    int        `json:"level"`
+	Nature   string     `json:"nature"`
+	IVs      StatSpread `json:"ivs" gorm:"embedded;embeddedPrefix:iv_"`
+	EVs      StatSpread `json:"evs" gorm:"embedded;embeddedPrefix:ev_"`
+	HeldItem string     `json:"held_item,omitempty"`
+	Shiny    bool       `json:"shiny"`
+
+	// Stats are the actual stats computed from the species' base stats, not persisted
+	Stats *StatSpread `json:"stats,omitempty" gorm:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OwnedPokemonRequest struct {
+	SpeciesID uint       `json:"species_id" binding:"required"`
+	Nickname  string     `json:"nickname,omitempty"`
+	Level     int        `json:"level"`
+	Nature    string     `json:"nature,omitempty"`
+	IVs       StatSpread `json:"ivs"`
+	EVs       StatSpread `json:"evs"`
+	HeldItem  string     `json:"held_item,omitempty"`
+	Shiny     bool       `json:"shiny"`
+}
+
+// Validate checks level, nature, IV and EV limits
+func (o *OwnedPokemon) Validate() error {
+	if o.Level < MinLevel || o.Level > MaxLevel {
+		return errors.New("level must be between 1 and 100")
+	}
+	if _, ok := LookupNature(o.Nature); !ok {
+		return errors.New("unknown nature")
+	}
+	if err := ValidateIVs(o.IVs); err != nil {
+		return err
+	}
+	return ValidateEVs(o.EVs)
+}
+
+// ComputeStats fills Stats from the loaded species; it does nothing if the species is missing
+func (o *OwnedPokemon) ComputeStats() {
+	if o.Species == nil {
+		return
+	}
+	nature, _ := LookupNature(o.Nature)
+	stats := CalculateStats(o.Species.BaseStats(), o.IVs, o.EVs, o.Level, nature)
+	o.Stats = &stats
+}
+
+// ApplyRequest copies the request's fields, defaulting to level 50 and a hardy nature
+func (o *OwnedPokemon) ApplyRequest(req *OwnedPokemonRequest) {
+	o.SpeciesID = req.SpeciesID
+	o.Nickname = strings.TrimSpace(req.Nickname)
+	o.Level = req.Level
+	if o.Level == 0 {
+		o.Level = 50
+	}
+	o.Nature = strings.ToLower(strings.TrimSpace(req.Nature))
+	if o.Nature == "" {
+		o.Nature = "hardy"
+	}
+	o.IVs = req.IVs
+	o.EVs = req.EVs
+	o.HeldItem = strings.ToLower(strings.TrimSpace(req.HeldItem))
+	o.Shiny = req.Shiny
+}
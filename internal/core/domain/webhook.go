@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	// DeliveryDead marks a delivery that failed every attempt; it can be retried by hand
+	DeliveryDead = "dead"
+)
+
+// Webhook subscribes a URL to change events; payloads are signed with the secret, which is never returned after creation
+type Webhook struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+	// TenantID is the workspace whose events the webhook receives
+	TenantID string `json:"-" gorm:"size:64;not null;default:default;index"`
+
+	URL        string   `json:"url" gorm:"not null"`
+	EventTypes []string `json:"event_types" gorm:"serializer:json"`
+	Secret     string   `json:"-" gorm:"not null"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Wants reports whether the webhook subscribes to eventType
+func (w *Webhook) Wants(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	// Secret is generated when left empty
+	Secret string `json:"secret,omitempty"`
+}
+
+// CreatedWebhook is returned once when a webhook is created, with the signing secret
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookPayload is the body POSTed to a webhook
+type WebhookPayload struct {
+	// ID is the event's dedup ID, the same on every delivery of the event
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Pokemon   *Pokemon  `json:"pokemon"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one payload queued for one webhook; its ID is sent with every attempt so receivers can drop duplicates
+type WebhookDelivery struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	WebhookID uint   `json:"webhook_id" gorm:"uniqueIndex:idx_delivery_dedup,priority:1;not null"`
+	TenantID  string `json:"-" gorm:"size:64;not null;default:default"`
+	// DedupID is the event's, so an event relayed twice is only queued once per webhook
+	DedupID   string `json:"-" gorm:"uniqueIndex:idx_delivery_dedup,priority:2;not null"`
+	EventType string `json:"event_type"`
+	Payload   []byte `json:"-"`
+
+	Status         string     `json:"status" gorm:"index:idx_delivery_due,priority:1;not null"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index:idx_delivery_due,priority:2"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
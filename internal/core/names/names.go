@@ -0,0 +1,131 @@
+// Package names turns user-typed Pokemon names into PokeAPI slugs and suggests close matches for unknown ones.
+package names
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// genderSymbols are spelled out the way PokeAPI names the Nidoran lines
+var genderSymbols = strings.NewReplacer("♀", "-f", "♂", "-m")
+
+// Slug converts a display name such as "Mr. Mime", "Farfetch’d", "Flabébé" or "Nidoran♀" into
+// the PokeAPI identifier: lowercase ASCII letters and digits joined by single hyphens
+func Slug(name string) string {
+	decomposed := norm.NFD.String(genderSymbols.Replace(strings.ToLower(name)))
+
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range decomposed {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+		case r == '-' || r == '_' || unicode.IsSpace(r) || r == '.' || r == ':':
+			// "Mr. Mime" and "Type: Null" separate words with punctuation as well as spaces
+			pendingHyphen = true
+		}
+		// Anything else, such as apostrophes and combining accents, is dropped
+	}
+	return b.String()
+}
+
+// Suggest ranks the candidates closest to slug, at most limit of them. A candidate qualifies if it is
+// within a few edits of slug (allowing for its length) or shares most of its trigrams; closer edits rank first.
+func Suggest(slug string, candidates []string, limit int) []string {
+	type match struct {
+		name       string
+		distance   int
+		similarity float64
+	}
+
+	maxDistance := 1 + len(slug)/4
+	queryTrigrams := trigrams(slug)
+	var matches []match
+	for _, candidate := range candidates {
+		distance := editDistance(slug, candidate)
+		similarity := trigramSimilarity(queryTrigrams, trigrams(candidate))
+		if distance <= maxDistance || similarity >= 0.4 {
+			matches = append(matches, match{name: candidate, distance: distance, similarity: similarity})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		if matches[i].similarity != matches[j].similarity {
+			return matches[i].similarity > matches[j].similarity
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(matches) && i < limit; i++ {
+		suggestions = append(suggestions, matches[i].name)
+	}
+	return suggestions
+}
+
+// Similarity is the share of trigrams a and b have in common, from 0 (none) to 1 (the same set)
+func Similarity(a, b string) float64 {
+	return trigramSimilarity(trigrams(a), trigrams(b))
+}
+
+// editDistance is the optimal string alignment distance: insertions, deletions, substitutions and
+// swaps of adjacent letters each cost one
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+// trigrams returns the set of three-letter sequences in s, padded so the start and end count too
+func trigrams(s string) map[string]bool {
+	padded := "  " + s + " "
+	set := make(map[string]bool, len(padded))
+	for i := 0; i+3 <= len(padded); i++ {
+		set[padded[i:i+3]] = true
+	}
+	return set
+}
+
+// trigramSimilarity is the share of trigrams the two sets have in common, from 0 to 1
+func trigramSimilarity(a, b map[string]bool) float64 {
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	total := len(a) + len(b) - shared
+	if total == 0 {
+		return 0
+	}
+	return float64(shared) / float64(total)
+}
@@ -0,0 +1,78 @@
+package names
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Pikachu", expected: "pikachu"},
+		{name: "  Mr. Mime ", expected: "mr-mime"},
+		{name: "Mime Jr.", expected: "mime-jr"},
+		{name: "Farfetch'd", expected: "farfetchd"},
+		{name: "Farfetch’d", expected: "farfetchd"},
+		{name: "Flabébé", expected: "flabebe"},
+		{name: "Nidoran♀", expected: "nidoran-f"},
+		{name: "nidoran ♂", expected: "nidoran-m"},
+		{name: "Type: Null", expected: "type-null"},
+		{name: "Porygon-Z", expected: "porygon-z"},
+		{name: "tapu_koko", expected: "tapu-koko"},
+		{name: "Ho--Oh", expected: "ho-oh"},
+		{name: "!!!", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Slug(tt.name))
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{
+		"bulbasaur", "charmander", "charmeleon", "charizard", "charizard-mega-x", "squirtle",
+		"pikachu", "raichu", "mr-mime", "deoxys-normal", "deoxys-attack", "eevee",
+	}
+
+	tests := []struct {
+		name     string
+		slug     string
+		expected []string
+	}{
+		{name: "missing letter", slug: "charzard", expected: []string{"charizard"}},
+		{name: "swapped letters", slug: "pikahcu", expected: []string{"pikachu"}},
+		{name: "species without its default form", slug: "deoxys", expected: []string{"deoxys-attack", "deoxys-normal"}},
+		{name: "missing hyphen", slug: "mrmime", expected: []string{"mr-mime"}},
+		{name: "nothing close", slug: "zzzzzz", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Suggest(tt.slug, candidates, 5))
+		})
+	}
+}
+
+func TestSuggest_Limit(t *testing.T) {
+	suggestions := Suggest("charmaner", []string{"charmander", "charmeleon", "charizard"}, 1)
+
+	assert.Equal(t, []string{"charmander"}, suggestions)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("eevee", "eevee"))
+	assert.Equal(t, 1, editDistance("charzard", "charizard"))
+	assert.Equal(t, 1, editDistance("pikahcu", "pikachu"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("eevee", "eevee"))
+	assert.Equal(t, 0.0, Similarity("eevee", "mew"))
+	assert.InDelta(t, 0.5, Similarity("pikachu", "pikachu-gmax"), 0.1)
+}
@@ -0,0 +1,35 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// AnnotationRepository defines the interface for tags, favorites and notes on Pokemon
+type AnnotationRepository interface {
+	ForTenant(tenantID string) AnnotationRepository
+	// AddTag attaches a tag to a Pokemon, creating the tag if the tenant has none by that name
+	AddTag(pokemonID uint, name string) error
+	RemoveTag(pokemonID uint, name string) error
+	ListTags(pokemonID uint) ([]string, error)
+	// CountTags counts the live Pokemon carrying each tag, leaving out tags no live Pokemon carries
+	CountTags() ([]domain.TagCount, error)
+	AddFavorite(favorite *domain.PokemonFavorite) error
+	RemoveFavorite(pokemonID uint, subject string) error
+	IsFavorite(pokemonID uint, subject string) (bool, error)
+	GetNote(pokemonID uint) (*domain.PokemonNote, error)
+	SaveNote(note *domain.PokemonNote) error
+	DeleteNote(pokemonID uint) error
+	ListPokemon(filter *domain.PokemonFilter) ([]*domain.Pokemon, error)
+}
+
+// AnnotationService defines the interface for annotating Pokemon with tags, favorites and notes
+type AnnotationService interface {
+	ForTenant(tenantID string) AnnotationService
+	// GetAnnotations returns a Pokemon's tags and note, and whether subject favorited it
+	GetAnnotations(pokemonID uint, subject string) (*domain.PokemonAnnotations, error)
+	AddTag(pokemonID uint, tag string) ([]string, error)
+	RemoveTag(pokemonID uint, tag string) ([]string, error)
+	SetFavorite(pokemonID uint, subject string, favorite bool) error
+	// SetNote replaces a Pokemon's note; an empty body removes it and returns nil
+	SetNote(pokemonID uint, req *domain.PokemonNoteRequest, by domain.Attribution) (*domain.PokemonNote, error)
+	TagCloud() ([]domain.TagCount, error)
+	ListPokemon(filter *domain.PokemonFilter) ([]*domain.Pokemon, error)
+}
@@ -0,0 +1,28 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// APIKeyRepository defines the interface for API key persistence
+type APIKeyRepository interface {
+	Create(key *domain.APIKey) error
+	GetByHash(hash string) (*domain.APIKey, error)
+	List() ([]*domain.APIKey, error)
+	Delete(id uint) error
+}
+
+// TokenVerifier validates bearer tokens issued by an external identity provider
+type TokenVerifier interface {
+	Verify(token string) (*domain.Principal, error)
+}
+
+// AuthService defines the interface for authenticating callers and managing API keys
+type AuthService interface {
+	AuthenticateAPIKey(key string) (*domain.Principal, error)
+	AuthenticateToken(token string) (*domain.Principal, error)
+
+	CreateAPIKey(req *domain.APIKeyRequest) (*domain.CreatedAPIKey, error)
+	// EnsureAPIKey stores a key supplied by the operator, such as the bootstrap admin key, if it is not stored yet
+	EnsureAPIKey(name, key string, scopes []string) error
+	ListAPIKeys() ([]*domain.APIKey, error)
+	RevokeAPIKey(id uint) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// BattleRepository defines the interface for Battle data persistence
+type BattleRepository interface {
+	ForTenant(tenantID string) BattleRepository
+	Create(battle *domain.Battle) error
+	GetByID(id uint) (*domain.Battle, error)
+}
+
+// BattleService defines the interface for simulating and replaying battles
+type BattleService interface {
+	ForTenant(tenantID string) BattleService
+	CreateBattle(req *domain.BattleRequest) (*domain.Battle, error)
+	GetBattle(id uint) (*domain.Battle, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// CalcService defines the interface for battle calculations
+type CalcService interface {
+	ForTenant(tenantID string) CalcService
+	CalculateDamage(req *domain.DamageCalcRequest) (*domain.DamageResult, error)
+}
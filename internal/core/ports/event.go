@@ -0,0 +1,28 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// EventRepository defines the interface for the bounded log of recent events
+type EventRepository interface {
+	// Append fails with "event already logged" if an event with the same DedupID exists
+	Append(event *domain.Event) error
+	// ListAfter returns up to limit of the tenant's events with an ID above afterID in ID order, optionally only of
+	// the given types
+	ListAfter(tenantID string, afterID uint, types []string, limit int) ([]*domain.Event, error)
+	// DeleteThrough removes every event with an ID up to and including id
+	DeleteThrough(id uint) error
+}
+
+// EventPublisher receives change events from the outbox relay. Delivery is at least once: after any failure the
+// message is published again with the same DedupID, so implementations must ignore repeats.
+type EventPublisher interface {
+	Publish(message *domain.OutboxMessage) error
+}
+
+// EventService defines the interface for the in-process event bus
+type EventService interface {
+	EventPublisher
+	// Subscribe replays the tenant's logged events after lastEventID, then streams its new ones; types filters when
+	// not empty
+	Subscribe(tenantID string, types []string, lastEventID uint) (*domain.EventSubscription, error)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"pokemon-api/internal/core/domain"
+	"time"
+)
+
+// IdempotencyRepository defines the interface for stored idempotent responses
+type IdempotencyRepository interface {
+	// Create fails with "idempotency key already exists" if the scope already uses the key
+	Create(record *domain.IdempotencyRecord) error
+	Get(scope, key string) (*domain.IdempotencyRecord, error)
+	Update(record *domain.IdempotencyRecord) error
+	Delete(id uint) error
+	DeleteExpired(now time.Time) error
+}
+
+// IdempotencyService defines the interface for replaying retried requests
+type IdempotencyService interface {
+	// Begin reserves the key for a new request, or returns the stored record when the request should be replayed
+	Begin(scope, key, fingerprint string) (*domain.IdempotencyRecord, error)
+	Complete(scope, key string, statusCode int, contentType string, body []byte) error
+	// Release frees a reserved key so the request can be retried
+	Release(scope, key string) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// SpeciesNameRepository defines the interface for the catalog of localized species names.
+// The catalog comes from PokeAPI, so it is shared by every tenant.
+type SpeciesNameRepository interface {
+	// Save replaces the names of the species with the dex number and marks it as synced, even with no names
+	Save(dexNumber int, names []*domain.SpeciesName) error
+	// FindPokemon returns the PokeAPI name of the Pokemon with a species name normalized to key, in any language
+	FindPokemon(key string) (string, error)
+	// DexNumbers returns the dex numbers of the species that have been synced
+	DexNumbers() (map[int]bool, error)
+}
+
+// SpeciesNameSync defines the interface for filling the localized species name catalog from PokeAPI
+type SpeciesNameSync interface {
+	// SyncNext fetches the names of a batch of species missing from the catalog and returns how many it added
+	SyncNext() (int, error)
+}
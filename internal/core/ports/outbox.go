@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"pokemon-api/internal/core/domain"
+	"time"
+)
+
+// OutboxRepository defines the interface for relaying messages the repositories wrote to the outbox
+type OutboxRepository interface {
+	// ListPending returns up to limit unpublished messages in ID order
+	ListPending(limit int) ([]*domain.OutboxMessage, error)
+	MarkPublished(id uint, at time.Time) error
+	// MarkFailed records a failed attempt; the message stays pending
+	MarkFailed(id uint, reason string) error
+	DeletePublishedBefore(before time.Time) error
+}
+
+// OutboxRelay defines the interface for publishing pending outbox messages
+type OutboxRelay interface {
+	// RelayPending publishes pending messages in order and returns how many were published
+	RelayPending() (int, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// PokedexRepository defines the interface for trainers' seen and caught flags
+type PokedexRepository interface {
+	ForTenant(tenantID string) PokedexRepository
+	ListFlags(trainerID uint) ([]*domain.PokedexFlag, error)
+	SaveFlag(flag *domain.PokedexFlag) error
+}
+
+// PokedexService defines the interface for Pokedex completion tracking
+type PokedexService interface {
+	ForTenant(tenantID string) PokedexService
+	GetCompletion() (*domain.PokedexCompletion, error)
+	GetTrainerCompletion(trainerID uint) (*domain.PokedexCompletion, error)
+	SetFlag(trainerID uint, dexNumber int, req *domain.PokedexFlagRequest) (*domain.PokedexFlag, error)
+}
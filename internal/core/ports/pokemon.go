@@ -1,24 +1,83 @@
 package ports
 
-import "pokemon-api/internal/core/domain"
+import (
+	"pokemon-api/internal/core/domain"
+	"time"
+)
 
-// PokemonRepository defines the interface for Pokemon data persistence
+// PokemonRepository defines the interface for Pokemon data persistence.
+// Create, Update, Delete and Restore write the matching change event to the outbox and an entry to the
+// Pokemon's history in the same transaction.
+// Every method but PurgeDeleted only sees the repository's tenant, the default tenant unless ForTenant scoped it.
 type PokemonRepository interface {
-	Create(pokemon *domain.Pokemon) error
+	ForTenant(tenantID string) PokemonRepository
+	Create(pokemon *domain.Pokemon, by domain.Attribution) error
 	GetByID(id uint) (*domain.Pokemon, error)
 	GetByName(name string) (*domain.Pokemon, error)
+	// GetByDexNumber returns the first stored Pokemon of the species, since its forms share the number
+	GetByDexNumber(number int) (*domain.Pokemon, error)
 	List() ([]*domain.Pokemon, error)
+	// ListPage returns the page of Pokemon the query selects, filtering and paging in the database
+	ListPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error)
+	// Update and Delete take the UpdatedAt the change is based on, when the caller has one, and fail with a
+	// *domain.ModifiedError if the Pokemon has changed since; a nil expected version applies the change regardless
+	Update(pokemon *domain.Pokemon, expected *time.Time, by domain.Attribution) error
+	// Delete moves the Pokemon to the trash
+	Delete(id uint, expected *time.Time, by domain.Attribution) error
+	// ListDeleted returns the Pokemon in the trash, most recently deleted first
+	ListDeleted() ([]*domain.Pokemon, error)
+	// Restore takes the Pokemon out of the trash, failing if a live Pokemon has taken its name
+	Restore(id uint, by domain.Attribution) (*domain.Pokemon, error)
+	// PurgeDeleted permanently removes Pokemon deleted before the given time, recording each in its history, and
+	// returns how many it removed
+	PurgeDeleted(before time.Time, by domain.Attribution) (int, error)
+	// History returns every change to the Pokemon, oldest first; it is kept after the Pokemon is purged
+	History(id uint) ([]*domain.PokemonAudit, error)
+	// AsOf returns the Pokemon as it was at the given time, from its history
+	AsOf(id uint, at time.Time) (*domain.Pokemon, error)
+	// Each calls fn for every Pokemon in ID order, loading them in batches; it stops at the first error fn returns
+	Each(fn func(*domain.Pokemon) error) error
+	// Search returns every Pokemon matching text across name, types, abilities and flavor text, best match first
+	Search(text string) ([]domain.PokemonSearchHit, error)
 }
 
 // PokemonAPIClient defines the interface for external PokeAPI integration
 type PokemonAPIClient interface {
 	GetPokemonData(identifier string) (*domain.ExternalPokemonResponse, error)
+	GetMoveData(identifier string) (*domain.ExternalMoveResponse, error)
+	GetSpeciesData(identifier string) (*domain.ExternalSpeciesResponse, error)
+	GetPokemonList(limit int) (*domain.ExternalPokemonListResponse, error)
 }
 
 // PokemonService defines the interface for Pokemon business logic
 type PokemonService interface {
+	// ForTenant returns the service acting on the tenant's Pokemon; the service itself acts on the default tenant
+	ForTenant(tenantID string) PokemonService
+	// CreatePokemon accepts localized species names such as "ピカチュウ" as well as PokeAPI and display names
 	CreatePokemon(req *domain.CreatePokemonRequest) (*domain.Pokemon, error)
 	CreatePokemonFlexible(req *domain.FlexiblePokemonRequest) (*domain.Pokemon, error)
 	GetPokemon(id uint) (*domain.Pokemon, error)
+	// GetPokemonByName accepts display names such as "Mr. Mime" and localized names as well as PokeAPI names
+	GetPokemonByName(name string) (*domain.Pokemon, error)
+	GetPokemonByDexNumber(number int) (*domain.Pokemon, error)
 	ListPokemon() ([]*domain.Pokemon, error)
+	// ListPokemonPage returns one page of Pokemon in ID order; type and name filters are matched case-insensitively
+	ListPokemonPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error)
+	// UpdatePokemon, DeletePokemon and RestorePokemon record the change as made through the API unless by names a source
+	// UpdatePokemon and DeletePokemon only apply while the Pokemon is at the expected version, when one is given
+	UpdatePokemon(id uint, req *domain.UpdatePokemonRequest, expected *time.Time, by domain.Attribution) (*domain.Pokemon, error)
+	DeletePokemon(id uint, expected *time.Time, by domain.Attribution) error
+	ListDeletedPokemon() ([]*domain.Pokemon, error)
+	RestorePokemon(id uint, by domain.Attribution) (*domain.Pokemon, error)
+	// PurgeDeletedPokemon permanently removes Pokemon that have been in the trash longer than retention
+	PurgeDeletedPokemon(retention time.Duration) (int, error)
+	ExportPokemon(fn func(*domain.Pokemon) error) error
+	// ImportPokemon validates and stores decoded rows, reporting every rejected row; a dry run stores nothing
+	ImportPokemon(rows []domain.PokemonImportRow, by domain.Attribution, dryRun bool) (*domain.PokemonImportReport, error)
+	// GetPokemonHistory returns every recorded change to the Pokemon, oldest first, including after it was deleted
+	GetPokemonHistory(id uint) ([]*domain.PokemonAudit, error)
+	// GetPokemonAsOf returns the Pokemon as it was at the given time
+	GetPokemonAsOf(id uint, at time.Time) (*domain.Pokemon, error)
+	// SearchPokemon ranks stored Pokemon against a text query and counts the hits by type and generation
+	SearchPokemon(query *domain.PokemonSearchQuery) (*domain.PokemonSearchResult, error)
 }
@@ -0,0 +1,8 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// RateLimitStore keeps token buckets by key; a shared implementation lets several instances enforce one limit
+type RateLimitStore interface {
+	Take(key string, limit domain.RateLimit) (domain.RateLimitDecision, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// BlobStore defines the interface for caching files by key, on disk or in a blob store
+type BlobStore interface {
+	// Get returns the blob stored under key, or a "blob not found" error
+	Get(key string) (*domain.Blob, error)
+	Put(key string, blob *domain.Blob) error
+}
+
+// ImageFetcher defines the interface for downloading images from the web
+type ImageFetcher interface {
+	// FetchImage returns the image's content type and data; the blob has no ETag
+	FetchImage(url string) (*domain.Blob, error)
+}
+
+// SpriteService defines the interface for serving Pokemon sprites from the local cache
+type SpriteService interface {
+	ForTenant(tenantID string) SpriteService
+	GetSprite(pokemonID uint, variant string) (*domain.Blob, error)
+}
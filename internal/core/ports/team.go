@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"pokemon-api/internal/core/domain"
+	"time"
+)
+
+// TeamRepository defines the interface for Team data persistence
+type TeamRepository interface {
+	ForTenant(tenantID string) TeamRepository
+	Create(team *domain.Team) error
+	GetByID(id uint) (*domain.Team, error)
+	List() ([]*domain.Team, error)
+	// Update and Delete fail with a *domain.ModifiedError if the team is no longer at the expected version, its
+	// UpdatedAt; a nil expected version applies the change regardless
+	Update(team *domain.Team, expected *time.Time) error
+	Delete(id uint, expected *time.Time) error
+}
+
+// TeamService defines the interface for team building and analysis
+type TeamService interface {
+	ForTenant(tenantID string) TeamService
+	CreateTeam(req *domain.TeamRequest) (*domain.Team, error)
+	GetTeam(id uint) (*domain.Team, error)
+	ListTeams() ([]*domain.Team, error)
+	UpdateTeam(id uint, req *domain.TeamRequest, expected *time.Time) (*domain.Team, error)
+	DeleteTeam(id uint, expected *time.Time) error
+	AnalyzeTeam(id uint) (*domain.TeamAnalysis, error)
+}
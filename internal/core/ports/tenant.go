@@ -0,0 +1,19 @@
+package ports
+
+import "pokemon-api/internal/core/domain"
+
+// TenantRepository defines the interface for tenant persistence
+type TenantRepository interface {
+	Create(tenant *domain.Tenant) error
+	GetByID(id string) (*domain.Tenant, error)
+	List() ([]*domain.Tenant, error)
+}
+
+// TenantService defines the interface for provisioning tenants and resolving the tenant of a request
+type TenantService interface {
+	CreateTenant(req *domain.TenantRequest) (*domain.Tenant, error)
+	GetTenant(id string) (*domain.Tenant, error)
+	ListTenants() ([]*domain.Tenant, error)
+	// EnsureTenant creates the tenant unless it exists, such as the default tenant at startup
+	EnsureTenant(id, name string) error
+}
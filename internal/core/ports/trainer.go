@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"pokemon-api/internal/core/domain"
+	"time"
+)
+
+// TrainerRepository defines the interface for trainers and the Pokemon they own
+type TrainerRepository interface {
+	ForTenant(tenantID string) TrainerRepository
+	Create(trainer *domain.Trainer) error
+	GetByID(id uint) (*domain.Trainer, error)
+	GetByName(name string) (*domain.Trainer, error)
+	List() ([]*domain.Trainer, error)
+
+	AddPokemon(owned *domain.OwnedPokemon) error
+	GetPokemon(trainerID, id uint) (*domain.OwnedPokemon, error)
+	ListPokemon(trainerID uint) ([]*domain.OwnedPokemon, error)
+	// UpdatePokemon and DeletePokemon fail with a *domain.ModifiedError if the owned Pokemon is no longer at the
+	// expected version, its UpdatedAt; a nil expected version applies the change regardless
+	UpdatePokemon(owned *domain.OwnedPokemon, expected *time.Time) error
+	DeletePokemon(trainerID, id uint, expected *time.Time) error
+}
+
+// TrainerService defines the interface for managing trainers and their Pokemon
+type TrainerService interface {
+	ForTenant(tenantID string) TrainerService
+	CreateTrainer(req *domain.TrainerRequest) (*domain.Trainer, error)
+	GetTrainer(id uint) (*domain.Trainer, error)
+	ListTrainers() ([]*domain.Trainer, error)
+
+	AddPokemon(trainerID uint, req *domain.OwnedPokemonRequest) (*domain.OwnedPokemon, error)
+	GetPokemon(trainerID, id uint) (*domain.OwnedPokemon, error)
+	ListPokemon(trainerID uint) ([]*domain.OwnedPokemon, error)
+	UpdatePokemon(trainerID, id uint, req *domain.OwnedPokemonRequest, expected *time.Time) (*domain.OwnedPokemon, error)
+	ReleasePokemon(trainerID, id uint, expected *time.Time) error
+}
@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"pokemon-api/internal/core/domain"
+	"time"
+)
+
+// WebhookRepository defines the interface for webhook subscriptions and their delivery queue.
+// Webhooks and the deliveries looked up through them are scoped to the repository's tenant; the delivery queue
+// itself is shared, so CreateDeliveries, ListDueDeliveries and UpdateDelivery see every tenant.
+type WebhookRepository interface {
+	ForTenant(tenantID string) WebhookRepository
+	Create(webhook *domain.Webhook) error
+	GetByID(id uint) (*domain.Webhook, error)
+	List() ([]*domain.Webhook, error)
+	// Delete removes the webhook and its deliveries
+	Delete(id uint) error
+
+	// CreateDeliveries skips deliveries whose webhook already has one with the same DedupID
+	CreateDeliveries(deliveries []*domain.WebhookDelivery) error
+	GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error)
+	// ListDueDeliveries returns up to limit pending deliveries whose next attempt is at or before now, oldest first
+	ListDueDeliveries(now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	// ListDeliveries returns the webhook's latest deliveries, newest first
+	ListDeliveries(webhookID uint, limit int) ([]*domain.WebhookDelivery, error)
+	UpdateDelivery(delivery *domain.WebhookDelivery) error
+}
+
+// WebhookSender defines the interface for POSTing signed payloads to receivers
+type WebhookSender interface {
+	// Send returns the receiver's status code; an error means no response, or one outside 2xx
+	Send(webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error)
+}
+
+// WebhookService defines the interface for managing webhooks and delivering to them
+type WebhookService interface {
+	// Publish queues deliveries to the webhooks of the message's tenant, whichever tenant the service is scoped to
+	EventPublisher
+	ForTenant(tenantID string) WebhookService
+
+	CreateWebhook(req *domain.WebhookRequest) (*domain.CreatedWebhook, error)
+	GetWebhook(id uint) (*domain.Webhook, error)
+	ListWebhooks() ([]*domain.Webhook, error)
+	DeleteWebhook(id uint) error
+	ListDeliveries(webhookID uint) ([]*domain.WebhookDelivery, error)
+	// RetryDelivery queues a delivery again, including one that was dead-lettered
+	RetryDelivery(webhookID, id uint) (*domain.WebhookDelivery, error)
+
+	// DeliverDue attempts every delivery that is due and returns how many were attempted
+	DeliverDue() (int, error)
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+	"unicode/utf8"
+)
+
+type annotationService struct {
+	annotationRepository ports.AnnotationRepository
+	pokemonRepository    ports.PokemonRepository
+}
+
+func NewAnnotationService(annotationRepository ports.AnnotationRepository, pokemonRepository ports.PokemonRepository) ports.AnnotationService {
+	return &annotationService{
+		annotationRepository: annotationRepository,
+		pokemonRepository:    pokemonRepository,
+	}
+}
+
+func (s *annotationService) ForTenant(tenantID string) ports.AnnotationService {
+	return &annotationService{
+		annotationRepository: s.annotationRepository.ForTenant(tenantID),
+		pokemonRepository:    s.pokemonRepository.ForTenant(tenantID),
+	}
+}
+
+func (s *annotationService) GetAnnotations(pokemonID uint, subject string) (*domain.PokemonAnnotations, error) {
+	if _, err := s.pokemonRepository.GetByID(pokemonID); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.annotationRepository.ListTags(pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	annotations := &domain.PokemonAnnotations{PokemonID: pokemonID, Tags: tags}
+
+	if subject != "" {
+		if annotations.Favorite, err = s.annotationRepository.IsFavorite(pokemonID, subject); err != nil {
+			return nil, err
+		}
+	}
+
+	note, err := s.annotationRepository.GetNote(pokemonID)
+	if err != nil && err.Error() != "note not found" {
+		return nil, err
+	}
+	annotations.Note = note
+
+	return annotations, nil
+}
+
+// AddTag attaches a tag and returns the Pokemon's tags; attaching a tag the Pokemon already carries changes nothing
+func (s *annotationService) AddTag(pokemonID uint, tag string) ([]string, error) {
+	name, err := normalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.pokemonRepository.GetByID(pokemonID); err != nil {
+		return nil, err
+	}
+
+	if err := s.annotationRepository.AddTag(pokemonID, name); err != nil {
+		return nil, fmt.Errorf("failed to save tag: %w", err)
+	}
+	return s.annotationRepository.ListTags(pokemonID)
+}
+
+// RemoveTag detaches a tag and returns the Pokemon's remaining tags; removing a tag it does not carry changes nothing
+func (s *annotationService) RemoveTag(pokemonID uint, tag string) ([]string, error) {
+	name, err := normalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.pokemonRepository.GetByID(pokemonID); err != nil {
+		return nil, err
+	}
+
+	if err := s.annotationRepository.RemoveTag(pokemonID, name); err != nil {
+		return nil, err
+	}
+	return s.annotationRepository.ListTags(pokemonID)
+}
+
+// SetFavorite adds the Pokemon to, or removes it from, the favorites of the user with subject
+func (s *annotationService) SetFavorite(pokemonID uint, subject string, favorite bool) error {
+	if subject == "" {
+		return errors.New("favorites need an authenticated user")
+	}
+	if _, err := s.pokemonRepository.GetByID(pokemonID); err != nil {
+		return err
+	}
+
+	if !favorite {
+		return s.annotationRepository.RemoveFavorite(pokemonID, subject)
+	}
+	return s.annotationRepository.AddFavorite(&domain.PokemonFavorite{PokemonID: pokemonID, Subject: subject})
+}
+
+func (s *annotationService) SetNote(pokemonID uint, req *domain.PokemonNoteRequest, by domain.Attribution) (*domain.PokemonNote, error) {
+	if utf8.RuneCountInString(req.Body) > domain.MaxNoteLength {
+		return nil, fmt.Errorf("note cannot be longer than %d characters", domain.MaxNoteLength)
+	}
+	if _, err := s.pokemonRepository.GetByID(pokemonID); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, s.annotationRepository.DeleteNote(pokemonID)
+	}
+
+	note := &domain.PokemonNote{
+		PokemonID: pokemonID,
+		Body:      req.Body,
+		UpdatedBy: by.Actor,
+	}
+	if err := s.annotationRepository.SaveNote(note); err != nil {
+		return nil, fmt.Errorf("failed to save note: %w", err)
+	}
+	return note, nil
+}
+
+// TagCloud counts the live Pokemon carrying each tag, most used first
+func (s *annotationService) TagCloud() ([]domain.TagCount, error) {
+	return s.annotationRepository.CountTags()
+}
+
+// ListPokemon lists the Pokemon matching the filter; filter tags are normalized and may repeat
+func (s *annotationService) ListPokemon(filter *domain.PokemonFilter) ([]*domain.Pokemon, error) {
+	normalized := &domain.PokemonFilter{FavoriteOf: filter.FavoriteOf}
+	seen := make(map[string]bool, len(filter.Tags))
+	for _, tag := range filter.Tags {
+		name, err := normalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized.Tags = append(normalized.Tags, name)
+		}
+	}
+
+	return s.annotationRepository.ListPokemon(normalized)
+}
+
+func normalizeTag(tag string) (string, error) {
+	name := domain.NormalizeTag(tag)
+	if name == "" || utf8.RuneCountInString(name) > domain.MaxTagLength {
+		return "", fmt.Errorf("tag must be 1 to %d characters", domain.MaxTagLength)
+	}
+	return name, nil
+}
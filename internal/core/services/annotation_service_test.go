@@ -0,0 +1,312 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAnnotationRepository struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockAnnotationRepository) ForTenant(tenantID string) ports.AnnotationRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockAnnotationRepository) AddTag(pokemonID uint, name string) error {
+	args := m.Called(pokemonID, name)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationRepository) RemoveTag(pokemonID uint, name string) error {
+	args := m.Called(pokemonID, name)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationRepository) ListTags(pokemonID uint) ([]string, error) {
+	args := m.Called(pokemonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAnnotationRepository) CountTags() ([]domain.TagCount, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TagCount), args.Error(1)
+}
+
+func (m *MockAnnotationRepository) AddFavorite(favorite *domain.PokemonFavorite) error {
+	args := m.Called(favorite)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationRepository) RemoveFavorite(pokemonID uint, subject string) error {
+	args := m.Called(pokemonID, subject)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationRepository) IsFavorite(pokemonID uint, subject string) (bool, error) {
+	args := m.Called(pokemonID, subject)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAnnotationRepository) GetNote(pokemonID uint) (*domain.PokemonNote, error) {
+	args := m.Called(pokemonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonNote), args.Error(1)
+}
+
+func (m *MockAnnotationRepository) SaveNote(note *domain.PokemonNote) error {
+	args := m.Called(note)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationRepository) DeleteNote(pokemonID uint) error {
+	args := m.Called(pokemonID)
+	return args.Error(0)
+}
+
+func (m *MockAnnotationRepository) ListPokemon(filter *domain.PokemonFilter) ([]*domain.Pokemon, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func TestAnnotationService_GetAnnotations(t *testing.T) {
+	tests := []struct {
+		name          string
+		subject       string
+		setupMocks    func(*MockAnnotationRepository, *MockPokemonRepository)
+		expected      *domain.PokemonAnnotations
+		expectedError string
+	}{
+		{
+			name:    "tags, favorite and note",
+			subject: "ash",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				annotations.On("ListTags", uint(1)).Return([]string{"ou viable"}, nil)
+				annotations.On("IsFavorite", uint(1), "ash").Return(true, nil)
+				annotations.On("GetNote", uint(1)).Return(&domain.PokemonNote{PokemonID: 1, Body: "needs EV spread"}, nil)
+			},
+			expected: &domain.PokemonAnnotations{
+				PokemonID: 1,
+				Tags:      []string{"ou viable"},
+				Favorite:  true,
+				Note:      &domain.PokemonNote{PokemonID: 1, Body: "needs EV spread"},
+			},
+		},
+		{
+			name: "no note",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				annotations.On("ListTags", uint(1)).Return([]string{}, nil)
+				annotations.On("GetNote", uint(1)).Return(nil, errors.New("note not found"))
+			},
+			expected: &domain.PokemonAnnotations{PokemonID: 1, Tags: []string{}},
+		},
+		{
+			name: "unknown Pokemon",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnnotations := new(MockAnnotationRepository)
+			mockPokemon := new(MockPokemonRepository)
+			tt.setupMocks(mockAnnotations, mockPokemon)
+
+			service := NewAnnotationService(mockAnnotations, mockPokemon)
+			annotations, err := service.GetAnnotations(1, tt.subject)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, annotations)
+			}
+			mockAnnotations.AssertExpectations(t)
+			mockPokemon.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationService_AddTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		tag           string
+		setupMocks    func(*MockAnnotationRepository, *MockPokemonRepository)
+		expectedError string
+	}{
+		{
+			name: "normalizes the tag",
+			tag:  "  OU   Viable ",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				annotations.On("AddTag", uint(1), "ou viable").Return(nil)
+				annotations.On("ListTags", uint(1)).Return([]string{"ou viable"}, nil)
+			},
+		},
+		{
+			name:          "blank tag",
+			tag:           "   ",
+			setupMocks:    func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {},
+			expectedError: "tag must be 1 to 50 characters",
+		},
+		{
+			name:          "tag too long",
+			tag:           strings.Repeat("a", 51),
+			setupMocks:    func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {},
+			expectedError: "tag must be 1 to 50 characters",
+		},
+		{
+			name: "unknown Pokemon",
+			tag:  "ou viable",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+		{
+			name: "database error",
+			tag:  "ou viable",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				annotations.On("AddTag", uint(1), "ou viable").Return(errors.New("database error"))
+			},
+			expectedError: "failed to save tag: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnnotations := new(MockAnnotationRepository)
+			mockPokemon := new(MockPokemonRepository)
+			tt.setupMocks(mockAnnotations, mockPokemon)
+
+			service := NewAnnotationService(mockAnnotations, mockPokemon)
+			tags, err := service.AddTag(1, tt.tag)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ou viable"}, tags)
+			}
+			mockAnnotations.AssertExpectations(t)
+			mockPokemon.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationService_SetFavorite(t *testing.T) {
+	mockAnnotations := new(MockAnnotationRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockPokemon.On("GetByID", uint(1)).Return(charizard, nil)
+	mockAnnotations.On("AddFavorite", &domain.PokemonFavorite{PokemonID: 1, Subject: "ash"}).Return(nil).Once()
+	mockAnnotations.On("RemoveFavorite", uint(1), "ash").Return(nil).Once()
+	service := NewAnnotationService(mockAnnotations, mockPokemon)
+
+	assert.NoError(t, service.SetFavorite(1, "ash", true))
+	assert.NoError(t, service.SetFavorite(1, "ash", false))
+	assert.EqualError(t, service.SetFavorite(1, "", true), "favorites need an authenticated user")
+
+	mockAnnotations.AssertExpectations(t)
+}
+
+func TestAnnotationService_SetNote(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		setupMocks    func(*MockAnnotationRepository, *MockPokemonRepository)
+		expectNote    bool
+		expectedError string
+	}{
+		{
+			name: "saves the note",
+			body: "Needs an **EV spread**",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				annotations.On("SaveNote", &domain.PokemonNote{PokemonID: 1, Body: "Needs an **EV spread**", UpdatedBy: "ash"}).Return(nil)
+			},
+			expectNote: true,
+		},
+		{
+			name: "empty body removes the note",
+			body: " \n",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				annotations.On("DeleteNote", uint(1)).Return(nil)
+			},
+		},
+		{
+			name:          "note too long",
+			body:          strings.Repeat("a", domain.MaxNoteLength+1),
+			setupMocks:    func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {},
+			expectedError: "note cannot be longer than 10000 characters",
+		},
+		{
+			name: "unknown Pokemon",
+			body: "Needs an EV spread",
+			setupMocks: func(annotations *MockAnnotationRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnnotations := new(MockAnnotationRepository)
+			mockPokemon := new(MockPokemonRepository)
+			tt.setupMocks(mockAnnotations, mockPokemon)
+
+			service := NewAnnotationService(mockAnnotations, mockPokemon)
+			note, err := service.SetNote(1, &domain.PokemonNoteRequest{Body: tt.body}, domain.Attribution{Actor: "ash"})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectNote, note != nil)
+			}
+			mockAnnotations.AssertExpectations(t)
+			mockPokemon.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnnotationService_ListPokemon_NormalizesTags(t *testing.T) {
+	mockAnnotations := new(MockAnnotationRepository)
+	mockAnnotations.On("ListPokemon", &domain.PokemonFilter{Tags: []string{"ou viable", "fast"}, FavoriteOf: "ash"}).Return([]*domain.Pokemon{charizard}, nil)
+	service := NewAnnotationService(mockAnnotations, new(MockPokemonRepository)).ForTenant("kanto")
+
+	pokemon, err := service.ListPokemon(&domain.PokemonFilter{Tags: []string{"OU Viable", "fast", "ou  viable"}, FavoriteOf: "ash"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Pokemon{charizard}, pokemon)
+	assert.Equal(t, "kanto", mockAnnotations.tenantID)
+	mockAnnotations.AssertExpectations(t)
+}
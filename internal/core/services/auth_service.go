@@ -0,0 +1,179 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+)
+
+const (
+	// apiKeyPrefix marks generated keys so they are recognisable in logs and secret scanners
+	apiKeyPrefix = "pk_"
+	// apiKeyDisplayLength is how much of a key is kept in plaintext to tell keys apart
+	apiKeyDisplayLength = 10
+	minAPIKeyLength     = 16
+)
+
+type authService struct {
+	apiKeyRepository ports.APIKeyRepository
+	tokenVerifier    ports.TokenVerifier
+}
+
+// NewAuthService creates the auth service; tokenVerifier may be nil when bearer tokens are not configured
+func NewAuthService(apiKeyRepository ports.APIKeyRepository, tokenVerifier ports.TokenVerifier) ports.AuthService {
+	return &authService{
+		apiKeyRepository: apiKeyRepository,
+		tokenVerifier:    tokenVerifier,
+	}
+}
+
+func (s *authService) AuthenticateAPIKey(key string) (*domain.Principal, error) {
+	if key == "" {
+		return nil, errors.New("invalid API key")
+	}
+
+	apiKey, err := s.apiKeyRepository.GetByHash(hashAPIKey(key))
+	if err != nil {
+		if err.Error() == "api key not found" {
+			return nil, errors.New("invalid API key")
+		}
+		return nil, err
+	}
+
+	return &domain.Principal{
+		Subject:  apiKey.Name,
+		Method:   domain.AuthMethodAPIKey,
+		Roles:    apiKey.Scopes,
+		TenantID: apiKey.TenantID,
+		Operator: apiKey.Operator,
+	}, nil
+}
+
+func (s *authService) AuthenticateToken(token string) (*domain.Principal, error) {
+	if s.tokenVerifier == nil {
+		return nil, errors.New("bearer tokens are not enabled")
+	}
+
+	principal, err := s.tokenVerifier.Verify(token)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return principal, nil
+}
+
+func (s *authService) CreateAPIKey(req *domain.APIKeyRequest) (*domain.CreatedAPIKey, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(random)
+
+	apiKey, err := s.storeAPIKey(req.Name, key, req.Scopes, req.TenantID, req.Operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedAPIKey{APIKey: *apiKey, Key: key}, nil
+}
+
+// EnsureAPIKey stores an operator-supplied key as an operator key; a stored key with the same name but a different
+// value, or stored before it was marked as an operator key, is replaced
+func (s *authService) EnsureAPIKey(name, key string, scopes []string) error {
+	if len(key) < minAPIKeyLength {
+		return errors.New("api key must be at least 16 characters")
+	}
+
+	stored, err := s.apiKeyRepository.GetByHash(hashAPIKey(key))
+	switch {
+	case err == nil && stored.Operator:
+		return nil
+	case err == nil:
+		if err := s.apiKeyRepository.Delete(stored.ID); err != nil {
+			return err
+		}
+	case err.Error() != "api key not found":
+		return err
+	}
+
+	keys, err := s.apiKeyRepository.List()
+	if err != nil {
+		return err
+	}
+	for _, existing := range keys {
+		if existing.Name == name {
+			if err := s.apiKeyRepository.Delete(existing.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = s.storeAPIKey(name, key, scopes, "", true)
+	return err
+}
+
+func (s *authService) ListAPIKeys() ([]*domain.APIKey, error) {
+	return s.apiKeyRepository.List()
+}
+
+func (s *authService) RevokeAPIKey(id uint) error {
+	return s.apiKeyRepository.Delete(id)
+}
+
+// storeAPIKey only checks that tenantID is well formed; a key bound to a tenant that does not exist is rejected when
+// it is used
+func (s *authService) storeAPIKey(name, key string, scopes []string, tenantID string, operator bool) (*domain.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("api key name is required")
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("api key requires at least one scope")
+	}
+	for _, scope := range scopes {
+		if !domain.IsValidRole(scope) {
+			return nil, errors.New("scopes must be reader, editor or admin")
+		}
+	}
+
+	if tenantID != "" && !domain.IsValidTenantID(tenantID) {
+		return nil, errors.New("tenant ID must be a lowercase slug of at most 63 characters")
+	}
+	if operator && tenantID != "" {
+		return nil, errors.New("operator keys cannot be bound to a tenant")
+	}
+
+	keys, err := s.apiKeyRepository.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range keys {
+		if existing.Name == name {
+			return nil, errors.New("api key with this name already exists")
+		}
+	}
+
+	apiKey := &domain.APIKey{
+		Name:     name,
+		Prefix:   key[:min(apiKeyDisplayLength, len(key))],
+		KeyHash:  hashAPIKey(key),
+		Scopes:   scopes,
+		TenantID: tenantID,
+		Operator: operator,
+	}
+	if err := s.apiKeyRepository.Create(apiKey); err != nil {
+		return nil, fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// hashAPIKey returns the hex SHA-256 of a key; keys are long and random, so a fast hash is sufficient
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(key *domain.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(hash string) (*domain.APIKey, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List() ([]*domain.APIKey, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockTokenVerifier struct {
+	mock.Mock
+}
+
+func (m *MockTokenVerifier) Verify(token string) (*domain.Principal, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func TestAuthService_AuthenticateAPIKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		setupMocks    func(*MockAPIKeyRepository)
+		expectedError string
+	}{
+		{
+			name: "valid key",
+			key:  "pk_secret",
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("GetByHash", hashAPIKey("pk_secret")).Return(&domain.APIKey{Name: "ci", Scopes: []string{domain.RoleEditor}, TenantID: "kanto"}, nil)
+			},
+		},
+		{
+			name: "unknown key",
+			key:  "pk_wrong",
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("GetByHash", hashAPIKey("pk_wrong")).Return(nil, errors.New("api key not found"))
+			},
+			expectedError: "invalid API key",
+		},
+		{
+			name:          "empty key",
+			setupMocks:    func(repo *MockAPIKeyRepository) {},
+			expectedError: "invalid API key",
+		},
+		{
+			name: "database error",
+			key:  "pk_secret",
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("GetByHash", hashAPIKey("pk_secret")).Return(nil, errors.New("database error"))
+			},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAPIKeyRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewAuthService(mockRepo, nil)
+			principal, err := service.AuthenticateAPIKey(tt.key)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, principal)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &domain.Principal{Subject: "ci", Method: domain.AuthMethodAPIKey, Roles: []string{domain.RoleEditor}, TenantID: "kanto"}, principal)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_AuthenticateToken(t *testing.T) {
+	mockVerifier := new(MockTokenVerifier)
+	mockVerifier.On("Verify", "good").Return(&domain.Principal{Subject: "ash", Method: domain.AuthMethodJWT, Roles: []string{domain.RoleReader}}, nil)
+	mockVerifier.On("Verify", "expired").Return(nil, errors.New("token has invalid claims: token is expired"))
+
+	service := NewAuthService(new(MockAPIKeyRepository), mockVerifier)
+
+	principal, err := service.AuthenticateToken("good")
+	assert.NoError(t, err)
+	assert.Equal(t, "ash", principal.Subject)
+
+	principal, err = service.AuthenticateToken("expired")
+	assert.EqualError(t, err, "invalid token")
+	assert.Nil(t, principal)
+
+	// Without a verifier bearer tokens are rejected outright
+	principal, err = NewAuthService(new(MockAPIKeyRepository), nil).AuthenticateToken("good")
+	assert.EqualError(t, err, "bearer tokens are not enabled")
+	assert.Nil(t, principal)
+
+	mockVerifier.AssertExpectations(t)
+}
+
+func TestAuthService_CreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *domain.APIKeyRequest
+		setupMocks    func(*MockAPIKeyRepository)
+		expectedError string
+	}{
+		{
+			name:    "successful creation",
+			request: &domain.APIKeyRequest{Name: " ci ", Scopes: []string{domain.RoleEditor}},
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("List").Return([]*domain.APIKey{{ID: 1, Name: "admin"}}, nil)
+				repo.On("Create", mock.AnythingOfType("*domain.APIKey")).Return(nil)
+			},
+		},
+		{
+			name:    "bound to a tenant",
+			request: &domain.APIKeyRequest{Name: "ci", Scopes: []string{domain.RoleEditor}, TenantID: "kanto"},
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("List").Return([]*domain.APIKey{}, nil)
+				repo.On("Create", mock.MatchedBy(func(key *domain.APIKey) bool { return key.TenantID == "kanto" })).Return(nil)
+			},
+		},
+		{
+			name:    "operator key",
+			request: &domain.APIKeyRequest{Name: "ci", Scopes: []string{domain.RoleAdmin}, Operator: true},
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("List").Return([]*domain.APIKey{}, nil)
+				repo.On("Create", mock.MatchedBy(func(key *domain.APIKey) bool { return key.Operator })).Return(nil)
+			},
+		},
+		{
+			name:          "operator key bound to a tenant",
+			request:       &domain.APIKeyRequest{Name: "ci", Scopes: []string{domain.RoleAdmin}, TenantID: "kanto", Operator: true},
+			setupMocks:    func(repo *MockAPIKeyRepository) {},
+			expectedError: "operator keys cannot be bound to a tenant",
+		},
+		{
+			name:          "invalid tenant ID",
+			request:       &domain.APIKeyRequest{Name: "ci", Scopes: []string{domain.RoleEditor}, TenantID: "Kanto League"},
+			setupMocks:    func(repo *MockAPIKeyRepository) {},
+			expectedError: "tenant ID must be a lowercase slug of at most 63 characters",
+		},
+		{
+			name:          "unknown scope",
+			request:       &domain.APIKeyRequest{Name: "ci", Scopes: []string{"superuser"}},
+			setupMocks:    func(repo *MockAPIKeyRepository) {},
+			expectedError: "scopes must be reader, editor or admin",
+		},
+		{
+			name:          "no scopes",
+			request:       &domain.APIKeyRequest{Name: "ci"},
+			setupMocks:    func(repo *MockAPIKeyRepository) {},
+			expectedError: "api key requires at least one scope",
+		},
+		{
+			name:    "duplicate name",
+			request: &domain.APIKeyRequest{Name: "admin", Scopes: []string{domain.RoleReader}},
+			setupMocks: func(repo *MockAPIKeyRepository) {
+				repo.On("List").Return([]*domain.APIKey{{ID: 1, Name: "admin"}}, nil)
+			},
+			expectedError: "api key with this name already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAPIKeyRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewAuthService(mockRepo, nil)
+			created, err := service.CreateAPIKey(tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, created)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ci", created.Name)
+				assert.True(t, strings.HasPrefix(created.Key, "pk_"))
+				assert.Len(t, created.Key, 67)
+				assert.Equal(t, created.Key[:10], created.Prefix)
+				assert.Equal(t, hashAPIKey(created.Key), created.KeyHash)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_EnsureAPIKey(t *testing.T) {
+	const key = "bootstrap-secret-key"
+
+	t.Run("already stored", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("GetByHash", hashAPIKey(key)).Return(&domain.APIKey{ID: 1, Name: "bootstrap-admin", Operator: true}, nil)
+
+		err := NewAuthService(mockRepo, nil).EnsureAPIKey("bootstrap-admin", key, []string{domain.RoleAdmin})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("stored before operators were an explicit grant", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("GetByHash", hashAPIKey(key)).Return(&domain.APIKey{ID: 1, Name: "bootstrap-admin"}, nil)
+		mockRepo.On("Delete", uint(1)).Return(nil)
+		mockRepo.On("List").Return([]*domain.APIKey{}, nil)
+		mockRepo.On("Create", mock.MatchedBy(func(apiKey *domain.APIKey) bool {
+			return apiKey.Name == "bootstrap-admin" && apiKey.Operator && apiKey.TenantID == ""
+		})).Return(nil)
+
+		err := NewAuthService(mockRepo, nil).EnsureAPIKey("bootstrap-admin", key, []string{domain.RoleAdmin})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rotated key replaces the old one", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("GetByHash", hashAPIKey(key)).Return(nil, errors.New("api key not found"))
+		mockRepo.On("List").Return([]*domain.APIKey{{ID: 7, Name: "bootstrap-admin"}}, nil).Once()
+		mockRepo.On("Delete", uint(7)).Return(nil)
+		mockRepo.On("List").Return([]*domain.APIKey{}, nil).Once()
+		mockRepo.On("Create", mock.MatchedBy(func(apiKey *domain.APIKey) bool {
+			return apiKey.Name == "bootstrap-admin" && apiKey.KeyHash == hashAPIKey(key) && apiKey.Prefix == "bootstrap-" && apiKey.Operator
+		})).Return(nil)
+
+		err := NewAuthService(mockRepo, nil).EnsureAPIKey("bootstrap-admin", key, []string{domain.RoleAdmin})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		err := NewAuthService(new(MockAPIKeyRepository), nil).EnsureAPIKey("bootstrap-admin", "short", []string{domain.RoleAdmin})
+		assert.EqualError(t, err, "api key must be at least 16 characters")
+	})
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/battle"
+	"pokemon-api/internal/core/damage"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+	"time"
+)
+
+type battleService struct {
+	battleRepository ports.BattleRepository
+	teamRepository   ports.TeamRepository
+	apiClient        ports.PokemonAPIClient
+}
+
+func NewBattleService(battleRepository ports.BattleRepository, teamRepository ports.TeamRepository, apiClient ports.PokemonAPIClient) ports.BattleService {
+	return &battleService{
+		battleRepository: battleRepository,
+		teamRepository:   teamRepository,
+		apiClient:        apiClient,
+	}
+}
+
+func (s *battleService) ForTenant(tenantID string) ports.BattleService {
+	return &battleService{
+		battleRepository: s.battleRepository.ForTenant(tenantID),
+		teamRepository:   s.teamRepository.ForTenant(tenantID),
+		apiClient:        s.apiClient,
+	}
+}
+
+func (s *battleService) CreateBattle(req *domain.BattleRequest) (*domain.Battle, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = domain.BattleFormatSingles
+	}
+	if format != domain.BattleFormatSingles && format != domain.BattleFormat1v1 {
+		return nil, errors.New("unknown battle format")
+	}
+
+	level := req.Level
+	if level == 0 {
+		level = 50
+	}
+	if level < domain.MinLevel || level > domain.MaxLevel {
+		return nil, errors.New("level must be between 1 and 100")
+	}
+
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	// Moves are shared between both teams, so each one is only fetched once per battle
+	moves := make(map[string]domain.Move)
+	sideA, err := s.buildSide(domain.BattleWinnerTeamA, req.TeamAID, format, level, moves)
+	if err != nil {
+		return nil, err
+	}
+	sideB, err := s.buildSide(domain.BattleWinnerTeamB, req.TeamBID, format, level, moves)
+	if err != nil {
+		return nil, err
+	}
+
+	result := battle.Simulate(sideA, sideB, seed)
+
+	record := &domain.Battle{
+		TeamAID: req.TeamAID,
+		TeamBID: req.TeamBID,
+		Format:  format,
+		Level:   level,
+		Seed:    seed,
+		Winner:  result.Winner,
+		Turns:   result.Turns,
+		Log:     result.Log,
+		Moves:   moves,
+	}
+	if err := s.battleRepository.Create(record); err != nil {
+		return nil, fmt.Errorf("failed to save battle: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *battleService) GetBattle(id uint) (*domain.Battle, error) {
+	return s.battleRepository.GetByID(id)
+}
+
+// buildSide turns a stored team into battle fighters with neutral natures, perfect IVs and no EVs
+func (s *battleService) buildSide(key string, teamID uint, format string, level int, moves map[string]domain.Move) (*battle.Side, error) {
+	team, err := s.teamRepository.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if len(team.Members) == 0 {
+		return nil, errors.New("team has no members")
+	}
+
+	members := team.Members
+	if format == domain.BattleFormat1v1 {
+		members = members[:1]
+	}
+
+	nature, _ := domain.LookupNature("hardy")
+	side := &battle.Side{Key: key}
+	for _, member := range members {
+		if member.Pokemon == nil {
+			return nil, errors.New("team member pokemon not found")
+		}
+		memberMoves, err := s.resolveMoves(member.Moves, moves)
+		if err != nil {
+			return nil, err
+		}
+		side.Fighters = append(side.Fighters, &battle.Fighter{
+			Name:      member.Pokemon.Name,
+			Combatant: damage.NewCombatant(member.Pokemon.Types(), member.Pokemon.BaseStats(), level, nature, maxIVs, domain.StatSpread{}),
+			Moves:     memberMoves,
+		})
+	}
+	return side, nil
+}
+
+// resolveMoves looks up a member's moves, adding them to cache; status moves are kept, and never chosen in battle
+func (s *battleService) resolveMoves(names []string, cache map[string]domain.Move) ([]domain.Move, error) {
+	resolved := make([]domain.Move, 0, len(names))
+	for _, name := range names {
+		move, cached := cache[name]
+		if !cached {
+			fetched, err := fetchMove(s.apiClient, name)
+			if err != nil {
+				return nil, err
+			}
+			move = fetched
+			cache[name] = move
+		}
+		resolved = append(resolved, move)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBattleRepository struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockBattleRepository) ForTenant(tenantID string) ports.BattleRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockBattleRepository) Create(battle *domain.Battle) error {
+	args := m.Called(battle)
+	return args.Error(0)
+}
+
+func (m *MockBattleRepository) GetByID(id uint) (*domain.Battle, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Battle), args.Error(1)
+}
+
+func moveData(name, moveType, category string, power, accuracy int) *domain.ExternalMoveResponse {
+	move := &domain.ExternalMoveResponse{Name: name, Power: intPtr(power), Accuracy: intPtr(accuracy)}
+	move.Type.Name = moveType
+	move.DamageClass.Name = category
+	return move
+}
+
+func battleTeams() (*domain.Team, *domain.Team) {
+	teamA := &domain.Team{ID: 1, Name: "water", Members: []domain.TeamMember{
+		{Slot: 1, PokemonID: 2, Pokemon: blastoise, Moves: []string{"surf", "splash"}},
+		{Slot: 2, PokemonID: 4, Pokemon: gyarados, Moves: []string{"surf"}},
+	}}
+	teamB := &domain.Team{ID: 2, Name: "fire", Members: []domain.TeamMember{
+		{Slot: 1, PokemonID: 1, Pokemon: charizard, Moves: []string{"flamethrower"}},
+		{Slot: 2, PokemonID: 5, Pokemon: snorlax, Moves: []string{"body-slam"}},
+	}}
+	return teamA, teamB
+}
+
+func setupBattleMoves(client *MockPokemonAPIClient) {
+	client.On("GetMoveData", "surf").Return(moveData("surf", "water", "special", 90, 100), nil).Once()
+	client.On("GetMoveData", "splash").Return(moveData("splash", "normal", "status", 0, 0), nil).Once()
+	client.On("GetMoveData", "flamethrower").Return(moveData("flamethrower", "fire", "special", 90, 100), nil).Once()
+	client.On("GetMoveData", "body-slam").Return(moveData("body-slam", "normal", "physical", 85, 100), nil).Once()
+}
+
+func TestBattleService_CreateBattle(t *testing.T) {
+	seed := int64(1234)
+
+	tests := []struct {
+		name          string
+		request       *domain.BattleRequest
+		setupMocks    func(*MockBattleRepository, *MockTeamRepository, *MockPokemonAPIClient)
+		expectedError string
+		check         func(*testing.T, *domain.Battle)
+	}{
+		{
+			name:    "full battle with defaults",
+			request: &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Seed: &seed},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teamA, teamB := battleTeams()
+				teams.On("GetByID", uint(1)).Return(teamA, nil)
+				teams.On("GetByID", uint(2)).Return(teamB, nil)
+				setupBattleMoves(client)
+				battles.On("Create", mock.AnythingOfType("*domain.Battle")).Return(nil)
+			},
+			check: func(t *testing.T, battle *domain.Battle) {
+				assert.Equal(t, domain.BattleFormatSingles, battle.Format)
+				assert.Equal(t, 50, battle.Level)
+				assert.Equal(t, seed, battle.Seed)
+				assert.NotEmpty(t, battle.Winner)
+				assert.Equal(t, domain.BattleEventEnd, battle.Log[len(battle.Log)-1].Type)
+
+				for _, event := range battle.Log {
+					if event.Pokemon == "blastoise" && event.Type == domain.BattleEventMove {
+						assert.Equal(t, "surf", event.Move)
+					}
+				}
+				// The move data is stored with the battle, status moves included
+				assert.Equal(t, map[string]domain.Move{
+					"surf":         {Name: "surf", Type: "water", Power: 90, Category: "special", Accuracy: 100},
+					"splash":       {Name: "splash", Type: "normal", Category: "status"},
+					"flamethrower": {Name: "flamethrower", Type: "fire", Power: 90, Category: "special", Accuracy: 100},
+					"body-slam":    {Name: "body-slam", Type: "normal", Power: 85, Category: "physical", Accuracy: 100},
+				}, battle.Moves)
+			},
+		},
+		{
+			name:    "1v1 uses only the leads",
+			request: &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Format: "1V1", Level: 100, Seed: &seed},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teamA, teamB := battleTeams()
+				teams.On("GetByID", uint(1)).Return(teamA, nil)
+				teams.On("GetByID", uint(2)).Return(teamB, nil)
+				client.On("GetMoveData", "surf").Return(moveData("surf", "water", "special", 90, 100), nil).Once()
+				client.On("GetMoveData", "splash").Return(moveData("splash", "normal", "status", 0, 0), nil).Once()
+				client.On("GetMoveData", "flamethrower").Return(moveData("flamethrower", "fire", "special", 90, 100), nil).Once()
+				battles.On("Create", mock.AnythingOfType("*domain.Battle")).Return(nil)
+			},
+			check: func(t *testing.T, battle *domain.Battle) {
+				assert.Equal(t, domain.BattleFormat1v1, battle.Format)
+				assert.Equal(t, domain.BattleWinnerTeamA, battle.Winner)
+				for _, event := range battle.Log {
+					assert.NotEqual(t, "gyarados", event.Pokemon)
+					assert.NotEqual(t, "snorlax", event.Pokemon)
+				}
+			},
+		},
+		{
+			name:    "status moves are never used",
+			request: &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Format: "1v1", Seed: &seed},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teamA, teamB := battleTeams()
+				teamA.Members[0].Moves = []string{"splash"}
+				teams.On("GetByID", uint(1)).Return(teamA, nil)
+				teams.On("GetByID", uint(2)).Return(teamB, nil)
+				client.On("GetMoveData", "splash").Return(moveData("splash", "normal", "status", 0, 0), nil).Once()
+				client.On("GetMoveData", "flamethrower").Return(moveData("flamethrower", "fire", "special", 90, 100), nil).Once()
+				battles.On("Create", mock.AnythingOfType("*domain.Battle")).Return(nil)
+			},
+			check: func(t *testing.T, battle *domain.Battle) {
+				for _, event := range battle.Log {
+					if event.Pokemon == "blastoise" && event.Type == domain.BattleEventMove {
+						assert.Equal(t, "struggle", event.Move)
+					}
+				}
+			},
+		},
+		{
+			name:    "move lookup failure",
+			request: &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Seed: &seed},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teamA, _ := battleTeams()
+				teams.On("GetByID", uint(1)).Return(teamA, nil)
+				client.On("GetMoveData", "surf").Return(nil, errors.New("PokeAPI unavailable"))
+			},
+			expectedError: "failed to fetch move data: PokeAPI unavailable",
+		},
+		{
+			name:          "unknown format",
+			request:       &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Format: "doubles"},
+			setupMocks:    func(*MockBattleRepository, *MockTeamRepository, *MockPokemonAPIClient) {},
+			expectedError: "unknown battle format",
+		},
+		{
+			name:          "invalid level",
+			request:       &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Level: 101},
+			setupMocks:    func(*MockBattleRepository, *MockTeamRepository, *MockPokemonAPIClient) {},
+			expectedError: "level must be between 1 and 100",
+		},
+		{
+			name:    "team not found",
+			request: &domain.BattleRequest{TeamAID: 1, TeamBID: 99},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teamA, _ := battleTeams()
+				teams.On("GetByID", uint(1)).Return(teamA, nil)
+				teams.On("GetByID", uint(99)).Return(nil, errors.New("team not found"))
+				client.On("GetMoveData", "surf").Return(moveData("surf", "water", "special", 90, 100), nil).Once()
+				client.On("GetMoveData", "splash").Return(moveData("splash", "normal", "status", 0, 0), nil).Once()
+			},
+			expectedError: "team not found",
+		},
+		{
+			name:    "empty team",
+			request: &domain.BattleRequest{TeamAID: 3, TeamBID: 2},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teams.On("GetByID", uint(3)).Return(&domain.Team{ID: 3, Name: "empty"}, nil)
+			},
+			expectedError: "team has no members",
+		},
+		{
+			name:    "save failure",
+			request: &domain.BattleRequest{TeamAID: 1, TeamBID: 2, Seed: &seed},
+			setupMocks: func(battles *MockBattleRepository, teams *MockTeamRepository, client *MockPokemonAPIClient) {
+				teamA, teamB := battleTeams()
+				teams.On("GetByID", uint(1)).Return(teamA, nil)
+				teams.On("GetByID", uint(2)).Return(teamB, nil)
+				setupBattleMoves(client)
+				battles.On("Create", mock.AnythingOfType("*domain.Battle")).Return(errors.New("database error"))
+			},
+			expectedError: "failed to save battle: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBattles := new(MockBattleRepository)
+			mockTeams := new(MockTeamRepository)
+			mockClient := new(MockPokemonAPIClient)
+			tt.setupMocks(mockBattles, mockTeams, mockClient)
+
+			service := NewBattleService(mockBattles, mockTeams, mockClient)
+			battle, err := service.CreateBattle(tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, battle)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, battle)
+			}
+
+			mockBattles.AssertExpectations(t)
+			mockTeams.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBattleService_CreateBattle_ReplaysWithSameSeed(t *testing.T) {
+	seed := int64(99)
+	run := func() *domain.Battle {
+		mockBattles := new(MockBattleRepository)
+		mockTeams := new(MockTeamRepository)
+		mockClient := new(MockPokemonAPIClient)
+		teamA, teamB := battleTeams()
+		mockTeams.On("GetByID", uint(1)).Return(teamA, nil)
+		mockTeams.On("GetByID", uint(2)).Return(teamB, nil)
+		setupBattleMoves(mockClient)
+		mockBattles.On("Create", mock.AnythingOfType("*domain.Battle")).Return(nil)
+
+		battle, err := NewBattleService(mockBattles, mockTeams, mockClient).CreateBattle(&domain.BattleRequest{TeamAID: 1, TeamBID: 2, Seed: &seed})
+		assert.NoError(t, err)
+		return battle
+	}
+
+	first, second := run(), run()
+	assert.Equal(t, first.Winner, second.Winner)
+	assert.Equal(t, first.Log, second.Log)
+}
+
+func TestBattleService_GetBattle(t *testing.T) {
+	mockBattles := new(MockBattleRepository)
+	mockBattles.On("GetByID", uint(1)).Return(&domain.Battle{ID: 1, Winner: domain.BattleDraw}, nil)
+	mockBattles.On("GetByID", uint(2)).Return(nil, errors.New("battle not found"))
+
+	service := NewBattleService(mockBattles, new(MockTeamRepository), new(MockPokemonAPIClient))
+
+	battle, err := service.GetBattle(1)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.BattleDraw, battle.Winner)
+
+	battle, err = service.GetBattle(2)
+	assert.EqualError(t, err, "battle not found")
+	assert.Nil(t, battle)
+
+	mockBattles.AssertExpectations(t)
+}
+
+func TestBattleService_ForTenant(t *testing.T) {
+	mockBattles := new(MockBattleRepository)
+	mockTeams := new(MockTeamRepository)
+	mockBattles.On("GetByID", uint(1)).Return(&domain.Battle{ID: 1}, nil)
+
+	_, err := NewBattleService(mockBattles, mockTeams, new(MockPokemonAPIClient)).ForTenant("kanto").GetBattle(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kanto", mockBattles.tenantID)
+	assert.Equal(t, "kanto", mockTeams.tenantID)
+	mockBattles.AssertExpectations(t)
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/damage"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+)
+
+// maxIVs is assumed when a calculation does not specify IVs, as competitive calculators do
+var maxIVs = domain.StatSpread{HP: 31, Attack: 31, Defense: 31, SpAttack: 31, SpDefense: 31, Speed: 31}
+
+type calcService struct {
+	pokemonRepository ports.PokemonRepository
+	apiClient         ports.PokemonAPIClient
+}
+
+func NewCalcService(pokemonRepository ports.PokemonRepository, apiClient ports.PokemonAPIClient) ports.CalcService {
+	return &calcService{
+		pokemonRepository: pokemonRepository,
+		apiClient:         apiClient,
+	}
+}
+
+func (s *calcService) ForTenant(tenantID string) ports.CalcService {
+	return &calcService{
+		pokemonRepository: s.pokemonRepository.ForTenant(tenantID),
+		apiClient:         s.apiClient,
+	}
+}
+
+func (s *calcService) CalculateDamage(req *domain.DamageCalcRequest) (*domain.DamageResult, error) {
+	attacker, err := s.buildCombatant(req.Attacker)
+	if err != nil {
+		return nil, err
+	}
+	defender, err := s.buildCombatant(req.Defender)
+	if err != nil {
+		return nil, err
+	}
+
+	move, err := resolveMove(s.apiClient, req.Move)
+	if err != nil {
+		return nil, err
+	}
+
+	result := damage.Calculate(attacker, defender, move, req.Modifiers)
+	return &result, nil
+}
+
+func (s *calcService) buildCombatant(spec domain.CombatantSpec) (damage.Combatant, error) {
+	types, base := spec.Types, spec.BaseStats
+	if spec.PokemonID != 0 {
+		pokemon, err := s.pokemonRepository.GetByID(spec.PokemonID)
+		if err != nil {
+			return damage.Combatant{}, err
+		}
+		stats := pokemon.BaseStats()
+		types, base = pokemon.Types(), &stats
+	}
+	if len(types) == 0 || base == nil {
+		return damage.Combatant{}, errors.New("combatant requires a pokemon_id or types and base_stats")
+	}
+
+	level := spec.Level
+	if level == 0 {
+		level = 50
+	}
+	if level < domain.MinLevel || level > domain.MaxLevel {
+		return damage.Combatant{}, errors.New("level must be between 1 and 100")
+	}
+
+	natureName := spec.Nature
+	if natureName == "" {
+		natureName = "hardy"
+	}
+	nature, ok := domain.LookupNature(natureName)
+	if !ok {
+		return damage.Combatant{}, errors.New("unknown nature")
+	}
+
+	ivs := maxIVs
+	if spec.IVs != nil {
+		ivs = *spec.IVs
+	}
+	if err := domain.ValidateIVs(ivs); err != nil {
+		return damage.Combatant{}, err
+	}
+	if err := domain.ValidateEVs(spec.EVs); err != nil {
+		return damage.Combatant{}, err
+	}
+
+	return damage.NewCombatant(types, *base, level, nature, ivs, spec.EVs), nil
+}
+
+// fetchMove looks a move up on PokeAPI by name; status moves come back with no power. An unknown move fails with
+// the client's *domain.ExternalNotFoundError.
+func fetchMove(apiClient ports.PokemonAPIClient, name string) (domain.Move, error) {
+	moveData, err := apiClient.GetMoveData(name)
+	if err != nil {
+		var notFound *domain.ExternalNotFoundError
+		if errors.As(err, &notFound) {
+			return domain.Move{}, err
+		}
+		return domain.Move{}, fmt.Errorf("failed to fetch move data: %w", err)
+	}
+	move := domain.Move{
+		Name:     moveData.Name,
+		Type:     moveData.Type.Name,
+		Category: moveData.DamageClass.Name,
+	}
+	if moveData.Power != nil {
+		move.Power = *moveData.Power
+	}
+	if moveData.Accuracy != nil {
+		move.Accuracy = *moveData.Accuracy
+	}
+	return move, nil
+}
+
+// resolveMove fills in a move's type, power and category from PokeAPI when they are not given inline
+func resolveMove(apiClient ports.PokemonAPIClient, move domain.Move) (domain.Move, error) {
+	move.Name = strings.ToLower(strings.TrimSpace(move.Name))
+	move.Type = strings.ToLower(strings.TrimSpace(move.Type))
+	move.Category = strings.ToLower(strings.TrimSpace(move.Category))
+
+	if move.Type == "" || move.Power == 0 {
+		if move.Name == "" {
+			return domain.Move{}, errors.New("move requires a name or a type and power")
+		}
+
+		fetched, err := fetchMove(apiClient, move.Name)
+		if err != nil {
+			return domain.Move{}, err
+		}
+		move = fetched
+	}
+
+	if move.Category == "" {
+		move.Category = domain.CategoryPhysical
+	}
+	if !domain.IsValidType(move.Type) {
+		return domain.Move{}, errors.New("unknown move type")
+	}
+	if move.Category == domain.CategoryStatus || move.Power <= 0 {
+		return domain.Move{}, errors.New("move does not deal damage")
+	}
+
+	return move, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestCalcService_CalculateDamage(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *domain.DamageCalcRequest
+		setupMocks    func(*MockPokemonRepository, *MockPokemonAPIClient)
+		expectedError string
+		check         func(*testing.T, *domain.DamageResult)
+	}{
+		{
+			name: "stored pokemon with move fetched from PokeAPI",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{PokemonID: 445, Level: 100, Nature: "jolly", EVs: domain.StatSpread{Attack: 252, Speed: 252}},
+				Defender: domain.CombatantSpec{PokemonID: 5, Level: 100},
+				Move:     domain.Move{Name: "Dragon Claw"},
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
+				repo.On("GetByID", uint(445)).Return(garchomp, nil)
+				repo.On("GetByID", uint(5)).Return(snorlax, nil)
+				move := &domain.ExternalMoveResponse{Name: "dragon-claw", Power: intPtr(80)}
+				move.Type.Name = "dragon"
+				move.DamageClass.Name = "physical"
+				client.On("GetMoveData", "dragon claw").Return(move, nil)
+			},
+			check: func(t *testing.T, result *domain.DamageResult) {
+				assert.Equal(t, "dragon-claw", result.Move.Name)
+				assert.Equal(t, "dragon", result.Move.Type)
+				assert.True(t, result.STAB)
+				assert.Equal(t, 1.0, result.Effectiveness)
+				assert.Equal(t, 461, result.DefenderHP)
+				assert.Less(t, result.Min, result.Max)
+			},
+		},
+		{
+			name: "inline specs and move",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{Types: []string{"water"}, BaseStats: &domain.StatSpread{HP: 100, Attack: 100}, IVs: &domain.StatSpread{}},
+				Defender: domain.CombatantSpec{Types: []string{"fire"}, BaseStats: &domain.StatSpread{HP: 100, Defense: 100}, IVs: &domain.StatSpread{}},
+				Move:     domain.Move{Name: "waterfall", Type: "Water", Power: 80},
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {},
+			check: func(t *testing.T, result *domain.DamageResult) {
+				// Level 50: attack and defense are both 105, so base damage is 22*80*105/105/50+2 = 37
+				assert.Equal(t, domain.CategoryPhysical, result.Move.Category)
+				assert.Equal(t, 2.0, result.Effectiveness)
+				assert.Equal(t, 160, result.DefenderHP)
+				assert.Equal(t, 92, result.Min)
+				assert.Equal(t, 110, result.Max)
+			},
+		},
+		{
+			name: "missing combatant data",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{Types: []string{"water"}},
+				Move:     domain.Move{Type: "water", Power: 80},
+			},
+			setupMocks:    func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {},
+			expectedError: "combatant requires a pokemon_id or types and base_stats",
+		},
+		{
+			name: "stored pokemon not found",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{PokemonID: 1},
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
+				repo.On("GetByID", uint(1)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+		{
+			name: "invalid EVs",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{Types: []string{"water"}, BaseStats: &domain.StatSpread{}, EVs: domain.StatSpread{HP: 300}},
+			},
+			setupMocks:    func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {},
+			expectedError: "EVs must be between 0 and 252",
+		},
+		{
+			name: "status move",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{Types: []string{"normal"}, BaseStats: &domain.StatSpread{}},
+				Defender: domain.CombatantSpec{Types: []string{"normal"}, BaseStats: &domain.StatSpread{}},
+				Move:     domain.Move{Name: "swords-dance"},
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
+				move := &domain.ExternalMoveResponse{Name: "swords-dance"}
+				move.Type.Name = "normal"
+				move.DamageClass.Name = "status"
+				client.On("GetMoveData", "swords-dance").Return(move, nil)
+			},
+			expectedError: "move does not deal damage",
+		},
+		{
+			name: "move without name or power",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{Types: []string{"normal"}, BaseStats: &domain.StatSpread{}},
+				Defender: domain.CombatantSpec{Types: []string{"normal"}, BaseStats: &domain.StatSpread{}},
+				Move:     domain.Move{Type: "normal"},
+			},
+			setupMocks:    func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {},
+			expectedError: "move requires a name or a type and power",
+		},
+		{
+			name: "move lookup fails",
+			request: &domain.DamageCalcRequest{
+				Attacker: domain.CombatantSpec{Types: []string{"normal"}, BaseStats: &domain.StatSpread{}},
+				Defender: domain.CombatantSpec{Types: []string{"normal"}, BaseStats: &domain.StatSpread{}},
+				Move:     domain.Move{Name: "hyper-punch"},
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
+				client.On("GetMoveData", "hyper-punch").Return(nil, &domain.ExternalNotFoundError{Resource: "move", Identifier: "hyper-punch"})
+			},
+			expectedError: "move 'hyper-punch' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			mockClient := new(MockPokemonAPIClient)
+			tt.setupMocks(mockRepo, mockClient)
+
+			service := NewCalcService(mockRepo, mockClient)
+			result, err := service.CalculateDamage(tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"sync"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it is dropped
+const subscriberBuffer = 64
+
+type subscriber struct {
+	tenantID string
+	types    []string
+	events   chan *domain.Event
+}
+
+func (s *subscriber) wants(event *domain.Event) bool {
+	if event.TenantID != s.tenantID {
+		return false
+	}
+	if len(s.types) == 0 {
+		return true
+	}
+	for _, t := range s.types {
+		if t == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+type eventService struct {
+	repository ports.EventRepository
+	logSize    int
+
+	// mu serializes publishing with subscribing, so subscribers see events in ID order
+	// and none is both replayed from the log and delivered live
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewEventService creates the event bus; the log keeps the most recent logSize events for resuming streams
+func NewEventService(repository ports.EventRepository, logSize int) ports.EventService {
+	return &eventService{
+		repository:  repository,
+		logSize:     logSize,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Publish logs the event and fans it out. A message the relay publishes again is already logged,
+// so it is not sent to subscribers a second time.
+func (s *eventService) Publish(message *domain.OutboxMessage) error {
+	event := &domain.Event{
+		DedupID:   message.DedupID,
+		TenantID:  message.TenantID,
+		Type:      message.Type,
+		PokemonID: message.PokemonID,
+		Pokemon:   message.Pokemon,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repository.Append(event); err != nil {
+		if err.Error() == "event already logged" {
+			return nil
+		}
+		return fmt.Errorf("failed to log %s event: %w", message.Type, err)
+	}
+	if event.ID > uint(s.logSize) {
+		if err := s.repository.DeleteThrough(event.ID - uint(s.logSize)); err != nil {
+			log.Printf("failed to trim event log: %v", err)
+		}
+	}
+
+	for sub := range s.subscribers {
+		if !sub.wants(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// Dropping a subscriber that fell behind keeps publishing from blocking; the client resumes from the log
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+	return nil
+}
+
+func (s *eventService) Subscribe(tenantID string, types []string, lastEventID uint) (*domain.EventSubscription, error) {
+	for _, eventType := range types {
+		if !domain.IsValidEventType(eventType) {
+			return nil, errors.New("unknown event type")
+		}
+	}
+
+	sub := &subscriber{tenantID: tenantID, types: types, events: make(chan *domain.Event, subscriberBuffer)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A new stream starts with new events; only a resumed one replays the log
+	var backlog []*domain.Event
+	if lastEventID > 0 {
+		var err error
+		backlog, err = s.repository.ListAfter(tenantID, lastEventID, types, s.logSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.subscribers[sub] = struct{}{}
+
+	return &domain.EventSubscription{
+		Backlog: backlog,
+		Live:    sub.events,
+		Close:   func() { s.unsubscribe(sub) },
+	}, nil
+}
+
+func (s *eventService) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
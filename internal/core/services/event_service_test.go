@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventRepository struct {
+	mock.Mock
+	nextID uint
+}
+
+// Append assigns increasing IDs like the database would, unless Return is given an error
+func (m *MockEventRepository) Append(event *domain.Event) error {
+	args := m.Called(event)
+	if args.Error(0) == nil {
+		m.nextID++
+		event.ID = m.nextID
+	}
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) ListAfter(tenantID string, afterID uint, types []string, limit int) ([]*domain.Event, error) {
+	args := m.Called(tenantID, afterID, types, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) DeleteThrough(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestEventService_PublishDeliversToMatchingSubscribers(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockRepo.On("Append", mock.AnythingOfType("*domain.Event")).Return(nil)
+	service := NewEventService(mockRepo, 100)
+
+	all, err := service.Subscribe(domain.DefaultTenantID, nil, 0)
+	assert.NoError(t, err)
+	deletes, err := service.Subscribe(domain.DefaultTenantID, []string{domain.EventPokemonDeleted}, 0)
+	assert.NoError(t, err)
+
+	pikachu := &domain.Pokemon{ID: 1, Name: "pikachu"}
+	assert.NoError(t, service.Publish(&domain.OutboxMessage{TenantID: domain.DefaultTenantID, DedupID: "a", Type: domain.EventPokemonCreated, PokemonID: 1, Pokemon: pikachu}))
+	assert.NoError(t, service.Publish(&domain.OutboxMessage{TenantID: domain.DefaultTenantID, DedupID: "b", Type: domain.EventPokemonDeleted, PokemonID: 1, Pokemon: pikachu}))
+
+	first := <-all.Live
+	assert.Equal(t, uint(1), first.ID)
+	assert.Equal(t, "a", first.DedupID)
+	assert.Equal(t, domain.EventPokemonCreated, first.Type)
+	assert.Equal(t, "pikachu", first.Pokemon.Name)
+	assert.Equal(t, uint(2), (<-all.Live).ID)
+
+	deleted := <-deletes.Live
+	assert.Equal(t, domain.EventPokemonDeleted, deleted.Type)
+	assert.Empty(t, deletes.Live)
+
+	all.Close()
+	_, open := <-all.Live
+	assert.False(t, open)
+	all.Close()
+}
+
+func TestEventService_PublishOnlyReachesTheEventsTenant(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockRepo.On("Append", mock.AnythingOfType("*domain.Event")).Return(nil)
+	service := NewEventService(mockRepo, 100)
+
+	kanto, err := service.Subscribe("kanto", nil, 0)
+	assert.NoError(t, err)
+	johto, err := service.Subscribe("johto", nil, 0)
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.Publish(&domain.OutboxMessage{TenantID: "kanto", DedupID: "a", Type: domain.EventPokemonCreated, PokemonID: 1}))
+
+	event := <-kanto.Live
+	assert.Equal(t, "kanto", event.TenantID)
+	assert.Empty(t, johto.Live)
+}
+
+func TestEventService_PublishTrimsLog(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockRepo.On("Append", mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepo.On("DeleteThrough", uint(1)).Return(nil).Once()
+	mockRepo.On("DeleteThrough", uint(2)).Return(errors.New("database error")).Once()
+	service := NewEventService(mockRepo, 2)
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, service.Publish(&domain.OutboxMessage{TenantID: domain.DefaultTenantID, DedupID: fmt.Sprint(i), Type: domain.EventPokemonCreated, PokemonID: uint(i + 1)}))
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEventService_PublishFailureIsNotDelivered(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockRepo.On("Append", mock.AnythingOfType("*domain.Event")).Return(errors.New("database error"))
+	service := NewEventService(mockRepo, 100)
+
+	sub, err := service.Subscribe(domain.DefaultTenantID, nil, 0)
+	assert.NoError(t, err)
+	err = service.Publish(&domain.OutboxMessage{TenantID: domain.DefaultTenantID, DedupID: "a", Type: domain.EventPokemonCreated, PokemonID: 1})
+
+	assert.EqualError(t, err, "failed to log pokemon.created event: database error")
+	assert.Empty(t, sub.Live)
+}
+
+func TestEventService_RepeatedMessageIsNotDeliveredAgain(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockRepo.On("Append", mock.MatchedBy(func(e *domain.Event) bool { return e.DedupID == "a" })).Return(nil).Once()
+	mockRepo.On("Append", mock.MatchedBy(func(e *domain.Event) bool { return e.DedupID == "a" })).Return(errors.New("event already logged")).Once()
+	service := NewEventService(mockRepo, 100)
+
+	sub, err := service.Subscribe(domain.DefaultTenantID, nil, 0)
+	assert.NoError(t, err)
+	message := &domain.OutboxMessage{TenantID: domain.DefaultTenantID, DedupID: "a", Type: domain.EventPokemonCreated, PokemonID: 1}
+	assert.NoError(t, service.Publish(message))
+	assert.NoError(t, service.Publish(message))
+
+	assert.Len(t, sub.Live, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEventService_SlowSubscriberIsDropped(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockRepo.On("Append", mock.AnythingOfType("*domain.Event")).Return(nil)
+	service := NewEventService(mockRepo, 1000)
+
+	sub, err := service.Subscribe(domain.DefaultTenantID, nil, 0)
+	assert.NoError(t, err)
+	for i := 0; i <= subscriberBuffer; i++ {
+		assert.NoError(t, service.Publish(&domain.OutboxMessage{TenantID: domain.DefaultTenantID, DedupID: fmt.Sprint(i), Type: domain.EventPokemonCreated, PokemonID: 1}))
+	}
+
+	received := 0
+	for range sub.Live {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+	sub.Close()
+}
+
+func TestEventService_Subscribe(t *testing.T) {
+	backlog := []*domain.Event{{ID: 8, Type: domain.EventPokemonCreated}, {ID: 9, Type: domain.EventPokemonCreated}}
+
+	tests := []struct {
+		name            string
+		types           []string
+		lastEventID     uint
+		setupMocks      func(*MockEventRepository)
+		expectedError   string
+		expectedBacklog []*domain.Event
+	}{
+		{
+			name:       "new stream does not replay",
+			setupMocks: func(repo *MockEventRepository) {},
+		},
+		{
+			name:        "resumed stream replays the log",
+			types:       []string{domain.EventPokemonCreated},
+			lastEventID: 7,
+			setupMocks: func(repo *MockEventRepository) {
+				repo.On("ListAfter", domain.DefaultTenantID, uint(7), []string{domain.EventPokemonCreated}, 100).Return(backlog, nil)
+			},
+			expectedBacklog: backlog,
+		},
+		{
+			name:          "unknown event type",
+			types:         []string{"pokemon.evolved"},
+			setupMocks:    func(repo *MockEventRepository) {},
+			expectedError: "unknown event type",
+		},
+		{
+			name:        "repository error",
+			lastEventID: 7,
+			setupMocks: func(repo *MockEventRepository) {
+				repo.On("ListAfter", domain.DefaultTenantID, uint(7), []string(nil), 100).Return(nil, errors.New("database error"))
+			},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewEventService(mockRepo, 100)
+			sub, err := service.Subscribe(domain.DefaultTenantID, tt.types, tt.lastEventID)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, sub)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBacklog, sub.Backlog)
+				sub.Close()
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"sync"
+	"time"
+)
+
+// maxIdempotencyKeyLength bounds keys so clients cannot store arbitrarily large values
+const maxIdempotencyKeyLength = 255
+
+// idempotencyPurgeInterval is how often expired records are removed
+const idempotencyPurgeInterval = time.Hour
+
+type idempotencyService struct {
+	repository ports.IdempotencyRepository
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu        sync.Mutex
+	lastPurge time.Time
+}
+
+// NewIdempotencyService creates the service; stored responses are replayed for ttl after the first request
+func NewIdempotencyService(repository ports.IdempotencyRepository, ttl time.Duration) ports.IdempotencyService {
+	return &idempotencyService{
+		repository: repository,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+func (s *idempotencyService) Begin(scope, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, errors.New("idempotency key must be between 1 and 255 characters")
+	}
+
+	now := s.now()
+	s.purgeExpired(now)
+
+	existing, err := s.repository.Get(scope, key)
+	switch {
+	case err == nil && existing.ExpiresAt.After(now):
+		if err := s.checkReplay(existing, fingerprint); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	case err == nil:
+		if err := s.repository.Delete(existing.ID); err != nil {
+			return nil, err
+		}
+	case err.Error() != "idempotency record not found":
+		return nil, err
+	}
+
+	record := &domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	if err := s.repository.Create(record); err != nil {
+		if err.Error() == "idempotency key already exists" {
+			// Another request reserved the key between the lookup and the insert
+			return nil, errors.New("a request with this idempotency key is still in progress")
+		}
+		return nil, fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+
+	return nil, nil
+}
+
+func (s *idempotencyService) Complete(scope, key string, statusCode int, contentType string, body []byte) error {
+	record, err := s.repository.Get(scope, key)
+	if err != nil {
+		return err
+	}
+
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.Body = body
+	return s.repository.Update(record)
+}
+
+func (s *idempotencyService) Release(scope, key string) error {
+	record, err := s.repository.Get(scope, key)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(record.ID)
+}
+
+func (s *idempotencyService) checkReplay(record *domain.IdempotencyRecord, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return errors.New("idempotency key was already used with a different request")
+	}
+	if !record.Completed() {
+		return errors.New("a request with this idempotency key is still in progress")
+	}
+	return nil
+}
+
+// purgeExpired deletes expired records at most once per purge interval; failures are retried next time
+func (s *idempotencyService) purgeExpired(now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPurge) < idempotencyPurgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurge = now
+	s.mu.Unlock()
+
+	if err := s.repository.DeleteExpired(now); err != nil {
+		s.mu.Lock()
+		s.lastPurge = time.Time{}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Create(record *domain.IdempotencyRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Get(scope, key string) (*domain.IdempotencyRecord, error) {
+	args := m.Called(scope, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) Update(record *domain.IdempotencyRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	completed := &domain.IdempotencyRecord{ID: 1, Scope: "api_key:ci", Key: "abc", Fingerprint: "f1", StatusCode: 201, Body: []byte(`{"id":1}`), ExpiresAt: now.Add(time.Hour)}
+
+	tests := []struct {
+		name          string
+		key           string
+		fingerprint   string
+		setupMocks    func(*MockIdempotencyRepository)
+		expectedError string
+		expectReplay  bool
+	}{
+		{
+			name:        "new key is reserved",
+			key:         "abc",
+			fingerprint: "f1",
+			setupMocks: func(repo *MockIdempotencyRepository) {
+				repo.On("Get", "api_key:ci", "abc").Return(nil, errors.New("idempotency record not found"))
+				repo.On("Create", mock.MatchedBy(func(record *domain.IdempotencyRecord) bool {
+					return record.Fingerprint == "f1" && !record.Completed() && record.ExpiresAt.Equal(now.Add(24*time.Hour))
+				})).Return(nil)
+			},
+		},
+		{
+			name:         "completed request is replayed",
+			key:          "abc",
+			fingerprint:  "f1",
+			setupMocks:   func(repo *MockIdempotencyRepository) { repo.On("Get", "api_key:ci", "abc").Return(completed, nil) },
+			expectReplay: true,
+		},
+		{
+			name:          "different request with the same key",
+			key:           "abc",
+			fingerprint:   "f2",
+			setupMocks:    func(repo *MockIdempotencyRepository) { repo.On("Get", "api_key:ci", "abc").Return(completed, nil) },
+			expectedError: "idempotency key was already used with a different request",
+		},
+		{
+			name:        "request still in progress",
+			key:         "abc",
+			fingerprint: "f1",
+			setupMocks: func(repo *MockIdempotencyRepository) {
+				repo.On("Get", "api_key:ci", "abc").Return(&domain.IdempotencyRecord{ID: 1, Fingerprint: "f1", ExpiresAt: now.Add(time.Hour)}, nil)
+			},
+			expectedError: "a request with this idempotency key is still in progress",
+		},
+		{
+			name:        "expired record is replaced",
+			key:         "abc",
+			fingerprint: "f2",
+			setupMocks: func(repo *MockIdempotencyRepository) {
+				repo.On("Get", "api_key:ci", "abc").Return(&domain.IdempotencyRecord{ID: 1, Fingerprint: "f1", StatusCode: 201, ExpiresAt: now}, nil)
+				repo.On("Delete", uint(1)).Return(nil)
+				repo.On("Create", mock.AnythingOfType("*domain.IdempotencyRecord")).Return(nil)
+			},
+		},
+		{
+			name:        "concurrent reservation",
+			key:         "abc",
+			fingerprint: "f1",
+			setupMocks: func(repo *MockIdempotencyRepository) {
+				repo.On("Get", "api_key:ci", "abc").Return(nil, errors.New("idempotency record not found"))
+				repo.On("Create", mock.AnythingOfType("*domain.IdempotencyRecord")).Return(errors.New("idempotency key already exists"))
+			},
+			expectedError: "a request with this idempotency key is still in progress",
+		},
+		{
+			name:          "key too long",
+			key:           strings.Repeat("k", 256),
+			setupMocks:    func(repo *MockIdempotencyRepository) {},
+			expectedError: "idempotency key must be between 1 and 255 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIdempotencyRepository)
+			mockRepo.On("DeleteExpired", now).Return(nil).Maybe()
+			tt.setupMocks(mockRepo)
+
+			service := NewIdempotencyService(mockRepo, 24*time.Hour).(*idempotencyService)
+			service.now = func() time.Time { return now }
+			record, err := service.Begin("api_key:ci", tt.key, tt.fingerprint)
+
+			switch {
+			case tt.expectedError != "":
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, record)
+			case tt.expectReplay:
+				assert.NoError(t, err)
+				assert.Equal(t, completed, record)
+			default:
+				assert.NoError(t, err)
+				assert.Nil(t, record)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdempotencyService_PurgesExpiredRecordsPeriodically(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := new(MockIdempotencyRepository)
+	mockRepo.On("Get", "ip:192.0.2.1", mock.Anything).Return(nil, errors.New("idempotency record not found"))
+	mockRepo.On("Create", mock.AnythingOfType("*domain.IdempotencyRecord")).Return(nil)
+	mockRepo.On("DeleteExpired", now).Return(nil).Once()
+	mockRepo.On("DeleteExpired", now.Add(2*time.Hour)).Return(nil).Once()
+
+	service := NewIdempotencyService(mockRepo, time.Hour).(*idempotencyService)
+	for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(2 * time.Hour)} {
+		service.now = func() time.Time { return at }
+		_, err := service.Begin("ip:192.0.2.1", "key-"+at.Format(time.Kitchen), "f1")
+		assert.NoError(t, err)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIdempotencyService_CompleteAndRelease(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	record := &domain.IdempotencyRecord{ID: 3, Scope: "api_key:ci", Key: "abc", Fingerprint: "f1"}
+	mockRepo.On("Get", "api_key:ci", "abc").Return(record, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(updated *domain.IdempotencyRecord) bool {
+		return updated.StatusCode == 201 && updated.ContentType == "application/json" && string(updated.Body) == `{"id":1}`
+	})).Return(nil)
+	mockRepo.On("Delete", uint(3)).Return(nil)
+
+	service := NewIdempotencyService(mockRepo, time.Hour)
+	assert.NoError(t, service.Complete("api_key:ci", "abc", 201, "application/json", []byte(`{"id":1}`)))
+	assert.NoError(t, service.Release("api_key:ci", "abc"))
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"log"
+	"pokemon-api/internal/core/ports"
+	"sync"
+	"time"
+)
+
+const (
+	// outboxBatchSize bounds how many messages one RelayPending call publishes
+	outboxBatchSize = 100
+	// outboxRetention is how long published messages are kept before they are purged
+	outboxRetention = 24 * time.Hour
+	// outboxPurgeInterval is how often published messages are purged
+	outboxPurgeInterval = time.Hour
+)
+
+type outboxRelay struct {
+	repository ports.OutboxRepository
+	publishers []ports.EventPublisher
+	now        func() time.Time
+
+	mu        sync.Mutex
+	lastPurge time.Time
+}
+
+// NewOutboxRelay creates the relay; every pending message is published to each of publishers
+func NewOutboxRelay(repository ports.OutboxRepository, publishers ...ports.EventPublisher) ports.OutboxRelay {
+	return &outboxRelay{
+		repository: repository,
+		publishers: publishers,
+		now:        time.Now,
+	}
+}
+
+// RelayPending publishes pending messages in order. It stops at the first failure, so no publisher sees a change
+// before the ones that came earlier; the failed message is published again, to every publisher, on the next run.
+func (r *outboxRelay) RelayPending() (int, error) {
+	r.purgePublished()
+
+	messages, err := r.repository.ListPending(outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, message := range messages {
+		for _, publisher := range r.publishers {
+			if err := publisher.Publish(message); err != nil {
+				if markErr := r.repository.MarkFailed(message.ID, err.Error()); markErr != nil {
+					log.Printf("failed to record outbox failure: %v", markErr)
+				}
+				return i, err
+			}
+		}
+		if err := r.repository.MarkPublished(message.ID, r.now()); err != nil {
+			return i, err
+		}
+	}
+
+	return len(messages), nil
+}
+
+// purgePublished deletes old published messages at most once per purge interval; failures are retried next time
+func (r *outboxRelay) purgePublished() {
+	now := r.now()
+
+	r.mu.Lock()
+	if now.Sub(r.lastPurge) < outboxPurgeInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastPurge = now
+	r.mu.Unlock()
+
+	if err := r.repository.DeletePublishedBefore(now.Add(-outboxRetention)); err != nil {
+		log.Printf("failed to purge outbox: %v", err)
+		r.mu.Lock()
+		r.lastPurge = time.Time{}
+		r.mu.Unlock()
+	}
+}
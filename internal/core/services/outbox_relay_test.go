@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) ListPending(limit int) ([]*domain.OutboxMessage, error) {
+	args := m.Called(limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkPublished(id uint, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(id uint, reason string) error {
+	args := m.Called(id, reason)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) DeletePublishedBefore(before time.Time) error {
+	args := m.Called(before)
+	return args.Error(0)
+}
+
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(message *domain.OutboxMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func TestOutboxRelay_RelayPending(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	first := &domain.OutboxMessage{ID: 1, DedupID: "a", Type: domain.EventPokemonCreated}
+	second := &domain.OutboxMessage{ID: 2, DedupID: "b", Type: domain.EventPokemonUpdated}
+	third := &domain.OutboxMessage{ID: 3, DedupID: "c", Type: domain.EventPokemonDeleted}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*MockOutboxRepository, *MockEventPublisher, *MockEventPublisher)
+		expectedCount int
+		expectedError string
+	}{
+		{
+			name: "publishes every message to every publisher in order",
+			setupMocks: func(repo *MockOutboxRepository, events, webhooks *MockEventPublisher) {
+				repo.On("ListPending", outboxBatchSize).Return([]*domain.OutboxMessage{first, second}, nil)
+				for _, message := range []*domain.OutboxMessage{first, second} {
+					events.On("Publish", message).Return(nil).Once()
+					webhooks.On("Publish", message).Return(nil).Once()
+					repo.On("MarkPublished", message.ID, now).Return(nil).Once()
+				}
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "stops at the first failure",
+			setupMocks: func(repo *MockOutboxRepository, events, webhooks *MockEventPublisher) {
+				repo.On("ListPending", outboxBatchSize).Return([]*domain.OutboxMessage{first, second, third}, nil)
+				events.On("Publish", first).Return(nil)
+				webhooks.On("Publish", first).Return(nil)
+				repo.On("MarkPublished", uint(1), now).Return(nil)
+				events.On("Publish", second).Return(nil)
+				webhooks.On("Publish", second).Return(errors.New("failed to queue webhook deliveries: database error"))
+				repo.On("MarkFailed", uint(2), "failed to queue webhook deliveries: database error").Return(nil)
+			},
+			expectedCount: 1,
+			expectedError: "failed to queue webhook deliveries: database error",
+		},
+		{
+			name: "nothing pending",
+			setupMocks: func(repo *MockOutboxRepository, events, webhooks *MockEventPublisher) {
+				repo.On("ListPending", outboxBatchSize).Return([]*domain.OutboxMessage{}, nil)
+			},
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *MockOutboxRepository, events, webhooks *MockEventPublisher) {
+				repo.On("ListPending", outboxBatchSize).Return(nil, errors.New("database error"))
+			},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOutboxRepository)
+			mockEvents := new(MockEventPublisher)
+			mockWebhooks := new(MockEventPublisher)
+			mockRepo.On("DeletePublishedBefore", now.Add(-outboxRetention)).Return(nil)
+			tt.setupMocks(mockRepo, mockEvents, mockWebhooks)
+
+			relay := NewOutboxRelay(mockRepo, mockEvents, mockWebhooks).(*outboxRelay)
+			relay.now = func() time.Time { return now }
+			count, err := relay.RelayPending()
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCount, count)
+			mockRepo.AssertExpectations(t)
+			mockEvents.AssertExpectations(t)
+			mockWebhooks.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOutboxRelay_PurgesPeriodically(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := new(MockOutboxRepository)
+	mockRepo.On("ListPending", outboxBatchSize).Return([]*domain.OutboxMessage{}, nil)
+	mockRepo.On("DeletePublishedBefore", now.Add(-outboxRetention)).Return(nil).Once()
+	mockRepo.On("DeletePublishedBefore", now.Add(time.Hour-outboxRetention)).Return(nil).Once()
+
+	relay := NewOutboxRelay(mockRepo).(*outboxRelay)
+	for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(time.Hour)} {
+		relay.now = func() time.Time { return at }
+		_, err := relay.RelayPending()
+		assert.NoError(t, err)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
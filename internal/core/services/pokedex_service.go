@@ -0,0 +1,234 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"sort"
+	"sync"
+)
+
+type pokedexService struct {
+	pokedexRepository ports.PokedexRepository
+	pokemonRepository ports.PokemonRepository
+	trainerRepository ports.TrainerRepository
+	apiClient         ports.PokemonAPIClient
+
+	// nationalDex is shared with the service's tenant-scoped copies
+	nationalDex *nationalDexCache
+}
+
+// nationalDexCache is fetched from PokeAPI on first use and kept for the life of the service
+type nationalDexCache struct {
+	mu      sync.Mutex
+	entries []domain.DexEntry
+}
+
+func NewPokedexService(pokedexRepository ports.PokedexRepository, pokemonRepository ports.PokemonRepository, trainerRepository ports.TrainerRepository, apiClient ports.PokemonAPIClient) ports.PokedexService {
+	return &pokedexService{
+		pokedexRepository: pokedexRepository,
+		pokemonRepository: pokemonRepository,
+		trainerRepository: trainerRepository,
+		apiClient:         apiClient,
+		nationalDex:       &nationalDexCache{},
+	}
+}
+
+func (s *pokedexService) ForTenant(tenantID string) ports.PokedexService {
+	return &pokedexService{
+		pokedexRepository: s.pokedexRepository.ForTenant(tenantID),
+		pokemonRepository: s.pokemonRepository.ForTenant(tenantID),
+		trainerRepository: s.trainerRepository.ForTenant(tenantID),
+		apiClient:         s.apiClient,
+		nationalDex:       s.nationalDex,
+	}
+}
+
+func (s *pokedexService) GetCompletion() (*domain.PokedexCompletion, error) {
+	dex, err := s.getNationalDex()
+	if err != nil {
+		return nil, err
+	}
+
+	pokemon, err := s.pokemonRepository.List()
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]bool, len(pokemon))
+	for _, p := range pokemon {
+		stored[p.Name] = true
+	}
+
+	return buildCompletion(dex, func(entry domain.DexEntry) (bool, bool) {
+		return stored[entry.Name], false
+	}), nil
+}
+
+// GetTrainerCompletion reports a trainer's progress; species the trainer currently owns count as caught
+func (s *pokedexService) GetTrainerCompletion(trainerID uint) (*domain.PokedexCompletion, error) {
+	if _, err := s.trainerRepository.GetByID(trainerID); err != nil {
+		return nil, err
+	}
+
+	dex, err := s.getNationalDex()
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := s.pokedexRepository.ListFlags(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	byNumber := make(map[int]*domain.PokedexFlag, len(flags))
+	for _, flag := range flags {
+		byNumber[flag.DexNumber] = flag
+	}
+
+	owned, err := s.trainerRepository.ListPokemon(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	ownedSpecies := make(map[string]bool, len(owned))
+	for _, o := range owned {
+		if o.Species != nil {
+			ownedSpecies[o.Species.Name] = true
+		}
+	}
+
+	completion := buildCompletion(dex, func(entry domain.DexEntry) (bool, bool) {
+		if ownedSpecies[entry.Name] {
+			return true, true
+		}
+		if flag, ok := byNumber[entry.Number]; ok {
+			return flag.Caught, flag.Seen || flag.Caught
+		}
+		return false, false
+	})
+	completion.TrainerID = trainerID
+	return completion, nil
+}
+
+// SetFlag updates a trainer's flags for one species; catching implies seeing, and unseeing clears caught
+func (s *pokedexService) SetFlag(trainerID uint, dexNumber int, req *domain.PokedexFlagRequest) (*domain.PokedexFlag, error) {
+	if _, err := s.trainerRepository.GetByID(trainerID); err != nil {
+		return nil, err
+	}
+
+	dex, err := s.getNationalDex()
+	if err != nil {
+		return nil, err
+	}
+	index := sort.Search(len(dex), func(i int) bool {
+		return dex[i].Number >= dexNumber
+	})
+	if index == len(dex) || dex[index].Number != dexNumber {
+		return nil, errors.New("pokemon not in national dex")
+	}
+
+	flags, err := s.pokedexRepository.ListFlags(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	flag := &domain.PokedexFlag{TrainerID: trainerID, DexNumber: dexNumber}
+	for _, existing := range flags {
+		if existing.DexNumber == dexNumber {
+			flag = existing
+			break
+		}
+	}
+
+	if req.Seen != nil {
+		flag.Seen = *req.Seen
+		if !flag.Seen {
+			flag.Caught = false
+		}
+	}
+	if req.Caught != nil {
+		flag.Caught = *req.Caught
+		if flag.Caught {
+			flag.Seen = true
+		}
+	}
+
+	if err := s.pokedexRepository.SaveFlag(flag); err != nil {
+		return nil, fmt.Errorf("failed to save pokedex flag: %w", err)
+	}
+
+	return flag, nil
+}
+
+func (s *pokedexService) getNationalDex() ([]domain.DexEntry, error) {
+	cache := s.nationalDex
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.entries != nil {
+		return cache.entries, nil
+	}
+
+	list, err := s.apiClient.GetPokemonList(domain.NationalDexSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch national dex: %w", err)
+	}
+
+	// Alternate forms are listed with numbers above 10000 and are not separate dex entries
+	var dex []domain.DexEntry
+	for _, entry := range list.DexEntries() {
+		if entry.Number <= domain.NationalDexSize {
+			dex = append(dex, entry)
+		}
+	}
+	sort.Slice(dex, func(i, j int) bool {
+		return dex[i].Number < dex[j].Number
+	})
+
+	cache.entries = dex
+	return dex, nil
+}
+
+// buildCompletion groups the national dex by generation; status reports whether an entry is registered and seen
+func buildCompletion(dex []domain.DexEntry, status func(domain.DexEntry) (registered, seen bool)) *domain.PokedexCompletion {
+	completion := &domain.PokedexCompletion{Generations: []domain.GenerationCompletion{}}
+	generations := make(map[int]int)
+
+	for _, entry := range dex {
+		number := domain.Generation(entry.Number)
+		index, ok := generations[number]
+		if !ok {
+			index = len(completion.Generations)
+			generations[number] = index
+			completion.Generations = append(completion.Generations, domain.GenerationCompletion{Generation: number, Missing: []domain.DexEntry{}})
+		}
+		generation := &completion.Generations[index]
+
+		registered, seen := status(entry)
+		generation.Total++
+		completion.Total++
+		if seen {
+			generation.Seen++
+			completion.Seen++
+		}
+		if registered {
+			generation.Registered++
+			completion.Registered++
+		} else {
+			generation.Missing = append(generation.Missing, entry)
+		}
+	}
+
+	completion.Percent = completionPercent(completion.Registered, completion.Total)
+	for i := range completion.Generations {
+		g := &completion.Generations[i]
+		g.Percent = completionPercent(g.Registered, g.Total)
+	}
+	return completion
+}
+
+func completionPercent(registered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(registered)*1000/float64(total)) / 10
+}
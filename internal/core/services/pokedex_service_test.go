@@ -0,0 +1,277 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPokedexRepository struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockPokedexRepository) ForTenant(tenantID string) ports.PokedexRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockPokedexRepository) ListFlags(trainerID uint) ([]*domain.PokedexFlag, error) {
+	args := m.Called(trainerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PokedexFlag), args.Error(1)
+}
+
+func (m *MockPokedexRepository) SaveFlag(flag *domain.PokedexFlag) error {
+	args := m.Called(flag)
+	return args.Error(0)
+}
+
+// nationalDexList mimics PokeAPI's /pokemon listing, including an alternate form beyond the national dex
+func nationalDexList(t *testing.T) *domain.ExternalPokemonListResponse {
+	entries := []domain.DexEntry{
+		{Number: 1, Name: "bulbasaur"},
+		{Number: 4, Name: "charmander"},
+		{Number: 6, Name: "charizard"},
+		{Number: 152, Name: "chikorita"},
+		{Number: 445, Name: "garchomp"},
+		{Number: 10034, Name: "charizard-mega-x"},
+	}
+	results := make([]map[string]string, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, map[string]string{
+			"name": entry.Name,
+			"url":  fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d/", entry.Number),
+		})
+	}
+	body, err := json.Marshal(map[string]interface{}{"count": len(results), "results": results})
+	assert.NoError(t, err)
+
+	var list domain.ExternalPokemonListResponse
+	assert.NoError(t, json.Unmarshal(body, &list))
+	return &list
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestPokedexService_GetCompletion(t *testing.T) {
+	mockPokedex := new(MockPokedexRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockClient := new(MockPokemonAPIClient)
+
+	mockClient.On("GetPokemonList", domain.NationalDexSize).Return(nationalDexList(t), nil).Once()
+	mockPokemon.On("List").Return([]*domain.Pokemon{charizard, garchomp, snorlax}, nil)
+
+	service := NewPokedexService(mockPokedex, mockPokemon, new(MockTrainerRepository), mockClient)
+
+	completion, err := service.GetCompletion()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, completion.Total)
+	assert.Equal(t, 2, completion.Registered)
+	assert.Equal(t, 40.0, completion.Percent)
+
+	assert.Len(t, completion.Generations, 3)
+	assert.Equal(t, domain.GenerationCompletion{
+		Generation: 1,
+		Total:      3,
+		Registered: 1,
+		Percent:    33.3,
+		Missing:    []domain.DexEntry{{Number: 1, Name: "bulbasaur"}, {Number: 4, Name: "charmander"}},
+	}, completion.Generations[0])
+	assert.Equal(t, 2, completion.Generations[1].Generation)
+	assert.Equal(t, []domain.DexEntry{{Number: 152, Name: "chikorita"}}, completion.Generations[1].Missing)
+	assert.Equal(t, 4, completion.Generations[2].Generation)
+	assert.Empty(t, completion.Generations[2].Missing)
+	assert.Equal(t, 100.0, completion.Generations[2].Percent)
+
+	// The national dex is only fetched from PokeAPI once
+	_, err = service.GetCompletion()
+	assert.NoError(t, err)
+
+	mockPokemon.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPokedexService_GetCompletion_PokeAPIError(t *testing.T) {
+	mockPokemon := new(MockPokemonRepository)
+	mockClient := new(MockPokemonAPIClient)
+	mockClient.On("GetPokemonList", domain.NationalDexSize).Return(nil, errors.New("PokeAPI returned status 503")).Once()
+	mockClient.On("GetPokemonList", domain.NationalDexSize).Return(nationalDexList(t), nil).Once()
+	mockPokemon.On("List").Return([]*domain.Pokemon{}, nil)
+
+	service := NewPokedexService(new(MockPokedexRepository), mockPokemon, new(MockTrainerRepository), mockClient)
+
+	completion, err := service.GetCompletion()
+	assert.EqualError(t, err, "failed to fetch national dex: PokeAPI returned status 503")
+	assert.Nil(t, completion)
+
+	// Failures are not cached, so the next request tries again
+	completion, err = service.GetCompletion()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, completion.Registered)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPokedexService_GetTrainerCompletion(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*MockPokedexRepository, *MockTrainerRepository, *MockPokemonAPIClient)
+		expectedError string
+		check         func(*testing.T, *domain.PokedexCompletion)
+	}{
+		{
+			name: "flags and owned pokemon",
+			setupMocks: func(pokedex *MockPokedexRepository, trainers *MockTrainerRepository, client *MockPokemonAPIClient) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1, Name: "ash"}, nil)
+				client.On("GetPokemonList", domain.NationalDexSize).Return(nationalDexList(t), nil)
+				pokedex.On("ListFlags", uint(1)).Return([]*domain.PokedexFlag{
+					{TrainerID: 1, DexNumber: 1, Seen: true},
+					{TrainerID: 1, DexNumber: 4, Seen: true, Caught: true},
+				}, nil)
+				trainers.On("ListPokemon", uint(1)).Return([]*domain.OwnedPokemon{
+					{TrainerID: 1, SpeciesID: 445, Species: garchomp},
+				}, nil)
+			},
+			check: func(t *testing.T, completion *domain.PokedexCompletion) {
+				assert.Equal(t, uint(1), completion.TrainerID)
+				assert.Equal(t, 5, completion.Total)
+				assert.Equal(t, 2, completion.Registered)
+				assert.Equal(t, 3, completion.Seen)
+				assert.Equal(t, 2, completion.Generations[0].Seen)
+				assert.Equal(t, []domain.DexEntry{{Number: 1, Name: "bulbasaur"}, {Number: 6, Name: "charizard"}}, completion.Generations[0].Missing)
+				assert.Equal(t, 1, completion.Generations[2].Registered)
+			},
+		},
+		{
+			name: "trainer not found",
+			setupMocks: func(pokedex *MockPokedexRepository, trainers *MockTrainerRepository, client *MockPokemonAPIClient) {
+				trainers.On("GetByID", uint(1)).Return(nil, errors.New("trainer not found"))
+			},
+			expectedError: "trainer not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPokedex := new(MockPokedexRepository)
+			mockTrainers := new(MockTrainerRepository)
+			mockClient := new(MockPokemonAPIClient)
+			tt.setupMocks(mockPokedex, mockTrainers, mockClient)
+
+			service := NewPokedexService(mockPokedex, new(MockPokemonRepository), mockTrainers, mockClient)
+			completion, err := service.GetTrainerCompletion(1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, completion)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, completion)
+			}
+
+			mockPokedex.AssertExpectations(t)
+			mockTrainers.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokedexService_SetFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		dexNumber     int
+		request       *domain.PokedexFlagRequest
+		existing      []*domain.PokedexFlag
+		expectedError string
+		expected      *domain.PokedexFlag
+	}{
+		{
+			name:      "caught implies seen",
+			dexNumber: 4,
+			request:   &domain.PokedexFlagRequest{Caught: boolPtr(true)},
+			expected:  &domain.PokedexFlag{TrainerID: 1, DexNumber: 4, Seen: true, Caught: true},
+		},
+		{
+			name:      "unseeing clears caught",
+			dexNumber: 4,
+			request:   &domain.PokedexFlagRequest{Seen: boolPtr(false)},
+			existing:  []*domain.PokedexFlag{{TrainerID: 1, DexNumber: 4, Seen: true, Caught: true}},
+			expected:  &domain.PokedexFlag{TrainerID: 1, DexNumber: 4},
+		},
+		{
+			name:      "releasing keeps seen",
+			dexNumber: 4,
+			request:   &domain.PokedexFlagRequest{Caught: boolPtr(false)},
+			existing:  []*domain.PokedexFlag{{TrainerID: 1, DexNumber: 4, Seen: true, Caught: true}},
+			expected:  &domain.PokedexFlag{TrainerID: 1, DexNumber: 4, Seen: true},
+		},
+		{
+			name:      "later generation",
+			dexNumber: 152,
+			request:   &domain.PokedexFlagRequest{Seen: boolPtr(true)},
+			expected:  &domain.PokedexFlag{TrainerID: 1, DexNumber: 152, Seen: true},
+		},
+		{
+			name:          "outside national dex",
+			dexNumber:     10034,
+			request:       &domain.PokedexFlagRequest{Seen: boolPtr(true)},
+			expectedError: "pokemon not in national dex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPokedex := new(MockPokedexRepository)
+			mockTrainers := new(MockTrainerRepository)
+			mockClient := new(MockPokemonAPIClient)
+
+			mockTrainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1, Name: "ash"}, nil)
+			mockClient.On("GetPokemonList", domain.NationalDexSize).Return(nationalDexList(t), nil)
+			if tt.expectedError == "" {
+				mockPokedex.On("ListFlags", uint(1)).Return(tt.existing, nil)
+				mockPokedex.On("SaveFlag", mock.AnythingOfType("*domain.PokedexFlag")).Return(nil)
+			}
+
+			service := NewPokedexService(mockPokedex, new(MockPokemonRepository), mockTrainers, mockClient)
+			flag, err := service.SetFlag(1, tt.dexNumber, tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, flag)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, flag)
+			}
+
+			mockPokedex.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokedexService_ForTenant(t *testing.T) {
+	mockPokedex := new(MockPokedexRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockTrainers := new(MockTrainerRepository)
+	mockTrainers.On("GetByID", uint(1)).Return(nil, errors.New("trainer not found"))
+
+	_, err := NewPokedexService(mockPokedex, mockPokemon, mockTrainers, new(MockPokemonAPIClient)).ForTenant("kanto").GetTrainerCompletion(1)
+
+	assert.EqualError(t, err, "trainer not found")
+	assert.Equal(t, "kanto", mockPokedex.tenantID)
+	assert.Equal(t, "kanto", mockPokemon.tenantID)
+	assert.Equal(t, "kanto", mockTrainers.tenantID)
+	mockTrainers.AssertExpectations(t)
+}
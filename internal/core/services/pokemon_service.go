@@ -4,43 +4,117 @@ import (
 	"errors"
 	"fmt"
 	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/names"
 	"pokemon-api/internal/core/ports"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// nameIndexSize covers every Pokemon PokeAPI lists, alternate forms included
+	nameIndexSize = 2000
+	// nameIndexTTL is how long the name index is used before it is fetched again
+	nameIndexTTL = 24 * time.Hour
+	// maxNameSuggestions bounds the "did you mean" list for an unknown name
+	maxNameSuggestions = 5
+
+	defaultSearchLimit  = 20
+	maxSearchLimit      = 100
+	maxSearchTextLength = 200
 )
 
 type pokemonService struct {
 	repository ports.PokemonRepository
 	apiClient  ports.PokemonAPIClient
+	// speciesNames resolves localized names such as "ピカチュウ"; like the name index, it is shared by every tenant
+	speciesNames ports.SpeciesNameRepository
+
+	// nameIndex is shared with the service's tenant-scoped copies, since PokeAPI's names are the same for every tenant
+	nameIndex *pokemonNameIndex
 }
 
-func NewPokemonService(repository ports.PokemonRepository, apiClient ports.PokemonAPIClient) ports.PokemonService {
+// pokemonNameIndex holds every PokeAPI Pokemon name, fetched on first use and refreshed after nameIndexTTL
+type pokemonNameIndex struct {
+	mu    sync.Mutex
+	list  []string
+	set   map[string]bool
+	taken time.Time
+}
+
+func NewPokemonService(repository ports.PokemonRepository, apiClient ports.PokemonAPIClient, speciesNames ports.SpeciesNameRepository) ports.PokemonService {
 	return &pokemonService{
-		repository: repository,
-		apiClient:  apiClient,
+		repository:   repository,
+		apiClient:    apiClient,
+		speciesNames: speciesNames,
+		nameIndex:    &pokemonNameIndex{},
+	}
+}
+
+func (s *pokemonService) ForTenant(tenantID string) ports.PokemonService {
+	return &pokemonService{
+		repository:   s.repository.ForTenant(tenantID),
+		apiClient:    s.apiClient,
+		speciesNames: s.speciesNames,
+		nameIndex:    s.nameIndex,
 	}
 }
 
 func (s *pokemonService) CreatePokemon(req *domain.CreatePokemonRequest) (*domain.Pokemon, error) {
-	existingPokemon, err := s.repository.GetByName(req.Name)
+	slug, err := s.resolveName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	existingPokemon, err := s.repository.GetByName(slug)
 	if err == nil && existingPokemon != nil {
 		return nil, errors.New("pokemon with this name already exists")
 	}
 
-	externalData, err := s.apiClient.GetPokemonData(req.Name)
+	externalData, err := s.apiClient.GetPokemonData(slug)
+	if err != nil {
+		var notFound *domain.ExternalNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, &domain.UnknownPokemonError{Name: req.Name, Suggestions: []string{}}
+		}
+		return nil, fmt.Errorf("failed to fetch Pokemon data: %w", err)
+	}
+
+	species, err := s.apiClient.GetSpeciesData(externalData.SpeciesName())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch Pokemon data: %w", err)
 	}
 
 	pokemon := &domain.Pokemon{
+		DexNumber: species.ID,
+
 		Name:    externalData.Name,
 		Type1:   req.Type1,
 		Type2:   req.Type2,
 		Height:  externalData.Height,
 		Weight:  externalData.Weight,
 		BaseExp: externalData.BaseExperience,
+
+		HP:        externalData.BaseStat("hp"),
+		Attack:    externalData.BaseStat("attack"),
+		Defense:   externalData.BaseStat("defense"),
+		SpAttack:  externalData.BaseStat("special-attack"),
+		SpDefense: externalData.BaseStat("special-defense"),
+		Speed:     externalData.BaseStat("speed"),
+
+		Abilities:    externalData.AbilityNames(),
+		FlavorText:   species.EnglishFlavorText(),
+		Generation:   domain.Generation(species.ID),
+		Sprites:      externalData.SpriteURLs(),
+		Translations: species.Translations(),
+
+		CreatedBy: req.CreatedBy,
 	}
 
-	if err := s.repository.Create(pokemon); err != nil {
+	by := domain.Attribution{Actor: req.CreatedBy, RequestID: req.RequestID, Source: domain.AuditSourceAPI}
+	if err := s.repository.Create(pokemon, by); err != nil {
 		return nil, fmt.Errorf("failed to save Pokemon: %w", err)
 	}
 
@@ -54,22 +128,350 @@ func (s *pokemonService) CreatePokemonFlexible(req *domain.FlexiblePokemonReques
 	}
 
 	standardReq := &domain.CreatePokemonRequest{
-		Name:  pokemonName,
-		Type1: req.Type1,
-		Type2: req.Type2,
+		Name:      pokemonName,
+		Type1:     req.Type1,
+		Type2:     req.Type2,
+		CreatedBy: req.CreatedBy,
+		RequestID: req.RequestID,
 	}
 
 	return s.CreatePokemon(standardReq)
 }
 
+// resolveName turns a requested name into a PokeAPI name. Names PokeAPI does not know are looked up in the
+// localized species name catalog, so "Pikachu" and "ピカチュウ" both resolve to pikachu.
+func (s *pokemonService) resolveName(name string) (string, error) {
+	slug := names.Slug(name)
+	key := domain.NormalizeLocalizedName(name)
+	if key == "" {
+		return "", errors.New("pokemon name is required")
+	}
+
+	// Without the index, PokeAPI itself decides whether the name exists
+	index, known, indexErr := s.nameIndexFor()
+	if slug != "" && (indexErr != nil || known[slug]) {
+		return slug, nil
+	}
+
+	pokemon, err := s.speciesNames.FindPokemon(key)
+	if err == nil {
+		return pokemon, nil
+	}
+	if err.Error() != "species name not found" {
+		return "", fmt.Errorf("failed to look up species name: %w", err)
+	}
+
+	suggestions := []string{}
+	if indexErr == nil && slug != "" {
+		suggestions = names.Suggest(slug, index, maxNameSuggestions)
+	}
+	return "", &domain.UnknownPokemonError{Name: name, Suggestions: suggestions}
+}
+
 func (s *pokemonService) GetPokemon(id uint) (*domain.Pokemon, error) {
 	return s.repository.GetByID(id)
 }
 
+func (s *pokemonService) GetPokemonByName(name string) (*domain.Pokemon, error) {
+	if slug := names.Slug(name); slug != "" {
+		pokemon, err := s.repository.GetByName(slug)
+		if err == nil || err.Error() != "pokemon not found" {
+			return pokemon, err
+		}
+	}
+
+	// Localized names such as "ピカチュウ" are looked up in the species name catalog
+	pokemonName, err := s.speciesNames.FindPokemon(domain.NormalizeLocalizedName(name))
+	if err != nil {
+		return nil, errors.New("pokemon not found")
+	}
+	return s.repository.GetByName(pokemonName)
+}
+
+func (s *pokemonService) GetPokemonByDexNumber(number int) (*domain.Pokemon, error) {
+	if number < 1 || number > domain.NationalDexSize {
+		return nil, errors.New("dex number must be between 1 and 1025")
+	}
+	return s.repository.GetByDexNumber(number)
+}
+
 func (s *pokemonService) ListPokemon() ([]*domain.Pokemon, error) {
 	return s.repository.List()
 }
 
+func (s *pokemonService) ListPokemonPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error) {
+	if query.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	types := make([]string, 0, len(query.Types))
+	for _, t := range query.Types {
+		types = append(types, strings.ToLower(t))
+	}
+	query.Types = types
+	query.NameContains = strings.ToLower(query.NameContains)
+	return s.repository.ListPage(query)
+}
+
+func (s *pokemonService) UpdatePokemon(id uint, req *domain.UpdatePokemonRequest, expected *time.Time, by domain.Attribution) (*domain.Pokemon, error) {
+	pokemon, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pokemon.Type1 = req.Type1
+	pokemon.Type2 = req.Type2
+	pokemon.Normalize()
+	if err := pokemon.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(pokemon, expected, withDefaultSource(by)); err != nil {
+		return nil, fmt.Errorf("failed to save Pokemon: %w", err)
+	}
+
+	return pokemon, nil
+}
+
+func (s *pokemonService) DeletePokemon(id uint, expected *time.Time, by domain.Attribution) error {
+	return s.repository.Delete(id, expected, withDefaultSource(by))
+}
+
+func (s *pokemonService) ListDeletedPokemon() ([]*domain.Pokemon, error) {
+	return s.repository.ListDeleted()
+}
+
+func (s *pokemonService) RestorePokemon(id uint, by domain.Attribution) (*domain.Pokemon, error) {
+	return s.repository.Restore(id, withDefaultSource(by))
+}
+
+func (s *pokemonService) PurgeDeletedPokemon(retention time.Duration) (int, error) {
+	if retention <= 0 {
+		return 0, errors.New("trash retention must be positive")
+	}
+	return s.repository.PurgeDeleted(time.Now().Add(-retention), domain.Attribution{Source: domain.AuditSourceJob})
+}
+
+func (s *pokemonService) GetPokemonHistory(id uint) ([]*domain.PokemonAudit, error) {
+	entries, err := s.repository.History(id)
+	if err != nil {
+		return nil, err
+	}
+	// Pokemon stored before history was recorded have none, but still exist
+	if len(entries) == 0 {
+		if _, err := s.repository.GetByID(id); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (s *pokemonService) GetPokemonAsOf(id uint, at time.Time) (*domain.Pokemon, error) {
+	return s.repository.AsOf(id, at)
+}
+
+// withDefaultSource records a change as made through the API when the caller did not say otherwise
+func withDefaultSource(by domain.Attribution) domain.Attribution {
+	if by.Source == "" {
+		by.Source = domain.AuditSourceAPI
+	}
+	return by
+}
+
+func (s *pokemonService) ExportPokemon(fn func(*domain.Pokemon) error) error {
+	return s.repository.Each(fn)
+}
+
+func (s *pokemonService) ImportPokemon(rows []domain.PokemonImportRow, by domain.Attribution, dryRun bool) (*domain.PokemonImportReport, error) {
+	if len(rows) > domain.MaxImportRows {
+		return nil, errors.New("import cannot have more than 5000 rows")
+	}
+
+	report := &domain.PokemonImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Errors: []domain.PokemonImportError{},
+	}
+	reject := func(row domain.PokemonImportRow, name, reason string) {
+		report.Errors = append(report.Errors, domain.PokemonImportError{Row: row.Row, Name: name, Error: reason})
+	}
+
+	// firstRow remembers where each name appeared, so a file cannot import the same species twice
+	firstRow := make(map[string]int)
+	by.Source = domain.AuditSourceImport
+	for _, row := range rows {
+		if row.Error != "" {
+			reject(row, "", row.Error)
+			continue
+		}
+
+		pokemon := importedPokemon(row.Pokemon, by.Actor)
+		if err := pokemon.Validate(); err != nil {
+			reject(row, pokemon.Name, err.Error())
+			continue
+		}
+		if first, ok := firstRow[pokemon.Name]; ok {
+			reject(row, pokemon.Name, fmt.Sprintf("duplicate of row %d", first))
+			continue
+		}
+		firstRow[pokemon.Name] = row.Row
+
+		_, err := s.repository.GetByName(pokemon.Name)
+		if err == nil {
+			reject(row, pokemon.Name, "pokemon with this name already exists")
+			continue
+		}
+		if err.Error() != "pokemon not found" {
+			return nil, err
+		}
+
+		if !dryRun {
+			if err := s.repository.Create(pokemon, by); err != nil {
+				reject(row, pokemon.Name, fmt.Sprintf("failed to save Pokemon: %v", err))
+				continue
+			}
+			report.Imported++
+		}
+		report.Valid++
+	}
+
+	report.Failed = len(report.Errors)
+	return report, nil
+}
+
+// importedPokemon copies the importable fields of a decoded row; IDs and timestamps in the file are ignored
+func importedPokemon(row *domain.Pokemon, createdBy string) *domain.Pokemon {
+	pokemon := &domain.Pokemon{
+		Name:    row.Name,
+		Type1:   row.Type1,
+		Type2:   row.Type2,
+		Height:  row.Height,
+		Weight:  row.Weight,
+		BaseExp: row.BaseExp,
+
+		HP:        row.HP,
+		Attack:    row.Attack,
+		Defense:   row.Defense,
+		SpAttack:  row.SpAttack,
+		SpDefense: row.SpDefense,
+		Speed:     row.Speed,
+
+		CreatedBy: createdBy,
+	}
+	pokemon.Normalize()
+	return pokemon
+}
+
+func (s *pokemonService) SearchPokemon(query *domain.PokemonSearchQuery) (*domain.PokemonSearchResult, error) {
+	text := strings.TrimSpace(query.Text)
+	switch {
+	case text == "":
+		return nil, errors.New("search query is required")
+	case len(text) > maxSearchTextLength:
+		return nil, errors.New("search query cannot be longer than 200 characters")
+	case query.Type != "" && !domain.IsValidType(query.Type):
+		return nil, errors.New("type must be a known type")
+	case query.Generation < 0:
+		return nil, errors.New("generation cannot be negative")
+	case query.Offset < 0:
+		return nil, errors.New("offset cannot be negative")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	hits, err := s.repository.Search(text)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []domain.PokemonSearchHit{}
+	types := make(map[string]int)
+	generations := make(map[string]int)
+	for _, hit := range hits {
+		pokemon := hit.Pokemon
+		matchesType := query.Type == "" || pokemon.Type1 == query.Type || pokemon.Type2 == query.Type
+		matchesGeneration := query.Generation == 0 || pokemon.Generation == query.Generation
+
+		// Each facet counts the hits the other facet's filter keeps, so a client narrowed to one type still sees
+		// every other type it could switch to
+		if matchesGeneration {
+			for _, t := range pokemon.Types() {
+				types[t]++
+			}
+		}
+		// Pokemon stored before generations were recorded have none
+		if matchesType && pokemon.Generation != 0 {
+			generations[strconv.Itoa(pokemon.Generation)]++
+		}
+
+		if matchesType && matchesGeneration {
+			filtered = append(filtered, hit)
+		}
+	}
+
+	start := min(query.Offset, len(filtered))
+	end := min(start+limit, len(filtered))
+	return &domain.PokemonSearchResult{
+		Query:  text,
+		Total:  len(filtered),
+		Limit:  limit,
+		Offset: query.Offset,
+		Hits:   filtered[start:end],
+		Facets: domain.PokemonSearchFacets{
+			Type:       facetCounts(types),
+			Generation: facetCounts(generations),
+		},
+	}, nil
+}
+
+// facetCounts orders facet values by count, most common first, then by value
+func facetCounts(counts map[string]int) []domain.FacetCount {
+	facets := make([]domain.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, domain.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	return facets
+}
+
+// nameIndexFor returns the cached PokeAPI names as a list and a set, fetching them when missing or stale.
+// A failed fetch is not cached, so the next create tries again.
+func (s *pokemonService) nameIndexFor() ([]string, map[string]bool, error) {
+	cache := s.nameIndex
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.list != nil && time.Since(cache.taken) < nameIndexTTL {
+		return cache.list, cache.set, nil
+	}
+
+	list, err := s.apiClient.GetPokemonList(nameIndexSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch Pokemon names: %w", err)
+	}
+	if len(list.Results) == 0 {
+		return nil, nil, errors.New("PokeAPI returned no Pokemon names")
+	}
+
+	index := make([]string, 0, len(list.Results))
+	set := make(map[string]bool, len(list.Results))
+	for _, result := range list.Results {
+		index = append(index, result.Name)
+		set[result.Name] = true
+	}
+
+	cache.list, cache.set, cache.taken = index, set, time.Now()
+	return index, set, nil
+}
+
 func (s *pokemonService) extractPokemonName(input *domain.FlexiblePokemonRequest) string {
 	if input.Name != "" {
 		return strings.ToLower(strings.TrimSpace(input.Name))
@@ -3,7 +3,9 @@ package services
 import (
 	"errors"
 	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -11,10 +13,18 @@ import (
 
 type MockPokemonRepository struct {
 	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
 }
 
-func (m *MockPokemonRepository) Create(pokemon *domain.Pokemon) error {
-	args := m.Called(pokemon)
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockPokemonRepository) ForTenant(tenantID string) ports.PokemonRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockPokemonRepository) Create(pokemon *domain.Pokemon, by domain.Attribution) error {
+	args := m.Called(pokemon, by)
 	return args.Error(0)
 }
 
@@ -31,11 +41,93 @@ func (m *MockPokemonRepository) GetByName(name string) (*domain.Pokemon, error)
 	return args.Get(0).(*domain.Pokemon), args.Error(1)
 }
 
+func (m *MockPokemonRepository) GetByDexNumber(number int) (*domain.Pokemon, error) {
+	args := m.Called(number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
 func (m *MockPokemonRepository) List() ([]*domain.Pokemon, error) {
 	args := m.Called()
 	return args.Get(0).([]*domain.Pokemon), args.Error(1)
 }
 
+func (m *MockPokemonRepository) ListPage(query domain.PokemonPageQuery) (*domain.PokemonPage, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonPage), args.Error(1)
+}
+
+func (m *MockPokemonRepository) Update(pokemon *domain.Pokemon, expected *time.Time, by domain.Attribution) error {
+	args := m.Called(pokemon, expected, by)
+	return args.Error(0)
+}
+
+func (m *MockPokemonRepository) Delete(id uint, expected *time.Time, by domain.Attribution) error {
+	args := m.Called(id, expected, by)
+	return args.Error(0)
+}
+
+func (m *MockPokemonRepository) ListDeleted() ([]*domain.Pokemon, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonRepository) Restore(id uint, by domain.Attribution) (*domain.Pokemon, error) {
+	args := m.Called(id, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+func (m *MockPokemonRepository) PurgeDeleted(before time.Time, by domain.Attribution) (int, error) {
+	args := m.Called(before, by)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPokemonRepository) History(id uint) ([]*domain.PokemonAudit, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PokemonAudit), args.Error(1)
+}
+
+func (m *MockPokemonRepository) AsOf(id uint, at time.Time) (*domain.Pokemon, error) {
+	args := m.Called(id, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokemon), args.Error(1)
+}
+
+// Each hands the Pokemon given to Return to fn, then returns the error given to Return
+func (m *MockPokemonRepository) Each(fn func(*domain.Pokemon) error) error {
+	args := m.Called()
+	for _, pokemon := range args.Get(0).([]*domain.Pokemon) {
+		if err := fn(pokemon); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockPokemonRepository) Search(text string) ([]domain.PokemonSearchHit, error) {
+	args := m.Called(text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PokemonSearchHit), args.Error(1)
+}
+
 type MockPokemonAPIClient struct {
 	mock.Mock
 }
@@ -48,6 +140,42 @@ func (m *MockPokemonAPIClient) GetPokemonData(identifier string) (*domain.Extern
 	return args.Get(0).(*domain.ExternalPokemonResponse), args.Error(1)
 }
 
+func (m *MockPokemonAPIClient) GetMoveData(identifier string) (*domain.ExternalMoveResponse, error) {
+	args := m.Called(identifier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExternalMoveResponse), args.Error(1)
+}
+
+func (m *MockPokemonAPIClient) GetSpeciesData(identifier string) (*domain.ExternalSpeciesResponse, error) {
+	args := m.Called(identifier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExternalSpeciesResponse), args.Error(1)
+}
+
+func (m *MockPokemonAPIClient) GetPokemonList(limit int) (*domain.ExternalPokemonListResponse, error) {
+	args := m.Called(limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExternalPokemonListResponse), args.Error(1)
+}
+
+// pokemonNames mimics PokeAPI's /pokemon listing of the given names
+func pokemonNames(names ...string) *domain.ExternalPokemonListResponse {
+	list := &domain.ExternalPokemonListResponse{Count: len(names)}
+	for _, name := range names {
+		list.Results = append(list.Results, struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		}{Name: name})
+	}
+	return list
+}
+
 func TestPokemonService_CreatePokemon(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -72,7 +200,7 @@ func TestPokemonService_CreatePokemon(t *testing.T) {
 					Weight:         60,
 					BaseExperience: 112,
 				}, nil)
-				repo.On("Create", mock.AnythingOfType("*domain.Pokemon")).Return(nil)
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(nil)
 			},
 			expectedResult: &domain.Pokemon{
 				Name:    "pikachu",
@@ -98,16 +226,65 @@ func TestPokemonService_CreatePokemon(t *testing.T) {
 			expectedError: "pokemon with this name already exists",
 		},
 		{
-			name: "external API error",
+			name: "display name is converted to a PokeAPI slug",
 			request: &domain.CreatePokemonRequest{
-				Name:  "invalid-pokemon",
+				Name:  "Mr. Mime",
+				Type1: "psychic",
+				Type2: "fairy",
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
+				repo.On("GetByName", "mr-mime").Return(nil, errors.New("not found"))
+				client.On("GetPokemonData", "mr-mime").Return(&domain.ExternalPokemonResponse{
+					ID:             122,
+					Name:           "mr-mime",
+					Height:         13,
+					Weight:         545,
+					BaseExperience: 161,
+				}, nil)
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(nil)
+			},
+			expectedResult: &domain.Pokemon{
+				Name:    "mr-mime",
+				Type1:   "psychic",
+				Type2:   "fairy",
+				Height:  13,
+				Weight:  545,
+				BaseExp: 161,
+			},
+		},
+		{
+			name: "unknown name is rejected with suggestions",
+			request: &domain.CreatePokemonRequest{
+				Name:  "Charzard",
 				Type1: "fire",
 			},
+			setupMocks:    func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {},
+			expectedError: `unknown pokemon "Charzard", did you mean charizard?`,
+		},
+		{
+			name: "unknown name without the index",
+			request: &domain.CreatePokemonRequest{
+				Name:  "missingno",
+				Type1: "normal",
+			},
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
+				repo.On("GetByName", "missingno").Return(nil, errors.New("not found"))
+				client.On("GetPokemonList", nameIndexSize).Return(nil, errors.New("PokeAPI returned status 503"))
+				client.On("GetPokemonData", "missingno").Return(nil, &domain.ExternalNotFoundError{Resource: "pokemon", Identifier: "missingno"})
+			},
+			expectedError: `unknown pokemon "missingno"`,
+		},
+		{
+			name: "external API error",
+			request: &domain.CreatePokemonRequest{
+				Name:  "pikachu",
+				Type1: "electric",
+			},
 			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
-				repo.On("GetByName", "invalid-pokemon").Return(nil, errors.New("not found"))
-				client.On("GetPokemonData", "invalid-pokemon").Return(nil, errors.New("pokemon not found"))
+				repo.On("GetByName", "pikachu").Return(nil, errors.New("not found"))
+				client.On("GetPokemonData", "pikachu").Return(nil, errors.New("PokeAPI returned status 502"))
 			},
-			expectedError: "failed to fetch Pokemon data: pokemon not found",
+			expectedError: "failed to fetch Pokemon data: PokeAPI returned status 502",
 		},
 		{
 			name: "repository save error",
@@ -124,7 +301,7 @@ func TestPokemonService_CreatePokemon(t *testing.T) {
 					Weight:         905,
 					BaseExperience: 267,
 				}, nil)
-				repo.On("Create", mock.AnythingOfType("*domain.Pokemon")).Return(errors.New("database error"))
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(errors.New("database error"))
 			},
 			expectedError: "failed to save Pokemon: database error",
 		},
@@ -135,8 +312,12 @@ func TestPokemonService_CreatePokemon(t *testing.T) {
 			mockRepo := new(MockPokemonRepository)
 			mockClient := new(MockPokemonAPIClient)
 			tt.setupMocks(mockRepo, mockClient)
+			mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("pikachu", "charmander", "charizard", "mr-mime"), nil).Maybe()
+			mockClient.On("GetSpeciesData", mock.Anything).Return(&domain.ExternalSpeciesResponse{ID: 25}, nil).Maybe()
+			speciesNames := new(MockSpeciesNameRepository)
+			speciesNames.On("FindPokemon", mock.Anything).Return("", errors.New("species name not found")).Maybe()
 
-			service := NewPokemonService(mockRepo, mockClient)
+			service := NewPokemonService(mockRepo, mockClient, speciesNames)
 			result, err := service.CreatePokemon(tt.request)
 
 			if tt.expectedError != "" {
@@ -160,6 +341,145 @@ func TestPokemonService_CreatePokemon(t *testing.T) {
 	}
 }
 
+func TestPokemonService_CreatePokemon_NameIndex(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockClient := new(MockPokemonAPIClient)
+	mockRepo.On("GetByName", mock.Anything).Return(nil, errors.New("not found"))
+	mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("charmander", "charmeleon", "charizard"), nil).Once()
+	speciesNames := new(MockSpeciesNameRepository)
+	speciesNames.On("FindPokemon", mock.Anything).Return("", errors.New("species name not found"))
+	service := NewPokemonService(mockRepo, mockClient, speciesNames)
+
+	_, err := service.CreatePokemon(&domain.CreatePokemonRequest{Name: "charmaner", Type1: "fire"})
+	var unknown *domain.UnknownPokemonError
+	assert.ErrorAs(t, err, &unknown)
+	assert.Equal(t, "charmaner", unknown.Name)
+	assert.Equal(t, "charmander", unknown.Suggestions[0])
+
+	// The index is cached, so a second unknown name does not list PokeAPI again
+	_, err = service.CreatePokemon(&domain.CreatePokemonRequest{Name: "charmelon", Type1: "fire"})
+	assert.ErrorAs(t, err, &unknown)
+	assert.Equal(t, []string{"charmeleon"}, unknown.Suggestions)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPokemonService_CreatePokemon_SpeciesData(t *testing.T) {
+	external := &domain.ExternalPokemonResponse{ID: 10001, Name: "deoxys-attack"}
+	external.Species.Name = "deoxys"
+	external.Abilities = append(external.Abilities, struct {
+		Ability struct {
+			Name string `json:"name"`
+		} `json:"ability"`
+	}{})
+	external.Abilities[0].Ability.Name = "pressure"
+	external.Sprites.FrontDefault = "https://sprites.example/10001.png"
+	external.Sprites.Other.OfficialArtwork.FrontDefault = "https://sprites.example/artwork/10001.png"
+	species := &domain.ExternalSpeciesResponse{ID: 386, Name: "deoxys"}
+	species.FlavorTextEntries = append(species.FlavorTextEntries, struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	}{FlavorText: "The DNA of a\nspace virus"})
+	species.FlavorTextEntries[0].Language.Name = "en"
+
+	t.Run("stores abilities, flavor text, generation and sprites", func(t *testing.T) {
+		mockRepo := new(MockPokemonRepository)
+		mockClient := new(MockPokemonAPIClient)
+		mockRepo.On("GetByName", "deoxys-attack").Return(nil, errors.New("not found"))
+		mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("deoxys-attack"), nil)
+		mockClient.On("GetPokemonData", "deoxys-attack").Return(external, nil)
+		mockClient.On("GetSpeciesData", "deoxys").Return(species, nil)
+		mockRepo.On("Create", mock.AnythingOfType("*domain.Pokemon"), domain.Attribution{Actor: "ash", RequestID: "req-1", Source: domain.AuditSourceAPI}).Return(nil)
+
+		result, err := NewPokemonService(mockRepo, mockClient, new(MockSpeciesNameRepository)).CreatePokemon(&domain.CreatePokemonRequest{Name: "deoxys-attack", Type1: "psychic", CreatedBy: "ash", RequestID: "req-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pressure"}, result.Abilities)
+		assert.Equal(t, "The DNA of a space virus", result.FlavorText)
+		assert.Equal(t, 386, result.DexNumber)
+		assert.Equal(t, 3, result.Generation)
+		assert.Equal(t, domain.PokemonSprites{Default: "https://sprites.example/10001.png", Artwork: "https://sprites.example/artwork/10001.png"}, result.Sprites)
+	})
+
+	t.Run("species lookup failure", func(t *testing.T) {
+		mockRepo := new(MockPokemonRepository)
+		mockClient := new(MockPokemonAPIClient)
+		mockRepo.On("GetByName", "deoxys-attack").Return(nil, errors.New("not found"))
+		mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("deoxys-attack"), nil)
+		mockClient.On("GetPokemonData", "deoxys-attack").Return(external, nil)
+		mockClient.On("GetSpeciesData", "deoxys").Return(nil, errors.New("PokeAPI returned status 500"))
+
+		_, err := NewPokemonService(mockRepo, mockClient, new(MockSpeciesNameRepository)).CreatePokemon(&domain.CreatePokemonRequest{Name: "deoxys-attack", Type1: "psychic"})
+
+		assert.EqualError(t, err, "failed to fetch Pokemon data: PokeAPI returned status 500")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPokemonService_CreatePokemon_LocalizedName(t *testing.T) {
+	external := &domain.ExternalPokemonResponse{ID: 25, Name: "pikachu"}
+	external.Species.Name = "pikachu"
+	species := namedSpecies(25, "pikachu", map[string]string{"en": "Pikachu", "ja-Hrkt": "ピカチュウ"})
+	species.FlavorTextEntries = append(species.FlavorTextEntries, struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	}{FlavorText: "It stores\nelectricity."})
+	species.FlavorTextEntries[0].Language.Name = "en"
+
+	t.Run("resolved through the species name catalog", func(t *testing.T) {
+		mockRepo := new(MockPokemonRepository)
+		mockClient := new(MockPokemonAPIClient)
+		speciesNames := new(MockSpeciesNameRepository)
+		mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("pikachu"), nil)
+		speciesNames.On("FindPokemon", "ピカチュウ").Return("pikachu", nil)
+		mockRepo.On("GetByName", "pikachu").Return(nil, errors.New("pokemon not found"))
+		mockClient.On("GetPokemonData", "pikachu").Return(external, nil)
+		mockClient.On("GetSpeciesData", "pikachu").Return(species, nil)
+		mockRepo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(nil)
+
+		result, err := NewPokemonService(mockRepo, mockClient, speciesNames).CreatePokemon(&domain.CreatePokemonRequest{Name: "ﾋﾟｶﾁｭｳ", Type1: "electric"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pikachu", result.Name)
+		assert.Equal(t, map[string]domain.PokemonTranslation{
+			"en":      {Name: "Pikachu", FlavorText: "It stores electricity."},
+			"ja-hrkt": {Name: "ピカチュウ"},
+		}, result.Translations)
+	})
+
+	t.Run("a known PokeAPI name skips the catalog", func(t *testing.T) {
+		mockRepo := new(MockPokemonRepository)
+		mockClient := new(MockPokemonAPIClient)
+		speciesNames := new(MockSpeciesNameRepository)
+		mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("pikachu"), nil)
+		mockRepo.On("GetByName", "pikachu").Return(&domain.Pokemon{ID: 1, Name: "pikachu"}, nil)
+
+		_, err := NewPokemonService(mockRepo, mockClient, speciesNames).CreatePokemon(&domain.CreatePokemonRequest{Name: "Pikachu", Type1: "electric"})
+
+		assert.EqualError(t, err, "pokemon with this name already exists")
+		speciesNames.AssertNotCalled(t, "FindPokemon", mock.Anything)
+	})
+
+	t.Run("unknown in every language", func(t *testing.T) {
+		mockRepo := new(MockPokemonRepository)
+		mockClient := new(MockPokemonAPIClient)
+		speciesNames := new(MockSpeciesNameRepository)
+		mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("pikachu"), nil)
+		speciesNames.On("FindPokemon", "ピカチュ").Return("", errors.New("species name not found"))
+
+		_, err := NewPokemonService(mockRepo, mockClient, speciesNames).CreatePokemon(&domain.CreatePokemonRequest{Name: "ピカチュ", Type1: "electric"})
+
+		var unknown *domain.UnknownPokemonError
+		assert.ErrorAs(t, err, &unknown)
+		assert.Equal(t, []string{}, unknown.Suggestions)
+		mockRepo.AssertNotCalled(t, "GetByName", mock.Anything)
+	})
+}
+
 func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -171,8 +491,9 @@ func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 		{
 			name: "direct name format",
 			request: &domain.FlexiblePokemonRequest{
-				Name:  "pikachu",
-				Type1: "electric",
+				Name:      "pikachu",
+				Type1:     "electric",
+				CreatedBy: "ash",
 			},
 			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient) {
 				repo.On("GetByName", "pikachu").Return(nil, errors.New("not found"))
@@ -182,14 +503,15 @@ func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 					Weight:         60,
 					BaseExperience: 112,
 				}, nil)
-				repo.On("Create", mock.AnythingOfType("*domain.Pokemon")).Return(nil)
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(nil)
 			},
 			expectedResult: &domain.Pokemon{
-				Name:    "pikachu",
-				Type1:   "electric",
-				Height:  4,
-				Weight:  60,
-				BaseExp: 112,
+				Name:      "pikachu",
+				Type1:     "electric",
+				Height:    4,
+				Weight:    60,
+				BaseExp:   112,
+				CreatedBy: "ash",
 			},
 		},
 		{
@@ -208,7 +530,7 @@ func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 					Weight:         905,
 					BaseExperience: 267,
 				}, nil)
-				repo.On("Create", mock.AnythingOfType("*domain.Pokemon")).Return(nil)
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(nil)
 			},
 			expectedResult: &domain.Pokemon{
 				Name:    "charizard",
@@ -234,7 +556,7 @@ func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 					Weight:         90,
 					BaseExperience: 63,
 				}, nil)
-				repo.On("Create", mock.AnythingOfType("*domain.Pokemon")).Return(nil)
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(nil)
 			},
 			expectedResult: &domain.Pokemon{
 				Name:    "squirtle",
@@ -260,8 +582,10 @@ func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 			mockRepo := new(MockPokemonRepository)
 			mockClient := new(MockPokemonAPIClient)
 			tt.setupMocks(mockRepo, mockClient)
+			mockClient.On("GetPokemonList", nameIndexSize).Return(pokemonNames("pikachu", "charizard", "squirtle"), nil).Maybe()
+			mockClient.On("GetSpeciesData", mock.Anything).Return(&domain.ExternalSpeciesResponse{ID: 25}, nil).Maybe()
 
-			service := NewPokemonService(mockRepo, mockClient)
+			service := NewPokemonService(mockRepo, mockClient, new(MockSpeciesNameRepository))
 			result, err := service.CreatePokemonFlexible(tt.request)
 
 			if tt.expectedError != "" {
@@ -276,6 +600,7 @@ func TestPokemonService_CreatePokemonFlexible(t *testing.T) {
 				assert.Equal(t, tt.expectedResult.Height, result.Height)
 				assert.Equal(t, tt.expectedResult.Weight, result.Weight)
 				assert.Equal(t, tt.expectedResult.BaseExp, result.BaseExp)
+				assert.Equal(t, tt.expectedResult.CreatedBy, result.CreatedBy)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -330,7 +655,7 @@ func TestPokemonService_GetPokemon(t *testing.T) {
 			mockClient := new(MockPokemonAPIClient)
 			tt.setupMocks(mockRepo)
 
-			service := NewPokemonService(mockRepo, mockClient)
+			service := NewPokemonService(mockRepo, mockClient, new(MockSpeciesNameRepository))
 			result, err := service.GetPokemon(tt.pokemonID)
 
 			if tt.expectedError != "" {
@@ -390,7 +715,7 @@ func TestPokemonService_ListPokemon(t *testing.T) {
 			mockClient := new(MockPokemonAPIClient)
 			tt.setupMocks(mockRepo)
 
-			service := NewPokemonService(mockRepo, mockClient)
+			service := NewPokemonService(mockRepo, mockClient, new(MockSpeciesNameRepository))
 			result, err := service.ListPokemon()
 
 			if tt.expectedError != "" {
@@ -407,6 +732,337 @@ func TestPokemonService_ListPokemon(t *testing.T) {
 	}
 }
 
+func TestPokemonService_ListPokemonPage(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	page := &domain.PokemonPage{Pokemon: []*domain.Pokemon{{ID: 4, Name: "charizard"}}, Total: 1}
+	mockRepo.On("ListPage", domain.PokemonPageQuery{Types: []string{"fire", "flying"}, NameContains: "char", After: 3, Limit: 10}).Return(page, nil)
+	service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+
+	result, err := service.ListPokemonPage(domain.PokemonPageQuery{Types: []string{"Fire", "FLYING"}, NameContains: "Char", After: 3, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, result)
+	mockRepo.AssertExpectations(t)
+
+	_, err = service.ListPokemonPage(domain.PokemonPageQuery{Limit: -1})
+	assert.EqualError(t, err, "limit cannot be negative")
+}
+
+func TestPokemonService_UpdatePokemon(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *domain.UpdatePokemonRequest
+		setupMocks    func(*MockPokemonRepository)
+		expectedError string
+	}{
+		{
+			name: "successful update",
+			req:  &domain.UpdatePokemonRequest{Type1: "Electric", Type2: "ghost"},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByID", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "rotom", Type1: "electric"}, nil)
+				repo.On("Update", mock.MatchedBy(func(p *domain.Pokemon) bool {
+					return p.Type1 == "electric" && p.Type2 == "ghost"
+				}), (*time.Time)(nil), domain.Attribution{Actor: "ash", Source: domain.AuditSourceAPI}).Return(nil)
+			},
+		},
+		{
+			name: "unknown type",
+			req:  &domain.UpdatePokemonRequest{Type1: "sound"},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByID", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "rotom", Type1: "electric"}, nil)
+			},
+			expectedError: "type1 must be a known type",
+		},
+		{
+			name: "pokemon not found",
+			req:  &domain.UpdatePokemonRequest{Type1: "electric"},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByID", uint(1)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+		{
+			name: "repository error",
+			req:  &domain.UpdatePokemonRequest{Type1: "electric"},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByID", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "rotom", Type1: "electric"}, nil)
+				repo.On("Update", mock.AnythingOfType("*domain.Pokemon"), (*time.Time)(nil), mock.AnythingOfType("domain.Attribution")).Return(errors.New("database error"))
+			},
+			expectedError: "failed to save Pokemon: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+			result, err := service.UpdatePokemon(1, tt.req, nil, domain.Attribution{Actor: "ash"})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ghost", result.Type2)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonService_DeletePokemon(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*MockPokemonRepository)
+		expectedError string
+	}{
+		{
+			name: "successful delete",
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("Delete", uint(1), (*time.Time)(nil), domain.Attribution{Actor: "ash", RequestID: "req-1", Source: domain.AuditSourceAPI}).Return(nil)
+			},
+		},
+		{
+			name: "pokemon not found",
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("Delete", uint(1), (*time.Time)(nil), domain.Attribution{Actor: "ash", RequestID: "req-1", Source: domain.AuditSourceAPI}).Return(errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("Delete", uint(1), (*time.Time)(nil), domain.Attribution{Actor: "ash", RequestID: "req-1", Source: domain.AuditSourceAPI}).Return(errors.New("database error"))
+			},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+			err := service.DeletePokemon(1, nil, domain.Attribution{Actor: "ash", RequestID: "req-1"})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonService_PurgeDeletedPokemon(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockRepo.On("PurgeDeleted", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 30*24*time.Hour && time.Since(before) < 30*24*time.Hour+time.Minute
+	}), domain.Attribution{Source: domain.AuditSourceJob}).Return(2, nil)
+	service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+
+	purged, err := service.PurgeDeletedPokemon(30 * 24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	_, err = service.PurgeDeletedPokemon(0)
+	assert.EqualError(t, err, "trash retention must be positive")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPokemonService_GetPokemonHistory(t *testing.T) {
+	notFound := errors.New("pokemon not found")
+	entries := []*domain.PokemonAudit{{ID: 1, PokemonID: 1, Action: domain.AuditActionCreated}}
+
+	tests := []struct {
+		name          string
+		id            uint
+		setupMocks    func(*MockPokemonRepository)
+		expectedLen   int
+		expectedError string
+	}{
+		{
+			name: "recorded history",
+			id:   1,
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("History", uint(1)).Return(entries, nil)
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "stored before history was recorded",
+			id:   2,
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("History", uint(2)).Return([]*domain.PokemonAudit{}, nil)
+				repo.On("GetByID", uint(2)).Return(&domain.Pokemon{ID: 2}, nil)
+			},
+		},
+		{
+			name: "unknown pokemon",
+			id:   3,
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("History", uint(3)).Return([]*domain.PokemonAudit{}, nil)
+				repo.On("GetByID", uint(3)).Return((*domain.Pokemon)(nil), notFound)
+			},
+			expectedError: "pokemon not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			tt.setupMocks(mockRepo)
+
+			result, err := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository)).GetPokemonHistory(tt.id)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedLen)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonService_RestorePokemon(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockRepo.On("Restore", uint(1), domain.Attribution{Actor: "ash", Source: domain.AuditSourceImport}).Return(&domain.Pokemon{ID: 1}, nil)
+
+	// A caller that names a source keeps it
+	result, err := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository)).RestorePokemon(1, domain.Attribution{Actor: "ash", Source: domain.AuditSourceImport})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPokemonService_ExportPokemon(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockRepo.On("Each").Return([]*domain.Pokemon{{ID: 1, Name: "pikachu"}, {ID: 2, Name: "eevee"}}, nil)
+
+	service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+	var names []string
+	err := service.ExportPokemon(func(pokemon *domain.Pokemon) error {
+		names = append(names, pokemon.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pikachu", "eevee"}, names)
+}
+
+func TestPokemonService_ImportPokemon(t *testing.T) {
+	notFound := errors.New("pokemon not found")
+	row := func(n int, pokemon *domain.Pokemon) domain.PokemonImportRow {
+		return domain.PokemonImportRow{Row: n, Pokemon: pokemon}
+	}
+
+	tests := []struct {
+		name             string
+		rows             []domain.PokemonImportRow
+		dryRun           bool
+		setupMocks       func(*MockPokemonRepository)
+		expectedError    string
+		expectedValid    int
+		expectedImported int
+		expectedErrors   []domain.PokemonImportError
+	}{
+		{
+			name: "imports valid rows and reports the rest",
+			rows: []domain.PokemonImportRow{
+				row(1, &domain.Pokemon{ID: 99, Name: " Pikachu ", Type1: "Electric", HP: 35}),
+				{Row: 2, Error: `invalid hp: "lots"`},
+				row(3, &domain.Pokemon{Name: "missingno", Type1: "bird"}),
+				row(4, &domain.Pokemon{Name: "pikachu", Type1: "electric"}),
+				row(5, &domain.Pokemon{Name: "eevee", Type1: "normal"}),
+			},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByName", "pikachu").Return(nil, notFound)
+				repo.On("GetByName", "eevee").Return(&domain.Pokemon{ID: 2, Name: "eevee"}, nil)
+				repo.On("Create", mock.MatchedBy(func(p *domain.Pokemon) bool {
+					return p.ID == 0 && p.Name == "pikachu" && p.Type1 == "electric" && p.HP == 35 && p.CreatedBy == "ci"
+				}), domain.Attribution{Actor: "ci", RequestID: "req-1", Source: domain.AuditSourceImport}).Return(nil)
+			},
+			expectedValid:    1,
+			expectedImported: 1,
+			expectedErrors: []domain.PokemonImportError{
+				{Row: 2, Error: `invalid hp: "lots"`},
+				{Row: 3, Name: "missingno", Error: "type1 must be a known type"},
+				{Row: 4, Name: "pikachu", Error: "duplicate of row 1"},
+				{Row: 5, Name: "eevee", Error: "pokemon with this name already exists"},
+			},
+		},
+		{
+			name:   "dry run stores nothing",
+			rows:   []domain.PokemonImportRow{row(1, &domain.Pokemon{Name: "pikachu", Type1: "electric"})},
+			dryRun: true,
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByName", "pikachu").Return(nil, notFound)
+			},
+			expectedValid:  1,
+			expectedErrors: []domain.PokemonImportError{},
+		},
+		{
+			name: "failed save is reported on the row",
+			rows: []domain.PokemonImportRow{row(1, &domain.Pokemon{Name: "pikachu", Type1: "electric"})},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByName", "pikachu").Return(nil, notFound)
+				repo.On("Create", mock.AnythingOfType("*domain.Pokemon"), mock.AnythingOfType("domain.Attribution")).Return(errors.New("UNIQUE constraint failed"))
+			},
+			expectedErrors: []domain.PokemonImportError{
+				{Row: 1, Name: "pikachu", Error: "failed to save Pokemon: UNIQUE constraint failed"},
+			},
+		},
+		{
+			name: "repository error aborts the import",
+			rows: []domain.PokemonImportRow{row(1, &domain.Pokemon{Name: "pikachu", Type1: "electric"})},
+			setupMocks: func(repo *MockPokemonRepository) {
+				repo.On("GetByName", "pikachu").Return(nil, errors.New("database error"))
+			},
+			expectedError: "database error",
+		},
+		{
+			name:          "too many rows",
+			rows:          make([]domain.PokemonImportRow, domain.MaxImportRows+1),
+			setupMocks:    func(repo *MockPokemonRepository) {},
+			expectedError: "import cannot have more than 5000 rows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+			report, err := service.ImportPokemon(tt.rows, domain.Attribution{Actor: "ci", RequestID: "req-1"}, tt.dryRun)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, report)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.dryRun, report.DryRun)
+				assert.Equal(t, len(tt.rows), report.Total)
+				assert.Equal(t, tt.expectedValid, report.Valid)
+				assert.Equal(t, tt.expectedImported, report.Imported)
+				assert.Equal(t, len(tt.expectedErrors), report.Failed)
+				assert.Equal(t, tt.expectedErrors, report.Errors)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestPokemonService_ExtractPokemonName(t *testing.T) {
 	service := &pokemonService{}
 
@@ -454,3 +1110,190 @@ func TestPokemonService_ExtractPokemonName(t *testing.T) {
 		})
 	}
 }
+
+func TestPokemonService_SearchPokemon(t *testing.T) {
+	hits := []domain.PokemonSearchHit{
+		{Pokemon: &domain.Pokemon{ID: 6, Name: "charizard", Type1: "fire", Type2: "flying", Generation: 1}, Score: 1.4},
+		{Pokemon: &domain.Pokemon{ID: 4, Name: "charmander", Type1: "fire", Generation: 1}, Score: 0.8},
+		{Pokemon: &domain.Pokemon{ID: 155, Name: "cyndaquil", Type1: "fire", Generation: 2}, Score: 0.4},
+		{Pokemon: &domain.Pokemon{ID: 16, Name: "pidgey", Type1: "normal", Type2: "flying"}, Score: 0.1},
+	}
+
+	tests := []struct {
+		name                string
+		query               *domain.PokemonSearchQuery
+		expectedError       string
+		expectedIDs         []uint
+		expectedTotal       int
+		expectedLimit       int
+		expectedTypes       []domain.FacetCount
+		expectedGenerations []domain.FacetCount
+	}{
+		{
+			name:          "ranked hits with facets over all of them",
+			query:         &domain.PokemonSearchQuery{Text: " fire ", Limit: 2},
+			expectedIDs:   []uint{6, 4},
+			expectedTotal: 4,
+			expectedLimit: 2,
+			expectedTypes: []domain.FacetCount{
+				{Value: "fire", Count: 3}, {Value: "flying", Count: 2}, {Value: "normal", Count: 1},
+			},
+			expectedGenerations: []domain.FacetCount{{Value: "1", Count: 2}, {Value: "2", Count: 1}},
+		},
+		{
+			name:          "filtered by type and generation",
+			query:         &domain.PokemonSearchQuery{Text: "fire", Type: "fire", Generation: 1},
+			expectedIDs:   []uint{6, 4},
+			expectedTotal: 2,
+			expectedLimit: defaultSearchLimit,
+			expectedTypes: []domain.FacetCount{{Value: "fire", Count: 2}, {Value: "flying", Count: 1}},
+			// The generation facet is only narrowed by the type filter, so generation 2 can still be picked
+			expectedGenerations: []domain.FacetCount{{Value: "1", Count: 2}, {Value: "2", Count: 1}},
+		},
+		{
+			name:          "narrowed by type, the type facet still counts every type",
+			query:         &domain.PokemonSearchQuery{Text: "fire", Type: "flying"},
+			expectedIDs:   []uint{6, 16},
+			expectedTotal: 2,
+			expectedLimit: defaultSearchLimit,
+			expectedTypes: []domain.FacetCount{
+				{Value: "fire", Count: 3}, {Value: "flying", Count: 2}, {Value: "normal", Count: 1},
+			},
+			expectedGenerations: []domain.FacetCount{{Value: "1", Count: 1}},
+		},
+		{
+			name:                "narrowed by generation, the generation facet still counts every generation",
+			query:               &domain.PokemonSearchQuery{Text: "fire", Generation: 2},
+			expectedIDs:         []uint{155},
+			expectedTotal:       1,
+			expectedLimit:       defaultSearchLimit,
+			expectedTypes:       []domain.FacetCount{{Value: "fire", Count: 1}},
+			expectedGenerations: []domain.FacetCount{{Value: "1", Count: 2}, {Value: "2", Count: 1}},
+		},
+		{
+			name:                "offset past the end",
+			query:               &domain.PokemonSearchQuery{Text: "fire", Offset: 10, Limit: 500},
+			expectedIDs:         []uint{},
+			expectedTotal:       4,
+			expectedLimit:       maxSearchLimit,
+			expectedTypes:       []domain.FacetCount{{Value: "fire", Count: 3}, {Value: "flying", Count: 2}, {Value: "normal", Count: 1}},
+			expectedGenerations: []domain.FacetCount{{Value: "1", Count: 2}, {Value: "2", Count: 1}},
+		},
+		{
+			name:          "missing text",
+			query:         &domain.PokemonSearchQuery{Text: "  "},
+			expectedError: "search query is required",
+		},
+		{
+			name:          "unknown type filter",
+			query:         &domain.PokemonSearchQuery{Text: "fire", Type: "sound"},
+			expectedError: "type must be a known type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			if tt.expectedError == "" {
+				mockRepo.On("Search", "fire").Return(hits, nil)
+			}
+			service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+
+			result, err := service.SearchPokemon(tt.query)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			ids := []uint{}
+			for _, hit := range result.Hits {
+				ids = append(ids, hit.Pokemon.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedTotal, result.Total)
+			assert.Equal(t, tt.expectedLimit, result.Limit)
+			assert.Equal(t, tt.expectedTypes, result.Facets.Type)
+			assert.Equal(t, tt.expectedGenerations, result.Facets.Generation)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonService_GetPokemonByName(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockRepo.On("GetByName", "mr-mime").Return(&domain.Pokemon{ID: 3, DexNumber: 122, Name: "mr-mime"}, nil)
+	mockRepo.On("GetByName", "pikachu").Return(&domain.Pokemon{ID: 4, DexNumber: 25, Name: "pikachu"}, nil)
+	speciesNames := new(MockSpeciesNameRepository)
+	speciesNames.On("FindPokemon", "ピカチュウ").Return("pikachu", nil)
+	speciesNames.On("FindPokemon", "???").Return("", errors.New("species name not found"))
+	service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), speciesNames)
+
+	result, err := service.GetPokemonByName("Mr. Mime")
+	assert.NoError(t, err)
+	assert.Equal(t, 122, result.DexNumber)
+
+	result, err = service.GetPokemonByName("ピカチュウ")
+	assert.NoError(t, err)
+	assert.Equal(t, 25, result.DexNumber)
+
+	_, err = service.GetPokemonByName("???")
+	assert.EqualError(t, err, "pokemon not found")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPokemonService_GetPokemonByDexNumber(t *testing.T) {
+	tests := []struct {
+		name          string
+		number        int
+		setupMock     func(*MockPokemonRepository)
+		expectedError string
+	}{
+		{
+			name:   "stored",
+			number: 25,
+			setupMock: func(repo *MockPokemonRepository) {
+				repo.On("GetByDexNumber", 25).Return(&domain.Pokemon{ID: 1, DexNumber: 25, Name: "pikachu"}, nil)
+			},
+		},
+		{
+			name:   "not stored",
+			number: 150,
+			setupMock: func(repo *MockPokemonRepository) {
+				repo.On("GetByDexNumber", 150).Return(nil, errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+		{name: "zero", number: 0, setupMock: func(repo *MockPokemonRepository) {}, expectedError: "dex number must be between 1 and 1025"},
+		{name: "past the national dex", number: 10034, setupMock: func(repo *MockPokemonRepository) {}, expectedError: "dex number must be between 1 and 1025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			tt.setupMock(mockRepo)
+			service := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository))
+
+			result, err := service.GetPokemonByDexNumber(tt.number)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.number, result.DexNumber)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPokemonService_ForTenant(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockRepo.On("GetByID", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "bulbasaur"}, nil)
+
+	_, err := NewPokemonService(mockRepo, new(MockPokemonAPIClient), new(MockSpeciesNameRepository)).ForTenant("kanto").GetPokemon(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kanto", mockRepo.tenantID)
+	mockRepo.AssertExpectations(t)
+}
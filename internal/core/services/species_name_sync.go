@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strconv"
+)
+
+// speciesNameBatchSize bounds how many species one SyncNext call fetches, leaving room in the outbound rate limit
+const speciesNameBatchSize = 20
+
+type speciesNameSync struct {
+	repository ports.SpeciesNameRepository
+	apiClient  ports.PokemonAPIClient
+}
+
+func NewSpeciesNameSync(repository ports.SpeciesNameRepository, apiClient ports.PokemonAPIClient) ports.SpeciesNameSync {
+	return &speciesNameSync{
+		repository: repository,
+		apiClient:  apiClient,
+	}
+}
+
+// SyncNext fetches the missing species with the lowest dex numbers. Species the API does not have, as in a
+// snapshot of part of the dex, are skipped. It stops at the first failure; the species fetched before it are
+// kept, and the failed one is fetched again on the next run.
+func (s *speciesNameSync) SyncNext() (int, error) {
+	synced, err := s.repository.DexNumbers()
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for number := 1; number <= domain.NationalDexSize && added < speciesNameBatchSize; number++ {
+		if synced[number] {
+			continue
+		}
+
+		species, err := s.apiClient.GetSpeciesData(strconv.Itoa(number))
+		if err != nil {
+			var notFound *domain.ExternalNotFoundError
+			if errors.As(err, &notFound) {
+				continue
+			}
+			return added, fmt.Errorf("failed to fetch species %d: %w", number, err)
+		}
+		if err := s.repository.Save(number, species.SpeciesNames()); err != nil {
+			return added, err
+		}
+		added++
+	}
+
+	return added, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSpeciesNameRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpeciesNameRepository) Save(dexNumber int, names []*domain.SpeciesName) error {
+	args := m.Called(dexNumber, names)
+	return args.Error(0)
+}
+
+func (m *MockSpeciesNameRepository) FindPokemon(key string) (string, error) {
+	args := m.Called(key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSpeciesNameRepository) DexNumbers() (map[int]bool, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]bool), args.Error(1)
+}
+
+// namedSpecies mimics a PokeAPI species with a name in each of the given languages
+func namedSpecies(id int, pokemon string, names map[string]string) *domain.ExternalSpeciesResponse {
+	species := &domain.ExternalSpeciesResponse{ID: id, Name: pokemon}
+	for language, name := range names {
+		entry := struct {
+			Name     string `json:"name"`
+			Language struct {
+				Name string `json:"name"`
+			} `json:"language"`
+		}{Name: name}
+		entry.Language.Name = language
+		species.Names = append(species.Names, entry)
+	}
+	return species
+}
+
+func TestSpeciesNameSync_SyncNext(t *testing.T) {
+	t.Run("fetches the missing species in dex order", func(t *testing.T) {
+		mockRepo := new(MockSpeciesNameRepository)
+		mockClient := new(MockPokemonAPIClient)
+		mockRepo.On("DexNumbers").Return(map[int]bool{1: true, 3: true}, nil)
+		mockClient.On("GetSpeciesData", "2").Return(namedSpecies(2, "ivysaur", map[string]string{"ja-Hrkt": "フシギソウ"}), nil)
+		mockRepo.On("Save", 2, []*domain.SpeciesName{{
+			DexNumber: 2, Language: "ja-hrkt", Name: "フシギソウ", NormalizedName: "フシギソウ", Pokemon: "ivysaur",
+		}}).Return(nil)
+		mockClient.On("GetSpeciesData", mock.Anything).Return(namedSpecies(0, "", nil), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		added, err := NewSpeciesNameSync(mockRepo, mockClient).SyncNext()
+
+		assert.NoError(t, err)
+		assert.Equal(t, speciesNameBatchSize, added)
+		mockClient.AssertCalled(t, "GetSpeciesData", "2")
+		mockClient.AssertNotCalled(t, "GetSpeciesData", "3")
+		mockClient.AssertCalled(t, "GetSpeciesData", "22")
+		mockClient.AssertNotCalled(t, "GetSpeciesData", "23")
+	})
+
+	t.Run("nothing left to fetch", func(t *testing.T) {
+		synced := make(map[int]bool, domain.NationalDexSize)
+		for number := 1; number <= domain.NationalDexSize; number++ {
+			synced[number] = true
+		}
+		mockRepo := new(MockSpeciesNameRepository)
+		mockClient := new(MockPokemonAPIClient)
+		mockRepo.On("DexNumbers").Return(synced, nil)
+
+		added, err := NewSpeciesNameSync(mockRepo, mockClient).SyncNext()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, added)
+		mockClient.AssertNotCalled(t, "GetSpeciesData", mock.Anything)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		mockRepo := new(MockSpeciesNameRepository)
+		mockClient := new(MockPokemonAPIClient)
+		mockRepo.On("DexNumbers").Return(map[int]bool{}, nil)
+		mockClient.On("GetSpeciesData", "1").Return(namedSpecies(1, "bulbasaur", map[string]string{"en": "Bulbasaur"}), nil)
+		mockRepo.On("Save", 1, mock.Anything).Return(nil)
+		mockClient.On("GetSpeciesData", "2").Return(nil, errors.New("PokeAPI returned status 503"))
+
+		added, err := NewSpeciesNameSync(mockRepo, mockClient).SyncNext()
+
+		assert.EqualError(t, err, "failed to fetch species 2: PokeAPI returned status 503")
+		assert.Equal(t, 1, added)
+		mockClient.AssertNotCalled(t, "GetSpeciesData", "3")
+	})
+
+	t.Run("skips species the API does not have", func(t *testing.T) {
+		synced := make(map[int]bool, domain.NationalDexSize)
+		for number := 1; number <= domain.NationalDexSize; number++ {
+			synced[number] = number != 151 && number != 152
+		}
+		mockRepo := new(MockSpeciesNameRepository)
+		mockClient := new(MockPokemonAPIClient)
+		mockRepo.On("DexNumbers").Return(synced, nil)
+		mockClient.On("GetSpeciesData", "151").Return(nil, &domain.ExternalNotFoundError{Resource: "pokemon-species", Identifier: "151"})
+		mockClient.On("GetSpeciesData", "152").Return(namedSpecies(152, "chikorita", map[string]string{"en": "Chikorita"}), nil)
+		mockRepo.On("Save", 152, mock.Anything).Return(nil)
+
+		added, err := NewSpeciesNameSync(mockRepo, mockClient).SyncNext()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, added)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"sync"
+)
+
+type spriteService struct {
+	pokemonRepository ports.PokemonRepository
+	apiClient         ports.PokemonAPIClient
+	images            ports.ImageFetcher
+	store             ports.BlobStore
+
+	// downloads is shared with the service's tenant-scoped copies, since sprites are cached for every tenant
+	downloads *spriteDownloads
+}
+
+// spriteDownloads serializes downloads of the same sprite, so concurrent requests fetch it once
+type spriteDownloads struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (d *spriteDownloads) lock(key string) func() {
+	d.mu.Lock()
+	lock, ok := d.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		d.locks[key] = lock
+	}
+	d.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func NewSpriteService(pokemonRepository ports.PokemonRepository, apiClient ports.PokemonAPIClient, images ports.ImageFetcher, store ports.BlobStore) ports.SpriteService {
+	return &spriteService{
+		pokemonRepository: pokemonRepository,
+		apiClient:         apiClient,
+		images:            images,
+		store:             store,
+		downloads:         &spriteDownloads{locks: make(map[string]*sync.Mutex)},
+	}
+}
+
+func (s *spriteService) ForTenant(tenantID string) ports.SpriteService {
+	return &spriteService{
+		pokemonRepository: s.pokemonRepository.ForTenant(tenantID),
+		apiClient:         s.apiClient,
+		images:            s.images,
+		store:             s.store,
+		downloads:         s.downloads,
+	}
+}
+
+// GetSprite returns a Pokemon's image, downloading it on first use and serving it from the store after that.
+// Sprites are cached by Pokemon name, since every tenant's Pokemon of the same name have the same images.
+func (s *spriteService) GetSprite(pokemonID uint, variant string) (*domain.Blob, error) {
+	if variant == "" {
+		variant = domain.SpriteDefault
+	}
+	if _, ok := (domain.PokemonSprites{}).URL(variant); !ok {
+		return nil, errors.New("variant must be default, shiny or artwork")
+	}
+
+	pokemon, err := s.pokemonRepository.GetByID(pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := "sprites/" + pokemon.Name + "/" + variant
+	if blob, err := s.cached(key); blob != nil || err != nil {
+		return blob, err
+	}
+
+	unlock := s.downloads.lock(key)
+	defer unlock()
+
+	// Another request may have downloaded the sprite while this one waited
+	if blob, err := s.cached(key); blob != nil || err != nil {
+		return blob, err
+	}
+
+	imageURL, err := s.spriteURL(pokemon, variant)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := s.images.FetchImage(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sprite: %w", err)
+	}
+	sum := sha256.Sum256(blob.Data)
+	blob.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	// The sprite can still be served when caching it fails; it is downloaded again next time
+	if err := s.store.Put(key, blob); err != nil {
+		log.Printf("failed to cache sprite %s: %v", key, err)
+	}
+
+	return blob, nil
+}
+
+// cached returns the stored sprite, or nil without an error if it is not stored yet
+func (s *spriteService) cached(key string) (*domain.Blob, error) {
+	blob, err := s.store.Get(key)
+	if err != nil {
+		if err.Error() == "blob not found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return blob, nil
+}
+
+// spriteURL returns the stored image URL; Pokemon stored before sprites were kept, or imported from files, have
+// none, so their URL is looked up on PokeAPI
+func (s *spriteService) spriteURL(pokemon *domain.Pokemon, variant string) (string, error) {
+	imageURL, _ := pokemon.Sprites.URL(variant)
+	if imageURL == "" && pokemon.Sprites == (domain.PokemonSprites{}) {
+		externalData, err := s.apiClient.GetPokemonData(pokemon.Name)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch sprite: %w", err)
+		}
+		imageURL, _ = externalData.SpriteURLs().URL(variant)
+	}
+	if imageURL == "" {
+		return "", errors.New("pokemon has no sprite of this variant")
+	}
+	return imageURL, nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+func (m *MockBlobStore) Get(key string) (*domain.Blob, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blob), args.Error(1)
+}
+
+func (m *MockBlobStore) Put(key string, blob *domain.Blob) error {
+	args := m.Called(key, blob)
+	return args.Error(0)
+}
+
+type MockImageFetcher struct {
+	mock.Mock
+}
+
+func (m *MockImageFetcher) FetchImage(url string) (*domain.Blob, error) {
+	args := m.Called(url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	// Each call gets its own blob, as a real download would
+	blob := *args.Get(0).(*domain.Blob)
+	return &blob, args.Error(1)
+}
+
+// spritedPikachu has PokeAPI's sprite URLs but no official artwork
+var spritedPikachu = &domain.Pokemon{
+	ID:   1,
+	Name: "pikachu",
+	Sprites: domain.PokemonSprites{
+		Default: "https://sprites.example/25.png",
+		Shiny:   "https://sprites.example/shiny/25.png",
+	},
+}
+
+func TestSpriteService_GetSprite(t *testing.T) {
+	png := &domain.Blob{ContentType: "image/png", Data: []byte("\x89PNG")}
+	cached := &domain.Blob{ContentType: "image/png", ETag: `"cached"`, Data: []byte("\x89PNG")}
+
+	tests := []struct {
+		name          string
+		variant       string
+		setupMocks    func(*MockPokemonRepository, *MockPokemonAPIClient, *MockImageFetcher, *MockBlobStore)
+		expectedETag  string
+		expectedError string
+	}{
+		{
+			name:    "served from the cache",
+			variant: "shiny",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return(spritedPikachu, nil)
+				store.On("Get", "sprites/pikachu/shiny").Return(cached, nil)
+			},
+			expectedETag: `"cached"`,
+		},
+		{
+			name: "downloaded and cached on first use",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return(spritedPikachu, nil)
+				store.On("Get", "sprites/pikachu/default").Return(nil, errors.New("blob not found"))
+				images.On("FetchImage", "https://sprites.example/25.png").Return(png, nil)
+				store.On("Put", "sprites/pikachu/default", mock.MatchedBy(func(blob *domain.Blob) bool {
+					return blob.ETag != "" && blob.ContentType == "image/png"
+				})).Return(nil)
+			},
+			expectedETag: `"0f4636c78f65d3639ece5a064b5ae753"`,
+		},
+		{
+			name: "served even when caching fails",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return(spritedPikachu, nil)
+				store.On("Get", "sprites/pikachu/default").Return(nil, errors.New("blob not found"))
+				images.On("FetchImage", "https://sprites.example/25.png").Return(png, nil)
+				store.On("Put", "sprites/pikachu/default", mock.AnythingOfType("*domain.Blob")).Return(errors.New("disk full"))
+			},
+			expectedETag: `"0f4636c78f65d3639ece5a064b5ae753"`,
+		},
+		{
+			name:    "Pokemon stored without sprite URLs looks them up on PokeAPI",
+			variant: "artwork",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return(&domain.Pokemon{ID: 1, Name: "pikachu"}, nil)
+				store.On("Get", "sprites/pikachu/artwork").Return(nil, errors.New("blob not found"))
+				external := &domain.ExternalPokemonResponse{}
+				external.Sprites.Other.OfficialArtwork.FrontDefault = "https://sprites.example/artwork/25.png"
+				client.On("GetPokemonData", "pikachu").Return(external, nil)
+				images.On("FetchImage", "https://sprites.example/artwork/25.png").Return(png, nil)
+				store.On("Put", "sprites/pikachu/artwork", mock.AnythingOfType("*domain.Blob")).Return(nil)
+			},
+			expectedETag: `"0f4636c78f65d3639ece5a064b5ae753"`,
+		},
+		{
+			name:    "variant PokeAPI has no image for",
+			variant: "artwork",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return(spritedPikachu, nil)
+				store.On("Get", "sprites/pikachu/artwork").Return(nil, errors.New("blob not found"))
+			},
+			expectedError: "pokemon has no sprite of this variant",
+		},
+		{
+			name:    "unknown variant",
+			variant: "back",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+			},
+			expectedError: "variant must be default, shiny or artwork",
+		},
+		{
+			name: "unknown Pokemon",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "pokemon not found",
+		},
+		{
+			name: "download failure",
+			setupMocks: func(repo *MockPokemonRepository, client *MockPokemonAPIClient, images *MockImageFetcher, store *MockBlobStore) {
+				repo.On("GetByID", uint(1)).Return(spritedPikachu, nil)
+				store.On("Get", "sprites/pikachu/default").Return(nil, errors.New("blob not found"))
+				images.On("FetchImage", "https://sprites.example/25.png").Return(nil, errors.New("image host returned status 503"))
+			},
+			expectedError: "failed to fetch sprite: image host returned status 503",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPokemonRepository)
+			mockClient := new(MockPokemonAPIClient)
+			mockImages := new(MockImageFetcher)
+			mockStore := new(MockBlobStore)
+			tt.setupMocks(mockRepo, mockClient, mockImages, mockStore)
+
+			service := NewSpriteService(mockRepo, mockClient, mockImages, mockStore)
+			blob, err := service.GetSprite(1, tt.variant)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, blob)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedETag, blob.ETag)
+				assert.Equal(t, "image/png", blob.ContentType)
+			}
+			mockRepo.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+			mockImages.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+// memoryBlobStore is a BlobStore for checking that concurrent requests download a sprite once
+type memoryBlobStore struct {
+	mu    sync.Mutex
+	blobs map[string]*domain.Blob
+}
+
+func (s *memoryBlobStore) Get(key string) (*domain.Blob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if blob, ok := s.blobs[key]; ok {
+		return blob, nil
+	}
+	return nil, errors.New("blob not found")
+}
+
+func (s *memoryBlobStore) Put(key string, blob *domain.Blob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = blob
+	return nil
+}
+
+func TestSpriteService_GetSprite_DownloadsOnce(t *testing.T) {
+	mockRepo := new(MockPokemonRepository)
+	mockRepo.On("GetByID", uint(1)).Return(spritedPikachu, nil)
+	mockImages := new(MockImageFetcher)
+	mockImages.On("FetchImage", "https://sprites.example/25.png").Return(&domain.Blob{ContentType: "image/png", Data: []byte("\x89PNG")}, nil).Once()
+	service := NewSpriteService(mockRepo, new(MockPokemonAPIClient), mockImages, &memoryBlobStore{blobs: map[string]*domain.Blob{}})
+	// Tenant-scoped copies share the downloads and the cache
+	services := []ports.SpriteService{service, service.ForTenant("kanto")}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(service ports.SpriteService) {
+			defer wg.Done()
+			_, err := service.GetSprite(1, domain.SpriteDefault)
+			assert.NoError(t, err)
+		}(services[i%2])
+	}
+	wg.Wait()
+
+	mockImages.AssertExpectations(t)
+}
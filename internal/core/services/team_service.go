@@ -0,0 +1,277 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+type teamService struct {
+	teamRepository    ports.TeamRepository
+	pokemonRepository ports.PokemonRepository
+	apiClient         ports.PokemonAPIClient
+}
+
+func NewTeamService(teamRepository ports.TeamRepository, pokemonRepository ports.PokemonRepository, apiClient ports.PokemonAPIClient) ports.TeamService {
+	return &teamService{
+		teamRepository:    teamRepository,
+		pokemonRepository: pokemonRepository,
+		apiClient:         apiClient,
+	}
+}
+
+func (s *teamService) ForTenant(tenantID string) ports.TeamService {
+	return &teamService{
+		teamRepository:    s.teamRepository.ForTenant(tenantID),
+		pokemonRepository: s.pokemonRepository.ForTenant(tenantID),
+		apiClient:         s.apiClient,
+	}
+}
+
+func (s *teamService) CreateTeam(req *domain.TeamRequest) (*domain.Team, error) {
+	members, err := s.buildMembers(req.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &domain.Team{
+		Name:    strings.TrimSpace(req.Name),
+		Owner:   strings.TrimSpace(req.Owner),
+		Members: members,
+	}
+
+	if err := s.teamRepository.Create(team); err != nil {
+		return nil, fmt.Errorf("failed to save team: %w", err)
+	}
+
+	return team, nil
+}
+
+func (s *teamService) GetTeam(id uint) (*domain.Team, error) {
+	return s.teamRepository.GetByID(id)
+}
+
+func (s *teamService) ListTeams() ([]*domain.Team, error) {
+	return s.teamRepository.List()
+}
+
+func (s *teamService) UpdateTeam(id uint, req *domain.TeamRequest, expected *time.Time) (*domain.Team, error) {
+	team, err := s.teamRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.buildMembers(req.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Name = strings.TrimSpace(req.Name)
+	team.Owner = strings.TrimSpace(req.Owner)
+	team.Members = members
+
+	if err := s.teamRepository.Update(team, expected); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *teamService) DeleteTeam(id uint, expected *time.Time) error {
+	return s.teamRepository.Delete(id, expected)
+}
+
+func (s *teamService) AnalyzeTeam(id uint) (*domain.TeamAnalysis, error) {
+	team, err := s.teamRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var roster []rosterMember
+	warnings := []string{}
+	moves := make(map[string]domain.Move)
+	for _, member := range team.Members {
+		// A member whose Pokemon was deleted is kept on the team, but there is nothing to analyze
+		if member.Pokemon == nil {
+			warnings = append(warnings, fmt.Sprintf("slot %d: pokemon %d is in the trash and was left out of the analysis", member.Slot, member.PokemonID))
+			continue
+		}
+		attackTypes, err := s.attackTypes(member, moves)
+		if err != nil {
+			return nil, err
+		}
+		roster = append(roster, rosterMember{pokemon: member.Pokemon, attackTypes: attackTypes})
+	}
+
+	analysis := analyzeRoster(roster)
+	analysis.TeamID = team.ID
+	analysis.Warnings = warnings
+	return analysis, nil
+}
+
+// rosterMember is a team member's Pokemon with the types it attacks with
+type rosterMember struct {
+	pokemon     *domain.Pokemon
+	attackTypes []string
+}
+
+// attackTypes returns the types of the member's damaging moves, fetching them from PokeAPI into cache. A member
+// with no damaging moves is assumed to attack with its own (STAB) types.
+func (s *teamService) attackTypes(member domain.TeamMember, cache map[string]domain.Move) ([]string, error) {
+	types := []string{}
+	for _, name := range member.Moves {
+		move, cached := cache[name]
+		if !cached {
+			fetched, err := fetchMove(s.apiClient, name)
+			if err != nil {
+				return nil, err
+			}
+			move = fetched
+			cache[name] = move
+		}
+		if move.Category != domain.CategoryStatus && !slices.Contains(types, move.Type) {
+			types = append(types, move.Type)
+		}
+	}
+	if len(types) == 0 {
+		return member.Pokemon.Types(), nil
+	}
+	return types, nil
+}
+
+// buildMembers validates the requested members and checks that every referenced Pokemon is stored
+func (s *teamService) buildMembers(requested []domain.TeamMemberRequest) ([]domain.TeamMember, error) {
+	if len(requested) > domain.MaxTeamSize {
+		return nil, errors.New("team cannot have more than 6 members")
+	}
+
+	members := make([]domain.TeamMember, 0, len(requested))
+	for i, req := range requested {
+		if len(req.Moves) > domain.MaxMovesPerMember {
+			return nil, errors.New("team member cannot know more than 4 moves")
+		}
+
+		pokemon, err := s.pokemonRepository.GetByID(req.PokemonID)
+		if err != nil || pokemon == nil {
+			return nil, errors.New("team member pokemon not found")
+		}
+
+		moves := make([]string, 0, len(req.Moves))
+		for _, move := range req.Moves {
+			moves = append(moves, strings.ToLower(strings.TrimSpace(move)))
+		}
+
+		members = append(members, domain.TeamMember{
+			Slot:      i + 1,
+			PokemonID: pokemon.ID,
+			Pokemon:   pokemon,
+			Moves:     moves,
+		})
+	}
+
+	return members, nil
+}
+
+func analyzeRoster(members []rosterMember) *domain.TeamAnalysis {
+	roster := make([]*domain.Pokemon, 0, len(members))
+	for _, member := range members {
+		roster = append(roster, member.pokemon)
+	}
+	analysis := &domain.TeamAnalysis{
+		Members:          make([]domain.TeamMemberSummary, 0, len(roster)),
+		SharedWeaknesses: []string{},
+		CoverageGaps:     []string{},
+		Warnings:         []string{},
+	}
+
+	for _, member := range members {
+		pokemon := member.pokemon
+		analysis.Members = append(analysis.Members, domain.TeamMemberSummary{
+			PokemonID:     pokemon.ID,
+			Name:          pokemon.Name,
+			Types:         pokemon.Types(),
+			AttackTypes:   member.attackTypes,
+			Speed:         pokemon.Speed,
+			BaseStatTotal: pokemon.BaseStatTotal(),
+		})
+
+		analysis.StatTotals.HP += pokemon.HP
+		analysis.StatTotals.Attack += pokemon.Attack
+		analysis.StatTotals.Defense += pokemon.Defense
+		analysis.StatTotals.SpAttack += pokemon.SpAttack
+		analysis.StatTotals.SpDefense += pokemon.SpDefense
+		analysis.StatTotals.Speed += pokemon.Speed
+		analysis.StatTotals.Total += pokemon.BaseStatTotal()
+	}
+	if len(roster) > 0 {
+		analysis.StatTotals.AverageBaseStatTotal = float64(analysis.StatTotals.Total) / float64(len(roster))
+	}
+
+	for _, attackType := range domain.PokemonTypes {
+		matchup := domain.TypeMatchup{Type: attackType}
+		for _, pokemon := range roster {
+			switch multiplier := domain.TypeEffectiveness(attackType, pokemon.Types()...); {
+			case multiplier == 0:
+				matchup.Immune++
+			case multiplier > 1:
+				matchup.Weak++
+			case multiplier < 1:
+				matchup.Resist++
+			}
+		}
+		analysis.Matchups = append(analysis.Matchups, matchup)
+
+		// A weakness is shared when two or more members are hit super effectively and the rest of the team can't cover it
+		if matchup.Weak >= 2 && matchup.Weak > matchup.Resist+matchup.Immune {
+			analysis.SharedWeaknesses = append(analysis.SharedWeaknesses, attackType)
+		}
+	}
+
+	// Offensive coverage is measured from the types the members attack with
+	for _, defendType := range domain.PokemonTypes {
+		covered := false
+		for _, member := range members {
+			for _, attackType := range member.attackTypes {
+				if domain.TypeEffectiveness(attackType, defendType) > 1 {
+					covered = true
+				}
+			}
+		}
+		if !covered {
+			analysis.CoverageGaps = append(analysis.CoverageGaps, defendType)
+		}
+	}
+
+	analysis.SpeedTiers = speedTiers(roster)
+	return analysis
+}
+
+// speedTiers groups members into fast (100+), medium (70-99) and slow (<70) base speed tiers, fastest first
+func speedTiers(roster []*domain.Pokemon) []domain.SpeedTier {
+	sorted := make([]*domain.Pokemon, len(roster))
+	copy(sorted, roster)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Speed > sorted[j].Speed
+	})
+
+	tiers := []domain.SpeedTier{}
+	for _, pokemon := range sorted {
+		tier := "slow"
+		if pokemon.Speed >= 100 {
+			tier = "fast"
+		} else if pokemon.Speed >= 70 {
+			tier = "medium"
+		}
+
+		if len(tiers) == 0 || tiers[len(tiers)-1].Tier != tier {
+			tiers = append(tiers, domain.SpeedTier{Tier: tier})
+		}
+		tiers[len(tiers)-1].Members = append(tiers[len(tiers)-1].Members, pokemon.Name)
+	}
+	return tiers
+}
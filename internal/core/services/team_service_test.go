@@ -0,0 +1,332 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTeamRepository struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockTeamRepository) ForTenant(tenantID string) ports.TeamRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockTeamRepository) Create(team *domain.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetByID(id uint) (*domain.Team, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) List() ([]*domain.Team, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Update(team *domain.Team, expected *time.Time) error {
+	args := m.Called(team, expected)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Delete(id uint, expected *time.Time) error {
+	args := m.Called(id, expected)
+	return args.Error(0)
+}
+
+var (
+	charizard = &domain.Pokemon{ID: 1, Name: "charizard", Type1: "fire", Type2: "flying", HP: 78, Attack: 84, Defense: 78, SpAttack: 109, SpDefense: 85, Speed: 100}
+	blastoise = &domain.Pokemon{ID: 2, Name: "blastoise", Type1: "water", HP: 79, Attack: 83, Defense: 100, SpAttack: 85, SpDefense: 105, Speed: 78}
+	venusaur  = &domain.Pokemon{ID: 3, Name: "venusaur", Type1: "grass", Type2: "poison", HP: 80, Attack: 82, Defense: 83, SpAttack: 100, SpDefense: 100, Speed: 80}
+	gyarados  = &domain.Pokemon{ID: 4, Name: "gyarados", Type1: "water", Type2: "flying", HP: 95, Attack: 125, Defense: 79, SpAttack: 60, SpDefense: 100, Speed: 81}
+	snorlax   = &domain.Pokemon{ID: 5, Name: "snorlax", Type1: "normal", HP: 160, Attack: 110, Defense: 65, SpAttack: 65, SpDefense: 110, Speed: 30}
+)
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *domain.TeamRequest
+		setupMocks    func(*MockTeamRepository, *MockPokemonRepository)
+		expectedError string
+	}{
+		{
+			name: "successful creation",
+			request: &domain.TeamRequest{
+				Name:  " Kanto Starters ",
+				Owner: "red",
+				Members: []domain.TeamMemberRequest{
+					{PokemonID: 1, Moves: []string{"Flamethrower", "Air Slash"}},
+					{PokemonID: 2, Moves: []string{"surf"}},
+				},
+			},
+			setupMocks: func(teams *MockTeamRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				pokemon.On("GetByID", uint(2)).Return(blastoise, nil)
+				teams.On("Create", mock.AnythingOfType("*domain.Team")).Return(nil)
+			},
+		},
+		{
+			name: "too many members",
+			request: &domain.TeamRequest{
+				Name:    "overfull",
+				Members: make([]domain.TeamMemberRequest, 7),
+			},
+			setupMocks:    func(teams *MockTeamRepository, pokemon *MockPokemonRepository) {},
+			expectedError: "team cannot have more than 6 members",
+		},
+		{
+			name: "too many moves",
+			request: &domain.TeamRequest{
+				Name: "greedy",
+				Members: []domain.TeamMemberRequest{
+					{PokemonID: 1, Moves: []string{"a", "b", "c", "d", "e"}},
+				},
+			},
+			setupMocks:    func(teams *MockTeamRepository, pokemon *MockPokemonRepository) {},
+			expectedError: "team member cannot know more than 4 moves",
+		},
+		{
+			name: "unknown pokemon",
+			request: &domain.TeamRequest{
+				Name:    "ghosts",
+				Members: []domain.TeamMemberRequest{{PokemonID: 99}},
+			},
+			setupMocks: func(teams *MockTeamRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(99)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "team member pokemon not found",
+		},
+		{
+			name: "repository save error",
+			request: &domain.TeamRequest{
+				Name:    "solo",
+				Members: []domain.TeamMemberRequest{{PokemonID: 1}},
+			},
+			setupMocks: func(teams *MockTeamRepository, pokemon *MockPokemonRepository) {
+				pokemon.On("GetByID", uint(1)).Return(charizard, nil)
+				teams.On("Create", mock.AnythingOfType("*domain.Team")).Return(errors.New("database error"))
+			},
+			expectedError: "failed to save team: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTeams := new(MockTeamRepository)
+			mockPokemon := new(MockPokemonRepository)
+			tt.setupMocks(mockTeams, mockPokemon)
+
+			service := NewTeamService(mockTeams, mockPokemon, new(MockPokemonAPIClient))
+			result, err := service.CreateTeam(tt.request)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Kanto Starters", result.Name)
+				assert.Len(t, result.Members, 2)
+				assert.Equal(t, 1, result.Members[0].Slot)
+				assert.Equal(t, []string{"flamethrower", "air slash"}, result.Members[0].Moves)
+				assert.Equal(t, uint(2), result.Members[1].PokemonID)
+			}
+
+			mockTeams.AssertExpectations(t)
+			mockPokemon.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_UpdateTeam(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockTeams.On("GetByID", uint(1)).Return(&domain.Team{ID: 1, Name: "old"}, nil)
+	mockPokemon.On("GetByID", uint(5)).Return(snorlax, nil)
+	mockTeams.On("Update", mock.AnythingOfType("*domain.Team"), (*time.Time)(nil)).Return(nil)
+
+	service := NewTeamService(mockTeams, mockPokemon, new(MockPokemonAPIClient))
+	result, err := service.UpdateTeam(1, &domain.TeamRequest{
+		Name:    "new",
+		Members: []domain.TeamMemberRequest{{PokemonID: 5}},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new", result.Name)
+	assert.Len(t, result.Members, 1)
+	mockTeams.AssertExpectations(t)
+	mockPokemon.AssertExpectations(t)
+}
+
+func TestTeamService_UpdateTeam_NotFound(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockTeams.On("GetByID", uint(9)).Return(nil, errors.New("team not found"))
+
+	service := NewTeamService(mockTeams, new(MockPokemonRepository), new(MockPokemonAPIClient))
+	result, err := service.UpdateTeam(9, &domain.TeamRequest{Name: "x"}, nil)
+
+	assert.EqualError(t, err, "team not found")
+	assert.Nil(t, result)
+}
+
+func TestTeamService_AnalyzeTeam(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockTeams.On("GetByID", uint(1)).Return(&domain.Team{
+		ID: 1,
+		Members: []domain.TeamMember{
+			{PokemonID: 1, Pokemon: charizard},
+			{PokemonID: 4, Pokemon: gyarados},
+			{PokemonID: 5, Pokemon: snorlax},
+		},
+	}, nil)
+
+	service := NewTeamService(mockTeams, new(MockPokemonRepository), new(MockPokemonAPIClient))
+	analysis, err := service.AnalyzeTeam(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), analysis.TeamID)
+	assert.Len(t, analysis.Members, 3)
+
+	// Charizard and Gyarados are both 4x weak to rock, Snorlax is neutral
+	assert.Contains(t, analysis.SharedWeaknesses, "rock")
+	assert.Contains(t, analysis.SharedWeaknesses, "electric")
+	assert.NotContains(t, analysis.SharedWeaknesses, "fire")
+
+	for _, matchup := range analysis.Matchups {
+		if matchup.Type == "ground" {
+			assert.Equal(t, 2, matchup.Immune)
+		}
+		if matchup.Type == "ghost" {
+			assert.Equal(t, 1, matchup.Immune)
+		}
+	}
+
+	// Without moves, members attack with their own types: fire, flying, water and normal cannot hit these types
+	// super effectively
+	assert.Contains(t, analysis.CoverageGaps, "electric")
+	assert.Contains(t, analysis.CoverageGaps, "dragon")
+	assert.NotContains(t, analysis.CoverageGaps, "grass")
+	assert.NotContains(t, analysis.CoverageGaps, "rock")
+
+	assert.Equal(t, []domain.SpeedTier{
+		{Tier: "fast", Members: []string{"charizard"}},
+		{Tier: "medium", Members: []string{"gyarados"}},
+		{Tier: "slow", Members: []string{"snorlax"}},
+	}, analysis.SpeedTiers)
+
+	assert.Equal(t, 78+95+160, analysis.StatTotals.HP)
+	assert.Equal(t, 534+540+540, analysis.StatTotals.Total)
+	assert.InDelta(t, 538.0, analysis.StatTotals.AverageBaseStatTotal, 0.001)
+	assert.Equal(t, []string{"fire", "flying"}, analysis.Members[0].AttackTypes)
+	assert.Empty(t, analysis.Warnings)
+}
+
+func TestTeamService_AnalyzeTeam_Empty(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockTeams.On("GetByID", uint(2)).Return(&domain.Team{ID: 2}, nil)
+
+	service := NewTeamService(mockTeams, new(MockPokemonRepository), new(MockPokemonAPIClient))
+	analysis, err := service.AnalyzeTeam(2)
+
+	assert.NoError(t, err)
+	assert.Empty(t, analysis.Members)
+	assert.Empty(t, analysis.SharedWeaknesses)
+	assert.Len(t, analysis.CoverageGaps, len(domain.PokemonTypes))
+	assert.Zero(t, analysis.StatTotals.AverageBaseStatTotal)
+}
+
+func TestTeamService_DeleteTeam(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockTeams.On("Delete", uint(3), (*time.Time)(nil)).Return(nil)
+
+	service := NewTeamService(mockTeams, new(MockPokemonRepository), new(MockPokemonAPIClient))
+	assert.NoError(t, service.DeleteTeam(3, nil))
+	mockTeams.AssertExpectations(t)
+}
+
+func TestTeamService_AnalyzeTeam_Moves(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockClient := new(MockPokemonAPIClient)
+	mockTeams.On("GetByID", uint(1)).Return(&domain.Team{
+		ID: 1,
+		Members: []domain.TeamMember{
+			{Slot: 1, PokemonID: 4, Pokemon: gyarados, Moves: []string{"earthquake", "dragon-dance"}},
+			{Slot: 2, PokemonID: 5, Pokemon: snorlax, Moves: []string{"earthquake"}},
+			{Slot: 3, PokemonID: 1, Moves: []string{"flamethrower"}},
+		},
+	}, nil)
+	// Each move is fetched once, however many members know it
+	mockClient.On("GetMoveData", "earthquake").Return(moveData("earthquake", "ground", domain.CategoryPhysical, 100, 100), nil).Once()
+	mockClient.On("GetMoveData", "dragon-dance").Return(moveData("dragon-dance", "dragon", domain.CategoryStatus, 0, 0), nil).Once()
+
+	service := NewTeamService(mockTeams, new(MockPokemonRepository), mockClient)
+	analysis, err := service.AnalyzeTeam(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, analysis.Members, 2)
+	// Status moves do not attack, and the moves replace the members' own types
+	assert.Equal(t, []string{"ground"}, analysis.Members[0].AttackTypes)
+	assert.Equal(t, []string{"water", "flying"}, analysis.Members[0].Types)
+	assert.NotContains(t, analysis.CoverageGaps, "electric")
+	assert.NotContains(t, analysis.CoverageGaps, "fire")
+	assert.Contains(t, analysis.CoverageGaps, "ground")
+	assert.Equal(t, []string{"slot 3: pokemon 1 is in the trash and was left out of the analysis"}, analysis.Warnings)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTeamService_AnalyzeTeam_UnknownMove(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockClient := new(MockPokemonAPIClient)
+	mockTeams.On("GetByID", uint(1)).Return(&domain.Team{
+		ID:      1,
+		Members: []domain.TeamMember{{Slot: 1, PokemonID: 5, Pokemon: snorlax, Moves: []string{"hyper-punch"}}},
+	}, nil)
+	mockClient.On("GetMoveData", "hyper-punch").Return(nil, &domain.ExternalNotFoundError{Resource: "move", Identifier: "hyper-punch"})
+
+	service := NewTeamService(mockTeams, new(MockPokemonRepository), mockClient)
+	_, err := service.AnalyzeTeam(1)
+
+	var notFound *domain.ExternalNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestAnalyzeRoster_CoversWithDualTypes(t *testing.T) {
+	analysis := analyzeRoster([]rosterMember{
+		{pokemon: venusaur, attackTypes: venusaur.Types()},
+		{pokemon: blastoise, attackTypes: blastoise.Types()},
+	})
+
+	assert.NotContains(t, analysis.CoverageGaps, "fire")
+	assert.NotContains(t, analysis.CoverageGaps, "fairy")
+	assert.Contains(t, analysis.CoverageGaps, "steel")
+}
+
+func TestTeamService_ForTenant(t *testing.T) {
+	mockTeams := new(MockTeamRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockTeams.On("List").Return([]*domain.Team{}, nil)
+
+	_, err := NewTeamService(mockTeams, mockPokemon, new(MockPokemonAPIClient)).ForTenant("kanto").ListTeams()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kanto", mockTeams.tenantID)
+	assert.Equal(t, "kanto", mockPokemon.tenantID)
+	mockTeams.AssertExpectations(t)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+	"sync"
+)
+
+type tenantService struct {
+	repository ports.TenantRepository
+
+	// known caches tenants already looked up; tenants are never deleted, so an entry never goes stale
+	mu    sync.Mutex
+	known map[string]*domain.Tenant
+}
+
+func NewTenantService(repository ports.TenantRepository) ports.TenantService {
+	return &tenantService{
+		repository: repository,
+		known:      make(map[string]*domain.Tenant),
+	}
+}
+
+func (s *tenantService) CreateTenant(req *domain.TenantRequest) (*domain.Tenant, error) {
+	if !domain.IsValidTenantID(req.ID) {
+		return nil, errors.New("tenant ID must be a lowercase slug of at most 63 characters")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("tenant name is required")
+	}
+
+	if _, err := s.GetTenant(req.ID); err == nil {
+		return nil, errors.New("tenant already exists")
+	} else if err.Error() != "tenant not found" {
+		return nil, err
+	}
+
+	tenant := &domain.Tenant{ID: req.ID, Name: name}
+	if err := s.repository.Create(tenant); err != nil {
+		return nil, fmt.Errorf("failed to save tenant: %w", err)
+	}
+	return tenant, nil
+}
+
+// GetTenant is called for every request, so found tenants are served from memory
+func (s *tenantService) GetTenant(id string) (*domain.Tenant, error) {
+	s.mu.Lock()
+	tenant, ok := s.known[id]
+	s.mu.Unlock()
+	if ok {
+		return tenant, nil
+	}
+
+	tenant, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.known[id] = tenant
+	s.mu.Unlock()
+	return tenant, nil
+}
+
+func (s *tenantService) ListTenants() ([]*domain.Tenant, error) {
+	return s.repository.List()
+}
+
+func (s *tenantService) EnsureTenant(id, name string) error {
+	_, err := s.CreateTenant(&domain.TenantRequest{ID: id, Name: name})
+	if err != nil && err.Error() != "tenant already exists" {
+		return err
+	}
+	return nil
+}
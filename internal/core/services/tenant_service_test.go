@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) Create(tenant *domain.Tenant) error {
+	args := m.Called(tenant)
+	return args.Error(0)
+}
+
+func (m *MockTenantRepository) GetByID(id string) (*domain.Tenant, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) List() ([]*domain.Tenant, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Tenant), args.Error(1)
+}
+
+func TestTenantService_CreateTenant(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *domain.TenantRequest
+		setupMocks    func(*MockTenantRepository)
+		expectedError string
+	}{
+		{
+			name:    "successful creation",
+			request: &domain.TenantRequest{ID: "kanto", Name: " Kanto League "},
+			setupMocks: func(repo *MockTenantRepository) {
+				repo.On("GetByID", "kanto").Return(nil, errors.New("tenant not found"))
+				repo.On("Create", &domain.Tenant{ID: "kanto", Name: "Kanto League"}).Return(nil)
+			},
+		},
+		{
+			name:          "invalid ID",
+			request:       &domain.TenantRequest{ID: "Kanto League", Name: "Kanto League"},
+			setupMocks:    func(repo *MockTenantRepository) {},
+			expectedError: "tenant ID must be a lowercase slug of at most 63 characters",
+		},
+		{
+			name:          "blank name",
+			request:       &domain.TenantRequest{ID: "kanto", Name: " "},
+			setupMocks:    func(repo *MockTenantRepository) {},
+			expectedError: "tenant name is required",
+		},
+		{
+			name:    "duplicate ID",
+			request: &domain.TenantRequest{ID: "kanto", Name: "Kanto League"},
+			setupMocks: func(repo *MockTenantRepository) {
+				repo.On("GetByID", "kanto").Return(&domain.Tenant{ID: "kanto"}, nil)
+			},
+			expectedError: "tenant already exists",
+		},
+		{
+			name:    "database error",
+			request: &domain.TenantRequest{ID: "kanto", Name: "Kanto League"},
+			setupMocks: func(repo *MockTenantRepository) {
+				repo.On("GetByID", "kanto").Return(nil, errors.New("tenant not found"))
+				repo.On("Create", mock.AnythingOfType("*domain.Tenant")).Return(errors.New("database error"))
+			},
+			expectedError: "failed to save tenant: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTenantRepository)
+			tt.setupMocks(mockRepo)
+
+			service := NewTenantService(mockRepo)
+			tenant, err := service.CreateTenant(tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, tenant)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Kanto League", tenant.Name)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantService_GetTenant_CachesFoundTenants(t *testing.T) {
+	mockRepo := new(MockTenantRepository)
+	mockRepo.On("GetByID", "kanto").Return(&domain.Tenant{ID: "kanto"}, nil).Once()
+	mockRepo.On("GetByID", "hoenn").Return(nil, errors.New("tenant not found")).Twice()
+	service := NewTenantService(mockRepo)
+
+	for i := 0; i < 2; i++ {
+		tenant, err := service.GetTenant("kanto")
+		assert.NoError(t, err)
+		assert.Equal(t, "kanto", tenant.ID)
+
+		// A missing tenant is looked up again, since it may be created later
+		_, err = service.GetTenant("hoenn")
+		assert.EqualError(t, err, "tenant not found")
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTenantService_EnsureTenant(t *testing.T) {
+	mockRepo := new(MockTenantRepository)
+	mockRepo.On("GetByID", domain.DefaultTenantID).Return(nil, errors.New("tenant not found")).Once()
+	mockRepo.On("Create", &domain.Tenant{ID: domain.DefaultTenantID, Name: "Default"}).Return(nil).Once()
+	service := NewTenantService(mockRepo)
+
+	assert.NoError(t, service.EnsureTenant(domain.DefaultTenantID, "Default"))
+
+	mockRepo.On("GetByID", domain.DefaultTenantID).Return(&domain.Tenant{ID: domain.DefaultTenantID}, nil).Once()
+	assert.NoError(t, service.EnsureTenant(domain.DefaultTenantID, "Default"))
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"strings"
+	"time"
+)
+
+type trainerService struct {
+	trainerRepository ports.TrainerRepository
+	pokemonRepository ports.PokemonRepository
+}
+
+func NewTrainerService(trainerRepository ports.TrainerRepository, pokemonRepository ports.PokemonRepository) ports.TrainerService {
+	return &trainerService{
+		trainerRepository: trainerRepository,
+		pokemonRepository: pokemonRepository,
+	}
+}
+
+func (s *trainerService) ForTenant(tenantID string) ports.TrainerService {
+	return &trainerService{
+		trainerRepository: s.trainerRepository.ForTenant(tenantID),
+		pokemonRepository: s.pokemonRepository.ForTenant(tenantID),
+	}
+}
+
+func (s *trainerService) CreateTrainer(req *domain.TrainerRequest) (*domain.Trainer, error) {
+	trainer := &domain.Trainer{Name: strings.TrimSpace(req.Name)}
+
+	existing, err := s.trainerRepository.GetByName(trainer.Name)
+	if err == nil && existing != nil {
+		return nil, errors.New("trainer with this name already exists")
+	}
+	if err != nil && err.Error() != "trainer not found" {
+		return nil, err
+	}
+
+	if err := s.trainerRepository.Create(trainer); err != nil {
+		return nil, fmt.Errorf("failed to save trainer: %w", err)
+	}
+	return trainer, nil
+}
+
+func (s *trainerService) GetTrainer(id uint) (*domain.Trainer, error) {
+	return s.trainerRepository.GetByID(id)
+}
+
+func (s *trainerService) ListTrainers() ([]*domain.Trainer, error) {
+	return s.trainerRepository.List()
+}
+
+func (s *trainerService) AddPokemon(trainerID uint, req *domain.OwnedPokemonRequest) (*domain.OwnedPokemon, error) {
+	if _, err := s.trainerRepository.GetByID(trainerID); err != nil {
+		return nil, err
+	}
+
+	owned := &domain.OwnedPokemon{TrainerID: trainerID}
+	if err := s.applyRequest(owned, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.trainerRepository.AddPokemon(owned); err != nil {
+		return nil, fmt.Errorf("failed to save owned pokemon: %w", err)
+	}
+
+	owned.ComputeStats()
+	return owned, nil
+}
+
+func (s *trainerService) GetPokemon(trainerID, id uint) (*domain.OwnedPokemon, error) {
+	owned, err := s.trainerRepository.GetPokemon(trainerID, id)
+	if err != nil {
+		return nil, err
+	}
+	owned.ComputeStats()
+	return owned, nil
+}
+
+func (s *trainerService) ListPokemon(trainerID uint) ([]*domain.OwnedPokemon, error) {
+	if _, err := s.trainerRepository.GetByID(trainerID); err != nil {
+		return nil, err
+	}
+
+	owned, err := s.trainerRepository.ListPokemon(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range owned {
+		o.ComputeStats()
+	}
+	return owned, nil
+}
+
+func (s *trainerService) UpdatePokemon(trainerID, id uint, req *domain.OwnedPokemonRequest, expected *time.Time) (*domain.OwnedPokemon, error) {
+	owned, err := s.trainerRepository.GetPokemon(trainerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(owned, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.trainerRepository.UpdatePokemon(owned, expected); err != nil {
+		// The owned Pokemon may have been released since it was read
+		if err.Error() == "owned pokemon not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save owned pokemon: %w", err)
+	}
+
+	owned.ComputeStats()
+	return owned, nil
+}
+
+func (s *trainerService) ReleasePokemon(trainerID, id uint, expected *time.Time) error {
+	return s.trainerRepository.DeletePokemon(trainerID, id, expected)
+}
+
+// applyRequest copies the request onto owned, resolving its species from the catalog and validating the result
+func (s *trainerService) applyRequest(owned *domain.OwnedPokemon, req *domain.OwnedPokemonRequest) error {
+	owned.ApplyRequest(req)
+	if err := owned.Validate(); err != nil {
+		return err
+	}
+
+	species, err := s.pokemonRepository.GetByID(req.SpeciesID)
+	if err != nil || species == nil {
+		return errors.New("species not found")
+	}
+	owned.Species = species
+	return nil
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrainerRepository struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockTrainerRepository) ForTenant(tenantID string) ports.TrainerRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockTrainerRepository) Create(trainer *domain.Trainer) error {
+	args := m.Called(trainer)
+	return args.Error(0)
+}
+
+func (m *MockTrainerRepository) GetByID(id uint) (*domain.Trainer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerRepository) GetByName(name string) (*domain.Trainer, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerRepository) List() ([]*domain.Trainer, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerRepository) AddPokemon(owned *domain.OwnedPokemon) error {
+	args := m.Called(owned)
+	return args.Error(0)
+}
+
+func (m *MockTrainerRepository) GetPokemon(trainerID, id uint) (*domain.OwnedPokemon, error) {
+	args := m.Called(trainerID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OwnedPokemon), args.Error(1)
+}
+
+func (m *MockTrainerRepository) ListPokemon(trainerID uint) ([]*domain.OwnedPokemon, error) {
+	args := m.Called(trainerID)
+	return args.Get(0).([]*domain.OwnedPokemon), args.Error(1)
+}
+
+func (m *MockTrainerRepository) UpdatePokemon(owned *domain.OwnedPokemon, expected *time.Time) error {
+	args := m.Called(owned, expected)
+	return args.Error(0)
+}
+
+func (m *MockTrainerRepository) DeletePokemon(trainerID, id uint, expected *time.Time) error {
+	args := m.Called(trainerID, id, expected)
+	return args.Error(0)
+}
+
+var garchomp = &domain.Pokemon{ID: 445, Name: "garchomp", Type1: "dragon", Type2: "ground", HP: 108, Attack: 130, Defense: 95, SpAttack: 80, SpDefense: 85, Speed: 102}
+
+func TestTrainerService_AddPokemon(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *domain.OwnedPokemonRequest
+		setupMocks    func(*MockTrainerRepository, *MockPokemonRepository)
+		expectedError string
+		expectedStats *domain.StatSpread
+	}{
+		{
+			// Bulbapedia's worked example of the stat formulas
+			name: "computes actual stats",
+			request: &domain.OwnedPokemonRequest{
+				SpeciesID: 445,
+				Nickname:  " Chompy ",
+				Level:     78,
+				Nature:    "Adamant",
+				IVs:       domain.StatSpread{HP: 24, Attack: 12, Defense: 30, SpAttack: 16, SpDefense: 23, Speed: 5},
+				EVs:       domain.StatSpread{HP: 74, Attack: 190, Defense: 91, SpAttack: 48, SpDefense: 84, Speed: 23},
+			},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1, Name: "cynthia"}, nil)
+				pokemon.On("GetByID", uint(445)).Return(garchomp, nil)
+				trainers.On("AddPokemon", mock.AnythingOfType("*domain.OwnedPokemon")).Return(nil)
+			},
+			expectedStats: &domain.StatSpread{HP: 289, Attack: 278, Defense: 193, SpAttack: 135, SpDefense: 171, Speed: 171},
+		},
+		{
+			name:    "defaults to level 50 hardy",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+				pokemon.On("GetByID", uint(445)).Return(garchomp, nil)
+				trainers.On("AddPokemon", mock.AnythingOfType("*domain.OwnedPokemon")).Return(nil)
+			},
+			expectedStats: &domain.StatSpread{HP: 168, Attack: 135, Defense: 100, SpAttack: 85, SpDefense: 90, Speed: 107},
+		},
+		{
+			name:    "trainer not found",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(nil, errors.New("trainer not found"))
+			},
+			expectedError: "trainer not found",
+		},
+		{
+			name:    "species not found",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 9999},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+				pokemon.On("GetByID", uint(9999)).Return((*domain.Pokemon)(nil), errors.New("pokemon not found"))
+			},
+			expectedError: "species not found",
+		},
+		{
+			name:    "level out of range",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445, Level: 101},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+			},
+			expectedError: "level must be between 1 and 100",
+		},
+		{
+			name:    "unknown nature",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445, Nature: "grumpy"},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+			},
+			expectedError: "unknown nature",
+		},
+		{
+			name:    "IV above 31",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445, IVs: domain.StatSpread{Speed: 32}},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+			},
+			expectedError: "IVs must be between 0 and 31",
+		},
+		{
+			name:    "EV above 252",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445, EVs: domain.StatSpread{Attack: 253}},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+			},
+			expectedError: "EVs must be between 0 and 252",
+		},
+		{
+			name:    "EVs above 510 total",
+			request: &domain.OwnedPokemonRequest{SpeciesID: 445, EVs: domain.StatSpread{Attack: 252, Speed: 252, HP: 8}},
+			setupMocks: func(trainers *MockTrainerRepository, pokemon *MockPokemonRepository) {
+				trainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+			},
+			expectedError: "EVs cannot total more than 510",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTrainers := new(MockTrainerRepository)
+			mockPokemon := new(MockPokemonRepository)
+			tt.setupMocks(mockTrainers, mockPokemon)
+
+			service := NewTrainerService(mockTrainers, mockPokemon)
+			result, err := service.AddPokemon(1, tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(1), result.TrainerID)
+				assert.Equal(t, tt.expectedStats, result.Stats)
+			}
+
+			mockTrainers.AssertExpectations(t)
+			mockPokemon.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrainerService_ListPokemon(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockTrainers.On("GetByID", uint(1)).Return(&domain.Trainer{ID: 1}, nil)
+	mockTrainers.On("ListPokemon", uint(1)).Return([]*domain.OwnedPokemon{
+		{ID: 1, TrainerID: 1, SpeciesID: 445, Species: garchomp, Level: 100, Nature: "jolly"},
+		{ID: 2, TrainerID: 1, SpeciesID: 445, Level: 5, Nature: "hardy"},
+	}, nil)
+
+	service := NewTrainerService(mockTrainers, new(MockPokemonRepository))
+	result, err := service.ListPokemon(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, 229, result[0].Stats.Speed)
+	assert.Equal(t, 148, result[0].Stats.SpAttack)
+	assert.Nil(t, result[1].Stats)
+}
+
+func TestTrainerService_UpdatePokemon(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockTrainers.On("GetPokemon", uint(1), uint(2)).Return(&domain.OwnedPokemon{ID: 2, TrainerID: 1, SpeciesID: 445, Level: 5, Nature: "hardy"}, nil)
+	mockPokemon.On("GetByID", uint(445)).Return(garchomp, nil)
+	mockTrainers.On("UpdatePokemon", mock.AnythingOfType("*domain.OwnedPokemon"), (*time.Time)(nil)).Return(nil)
+
+	service := NewTrainerService(mockTrainers, mockPokemon)
+	result, err := service.UpdatePokemon(1, 2, &domain.OwnedPokemonRequest{SpeciesID: 445, Level: 100, HeldItem: "Choice Scarf", Shiny: true}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, result.Level)
+	assert.Equal(t, "choice scarf", result.HeldItem)
+	assert.True(t, result.Shiny)
+	assert.NotNil(t, result.Stats)
+	mockTrainers.AssertExpectations(t)
+}
+
+func TestTrainerService_UpdatePokemon_Released(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockTrainers.On("GetPokemon", uint(1), uint(2)).Return(&domain.OwnedPokemon{ID: 2, TrainerID: 1, SpeciesID: 445, Level: 5, Nature: "hardy"}, nil)
+	mockPokemon.On("GetByID", uint(445)).Return(garchomp, nil)
+	// The Pokemon is released between the read and the write
+	mockTrainers.On("UpdatePokemon", mock.AnythingOfType("*domain.OwnedPokemon"), (*time.Time)(nil)).Return(errors.New("owned pokemon not found"))
+
+	service := NewTrainerService(mockTrainers, mockPokemon)
+	_, err := service.UpdatePokemon(1, 2, &domain.OwnedPokemonRequest{SpeciesID: 445, Level: 100}, nil)
+
+	assert.EqualError(t, err, "owned pokemon not found")
+}
+
+func TestTrainerService_ReleasePokemon(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockTrainers.On("DeletePokemon", uint(1), uint(2), (*time.Time)(nil)).Return(errors.New("owned pokemon not found"))
+
+	service := NewTrainerService(mockTrainers, new(MockPokemonRepository))
+	assert.EqualError(t, service.ReleasePokemon(1, 2, nil), "owned pokemon not found")
+}
+
+func TestTrainerService_CreateTrainer(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockTrainers.On("GetByName", "ash").Return(nil, errors.New("trainer not found"))
+	mockTrainers.On("Create", mock.AnythingOfType("*domain.Trainer")).Return(nil)
+
+	service := NewTrainerService(mockTrainers, new(MockPokemonRepository))
+	trainer, err := service.CreateTrainer(&domain.TrainerRequest{Name: "  ash "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ash", trainer.Name)
+}
+
+func TestTrainerService_CreateTrainer_Duplicate(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockTrainers.On("GetByName", "ash").Return(&domain.Trainer{ID: 1, Name: "ash"}, nil)
+
+	service := NewTrainerService(mockTrainers, new(MockPokemonRepository))
+	_, err := service.CreateTrainer(&domain.TrainerRequest{Name: "ash"})
+
+	assert.EqualError(t, err, "trainer with this name already exists")
+	mockTrainers.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTrainerService_ForTenant(t *testing.T) {
+	mockTrainers := new(MockTrainerRepository)
+	mockPokemon := new(MockPokemonRepository)
+	mockTrainers.On("List").Return([]*domain.Trainer{}, nil)
+
+	_, err := NewTrainerService(mockTrainers, mockPokemon).ForTenant("kanto").ListTrainers()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kanto", mockTrainers.tenantID)
+	assert.Equal(t, "kanto", mockPokemon.tenantID)
+	mockTrainers.AssertExpectations(t)
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"time"
+)
+
+const (
+	// webhookBatchSize bounds how many deliveries one DeliverDue call attempts
+	webhookBatchSize = 50
+	// maxWebhookAttempts is how many times a delivery is tried before it is dead-lettered
+	maxWebhookAttempts = 10
+	// webhookBaseBackoff doubles after every failed attempt, up to webhookMaxBackoff
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = time.Hour
+	// deliveryHistorySize is how many recent deliveries are listed per webhook
+	deliveryHistorySize = 100
+)
+
+type webhookService struct {
+	repository ports.WebhookRepository
+	sender     ports.WebhookSender
+	now        func() time.Time
+}
+
+func NewWebhookService(repository ports.WebhookRepository, sender ports.WebhookSender) ports.WebhookService {
+	return &webhookService{
+		repository: repository,
+		sender:     sender,
+		now:        time.Now,
+	}
+}
+
+func (s *webhookService) ForTenant(tenantID string) ports.WebhookService {
+	return &webhookService{
+		repository: s.repository.ForTenant(tenantID),
+		sender:     s.sender,
+		now:        s.now,
+	}
+}
+
+func (s *webhookService) CreateWebhook(req *domain.WebhookRequest) (*domain.CreatedWebhook, error) {
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, errors.New("webhook URL must be an absolute http or https URL")
+	}
+	if len(req.EventTypes) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+	for _, eventType := range req.EventTypes {
+		if !domain.IsValidEventType(eventType) {
+			return nil, errors.New("unknown event type")
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	webhook := &domain.Webhook{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     secret,
+	}
+	if err := s.repository.Create(webhook); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	return &domain.CreatedWebhook{Webhook: *webhook, Secret: secret}, nil
+}
+
+func (s *webhookService) GetWebhook(id uint) (*domain.Webhook, error) {
+	return s.repository.GetByID(id)
+}
+
+func (s *webhookService) ListWebhooks() ([]*domain.Webhook, error) {
+	return s.repository.List()
+}
+
+func (s *webhookService) DeleteWebhook(id uint) error {
+	return s.repository.Delete(id)
+}
+
+func (s *webhookService) ListDeliveries(webhookID uint) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.repository.GetByID(webhookID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListDeliveries(webhookID, deliveryHistorySize)
+}
+
+func (s *webhookService) RetryDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	delivery, err := s.repository.GetDelivery(webhookID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = s.now()
+	if err := s.repository.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Publish queues a delivery for every webhook of the message's tenant subscribed to the event. A message the relay
+// publishes again is not queued twice for the same webhook.
+func (s *webhookService) Publish(message *domain.OutboxMessage) error {
+	webhooks, err := s.repository.ForTenant(message.TenantID).List()
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	now := s.now()
+	var payload []byte
+	var deliveries []*domain.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Wants(message.Type) {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(domain.WebhookPayload{
+				ID:        message.DedupID,
+				Type:      message.Type,
+				Pokemon:   message.Pokemon,
+				CreatedAt: message.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			TenantID:      webhook.TenantID,
+			DedupID:       message.DedupID,
+			EventType:     message.Type,
+			Payload:       payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+		})
+	}
+
+	if err := s.repository.CreateDeliveries(deliveries); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) DeliverDue() (int, error) {
+	deliveries, err := s.repository.ListDueDeliveries(s.now(), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[uint]*domain.Webhook)
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.repository.ForTenant(delivery.TenantID).GetByID(delivery.WebhookID)
+			if err != nil && err.Error() != "webhook not found" {
+				return 0, err
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		// A delivery left behind by a deleted webhook is dead-lettered, so it neither blocks the queue nor comes back
+		if webhook == nil {
+			delivery.Status = domain.DeliveryDead
+			delivery.LastError = "webhook not found"
+		} else {
+			status, sendErr := s.sender.Send(webhook, delivery)
+			s.recordAttempt(delivery, status, sendErr)
+		}
+		// The webhook may have been deleted, with its deliveries, while this one was being sent
+		if err := s.repository.UpdateDelivery(delivery); err != nil && err.Error() != "webhook delivery not found" {
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (s *webhookService) recordAttempt(delivery *domain.WebhookDelivery, status int, err error) {
+	now := s.now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+
+	if err == nil {
+		delivery.Status = domain.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= maxWebhookAttempts {
+		delivery.Status = domain.DeliveryDead
+		return
+	}
+	delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+}
+
+// webhookBackoff is the wait after the given number of failed attempts
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff << (attempts - 1)
+	if backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
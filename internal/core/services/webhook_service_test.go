@@ -0,0 +1,411 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"pokemon-api/internal/core/domain"
+	"pokemon-api/internal/core/ports"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+	// tenantID is the tenant the code under test last scoped the mock to
+	tenantID string
+}
+
+// ForTenant records the tenant and returns the mock itself, so expectations hold whichever tenant is used
+func (m *MockWebhookRepository) ForTenant(tenantID string) ports.WebhookRepository {
+	m.tenantID = tenantID
+	return m
+}
+
+func (m *MockWebhookRepository) Create(webhook *domain.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetByID(id uint) (*domain.Webhook, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) List() ([]*domain.Webhook, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	args := m.Called(deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListDueDeliveries(now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(webhookID uint, limit int) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(delivery *domain.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	args := m.Called(webhook, delivery)
+	return args.Int(0), args.Error(1)
+}
+
+func newTestWebhookService(repo *MockWebhookRepository, sender *MockWebhookSender, now time.Time) *webhookService {
+	service := NewWebhookService(repo, sender).(*webhookService)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *domain.WebhookRequest
+		setupMock     func(*MockWebhookRepository)
+		expectedError string
+		checkSecret   func(*testing.T, string)
+	}{
+		{
+			name: "generated secret",
+			req:  &domain.WebhookRequest{URL: "https://example.com/hooks", EventTypes: []string{domain.EventPokemonCreated}},
+			setupMock: func(repo *MockWebhookRepository) {
+				repo.On("Create", mock.MatchedBy(func(w *domain.Webhook) bool {
+					return len(w.Secret) == 64
+				})).Return(nil)
+			},
+			checkSecret: func(t *testing.T, secret string) {
+				assert.Len(t, secret, 64)
+			},
+		},
+		{
+			name: "given secret",
+			req:  &domain.WebhookRequest{URL: "http://localhost:9000", EventTypes: []string{domain.EventPokemonDeleted}, Secret: "s3cret"},
+			setupMock: func(repo *MockWebhookRepository) {
+				repo.On("Create", mock.AnythingOfType("*domain.Webhook")).Return(nil)
+			},
+			checkSecret: func(t *testing.T, secret string) {
+				assert.Equal(t, "s3cret", secret)
+			},
+		},
+		{
+			name:          "relative URL",
+			req:           &domain.WebhookRequest{URL: "/hooks", EventTypes: []string{domain.EventPokemonCreated}},
+			setupMock:     func(repo *MockWebhookRepository) {},
+			expectedError: "webhook URL must be an absolute http or https URL",
+		},
+		{
+			name:          "unsupported scheme",
+			req:           &domain.WebhookRequest{URL: "ftp://example.com", EventTypes: []string{domain.EventPokemonCreated}},
+			setupMock:     func(repo *MockWebhookRepository) {},
+			expectedError: "webhook URL must be an absolute http or https URL",
+		},
+		{
+			name:          "no event types",
+			req:           &domain.WebhookRequest{URL: "https://example.com", EventTypes: []string{}},
+			setupMock:     func(repo *MockWebhookRepository) {},
+			expectedError: "at least one event type is required",
+		},
+		{
+			name:          "unknown event type",
+			req:           &domain.WebhookRequest{URL: "https://example.com", EventTypes: []string{"pokemon.evolved"}},
+			setupMock:     func(repo *MockWebhookRepository) {},
+			expectedError: "unknown event type",
+		},
+		{
+			name: "repository error",
+			req:  &domain.WebhookRequest{URL: "https://example.com", EventTypes: []string{domain.EventPokemonCreated}},
+			setupMock: func(repo *MockWebhookRepository) {
+				repo.On("Create", mock.AnythingOfType("*domain.Webhook")).Return(errors.New("database error"))
+			},
+			expectedError: "failed to save webhook: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockWebhookRepository)
+			tt.setupMock(mockRepo)
+			service := NewWebhookService(mockRepo, new(MockWebhookSender))
+
+			result, err := service.CreateWebhook(tt.req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.req.URL, result.URL)
+				tt.checkSecret(t, result.Secret)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := new(MockWebhookRepository)
+	mockRepo.On("List").Return([]*domain.Webhook{
+		{ID: 1, TenantID: "kanto", EventTypes: []string{domain.EventPokemonCreated, domain.EventPokemonDeleted}},
+		{ID: 2, TenantID: "kanto", EventTypes: []string{domain.EventPokemonUpdated}},
+		{ID: 3, TenantID: "kanto", EventTypes: []string{domain.EventPokemonCreated}},
+	}, nil)
+
+	var queued []*domain.WebhookDelivery
+	mockRepo.On("CreateDeliveries", mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(0).([]*domain.WebhookDelivery)
+	}).Return(nil)
+
+	service := newTestWebhookService(mockRepo, new(MockWebhookSender), now)
+	err := service.Publish(&domain.OutboxMessage{
+		DedupID:   "abc123",
+		TenantID:  "kanto",
+		Type:      domain.EventPokemonCreated,
+		PokemonID: 25,
+		Pokemon:   &domain.Pokemon{ID: 25, Name: "pikachu"},
+	})
+
+	assert.NoError(t, err)
+	// Only the webhooks of the message's tenant are listed
+	assert.Equal(t, "kanto", mockRepo.tenantID)
+	assert.Len(t, queued, 2)
+	assert.Equal(t, uint(1), queued[0].WebhookID)
+	assert.Equal(t, uint(3), queued[1].WebhookID)
+	for _, delivery := range queued {
+		assert.Equal(t, "abc123", delivery.DedupID)
+		assert.Equal(t, "kanto", delivery.TenantID)
+		assert.Equal(t, domain.DeliveryPending, delivery.Status)
+		assert.Equal(t, now, delivery.NextAttemptAt)
+	}
+
+	var payload domain.WebhookPayload
+	assert.NoError(t, json.Unmarshal(queued[0].Payload, &payload))
+	assert.Equal(t, "abc123", payload.ID)
+	assert.Equal(t, domain.EventPokemonCreated, payload.Type)
+	assert.Equal(t, "pikachu", payload.Pokemon.Name)
+}
+
+func TestWebhookService_Publish_Failures(t *testing.T) {
+	message := &domain.OutboxMessage{DedupID: "abc123", Type: domain.EventPokemonCreated}
+
+	mockRepo := new(MockWebhookRepository)
+	mockRepo.On("List").Return(nil, errors.New("database error")).Once()
+	service := NewWebhookService(mockRepo, new(MockWebhookSender))
+	assert.EqualError(t, service.Publish(message), "failed to list webhooks: database error")
+
+	mockRepo.On("List").Return([]*domain.Webhook{{ID: 1, EventTypes: []string{domain.EventPokemonCreated}}}, nil)
+	mockRepo.On("CreateDeliveries", mock.Anything).Return(errors.New("database error"))
+	assert.EqualError(t, service.Publish(message), "failed to queue webhook deliveries: database error")
+}
+
+func TestWebhookService_DeliverDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		attempts          int
+		status            int
+		sendErr           error
+		expectedStatus    string
+		expectedAttempts  int
+		expectedNext      time.Time
+		expectedDelivered bool
+	}{
+		{
+			name:              "delivered",
+			status:            204,
+			expectedStatus:    domain.DeliverySucceeded,
+			expectedAttempts:  1,
+			expectedNext:      now,
+			expectedDelivered: true,
+		},
+		{
+			name:             "first failure backs off 30 seconds",
+			status:           500,
+			sendErr:          errors.New("webhook responded with status 500"),
+			expectedStatus:   domain.DeliveryPending,
+			expectedAttempts: 1,
+			expectedNext:     now.Add(30 * time.Second),
+		},
+		{
+			name:             "backoff doubles",
+			attempts:         3,
+			sendErr:          errors.New("connection refused"),
+			expectedStatus:   domain.DeliveryPending,
+			expectedAttempts: 4,
+			expectedNext:     now.Add(4 * time.Minute),
+		},
+		{
+			name:             "backoff is capped",
+			attempts:         7,
+			sendErr:          errors.New("connection refused"),
+			expectedStatus:   domain.DeliveryPending,
+			expectedAttempts: 8,
+			expectedNext:     now.Add(time.Hour),
+		},
+		{
+			name:             "last attempt dead-letters",
+			attempts:         9,
+			sendErr:          errors.New("connection refused"),
+			expectedStatus:   domain.DeliveryDead,
+			expectedAttempts: 10,
+			expectedNext:     now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &domain.Webhook{ID: 1, URL: "https://example.com", Secret: "s"}
+			delivery := &domain.WebhookDelivery{ID: 7, WebhookID: 1, TenantID: "kanto", Status: domain.DeliveryPending, Attempts: tt.attempts, NextAttemptAt: now}
+
+			mockRepo := new(MockWebhookRepository)
+			mockSender := new(MockWebhookSender)
+			mockRepo.On("ListDueDeliveries", now, webhookBatchSize).Return([]*domain.WebhookDelivery{delivery}, nil)
+			mockRepo.On("GetByID", uint(1)).Return(webhook, nil)
+			mockSender.On("Send", webhook, delivery).Return(tt.status, tt.sendErr)
+			mockRepo.On("UpdateDelivery", delivery).Return(nil)
+
+			service := newTestWebhookService(mockRepo, mockSender, now)
+			count, err := service.DeliverDue()
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+			// The webhook is looked up in the delivery's tenant
+			assert.Equal(t, "kanto", mockRepo.tenantID)
+			assert.Equal(t, tt.expectedStatus, delivery.Status)
+			assert.Equal(t, tt.expectedAttempts, delivery.Attempts)
+			assert.Equal(t, tt.expectedNext, delivery.NextAttemptAt)
+			assert.Equal(t, tt.status, delivery.ResponseStatus)
+			assert.Equal(t, tt.expectedDelivered, delivery.DeliveredAt != nil)
+			if tt.sendErr != nil {
+				assert.Equal(t, tt.sendErr.Error(), delivery.LastError)
+			}
+			mockRepo.AssertExpectations(t)
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_DeliverDue_LooksUpEachWebhookOnce(t *testing.T) {
+	now := time.Now()
+	webhook := &domain.Webhook{ID: 1}
+	deliveries := []*domain.WebhookDelivery{{ID: 1, WebhookID: 1}, {ID: 2, WebhookID: 1}}
+
+	mockRepo := new(MockWebhookRepository)
+	mockSender := new(MockWebhookSender)
+	mockRepo.On("ListDueDeliveries", now, webhookBatchSize).Return(deliveries, nil)
+	mockRepo.On("GetByID", uint(1)).Return(webhook, nil).Once()
+	mockSender.On("Send", webhook, mock.Anything).Return(200, nil).Twice()
+	mockRepo.On("UpdateDelivery", mock.Anything).Return(nil).Twice()
+
+	service := newTestWebhookService(mockRepo, mockSender, now)
+	count, err := service.DeliverDue()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockRepo.AssertExpectations(t)
+	mockSender.AssertExpectations(t)
+}
+
+func TestWebhookService_DeliverDue_DeletedWebhook(t *testing.T) {
+	now := time.Now()
+	webhook := &domain.Webhook{ID: 2}
+	orphan := &domain.WebhookDelivery{ID: 1, WebhookID: 1, Status: domain.DeliveryPending}
+	deleted := &domain.WebhookDelivery{ID: 2, WebhookID: 2, Status: domain.DeliveryPending}
+	other := &domain.WebhookDelivery{ID: 3, WebhookID: 2, Status: domain.DeliveryPending}
+
+	mockRepo := new(MockWebhookRepository)
+	mockSender := new(MockWebhookSender)
+	mockRepo.On("ListDueDeliveries", now, webhookBatchSize).Return([]*domain.WebhookDelivery{orphan, deleted, other}, nil)
+	mockRepo.On("GetByID", uint(1)).Return(nil, errors.New("webhook not found"))
+	mockRepo.On("GetByID", uint(2)).Return(webhook, nil)
+	mockSender.On("Send", webhook, mock.Anything).Return(200, nil).Twice()
+	mockRepo.On("UpdateDelivery", orphan).Return(nil)
+	// Webhook 2 is deleted while its first delivery is being sent
+	mockRepo.On("UpdateDelivery", deleted).Return(errors.New("webhook delivery not found"))
+	mockRepo.On("UpdateDelivery", other).Return(nil)
+
+	service := newTestWebhookService(mockRepo, mockSender, now)
+	count, err := service.DeliverDue()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, domain.DeliveryDead, orphan.Status)
+	assert.Equal(t, "webhook not found", orphan.LastError)
+	assert.Equal(t, domain.DeliverySucceeded, other.Status)
+	mockRepo.AssertExpectations(t)
+	mockSender.AssertExpectations(t)
+}
+
+func TestWebhookService_RetryDelivery(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dead := &domain.WebhookDelivery{ID: 7, WebhookID: 1, Status: domain.DeliveryDead, Attempts: 10, NextAttemptAt: now.Add(-time.Hour)}
+
+	mockRepo := new(MockWebhookRepository)
+	mockRepo.On("GetDelivery", uint(1), uint(7)).Return(dead, nil)
+	mockRepo.On("GetDelivery", uint(1), uint(8)).Return(nil, errors.New("webhook delivery not found"))
+	mockRepo.On("UpdateDelivery", dead).Return(nil)
+	service := newTestWebhookService(mockRepo, new(MockWebhookSender), now)
+
+	result, err := service.RetryDelivery(1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, result.Status)
+	assert.Zero(t, result.Attempts)
+	assert.Equal(t, now, result.NextAttemptAt)
+
+	_, err = service.RetryDelivery(1, 8)
+	assert.EqualError(t, err, "webhook delivery not found")
+}
+
+func TestWebhookService_ListDeliveries_UnknownWebhook(t *testing.T) {
+	mockRepo := new(MockWebhookRepository)
+	mockRepo.On("GetByID", uint(9)).Return(nil, errors.New("webhook not found"))
+	service := NewWebhookService(mockRepo, new(MockWebhookSender))
+
+	_, err := service.ListDeliveries(9)
+
+	assert.EqualError(t, err, "webhook not found")
+	mockRepo.AssertNotCalled(t, "ListDeliveries", mock.Anything, mock.Anything)
+}